@@ -0,0 +1,20 @@
+# Mutes the detector for the duration of a deployment,
+# the muting rule expires on its own once the duration has passed.
+action "signalfx_mute_detector" "deploy" {
+  config {
+    detector_id = signalfx_detector.latency.id
+    duration    = "2h"
+    description = "Deploying ${var.release}"
+  }
+}
+
+resource "terraform_data" "release" {
+  input = var.release
+
+  lifecycle {
+    action_trigger {
+      events  = [before_create, before_update]
+      actions = [action.signalfx_mute_detector.deploy]
+    }
+  }
+}
@@ -0,0 +1,8 @@
+# Rotates the org token secret on demand using:
+#   terraform apply -invoke=action.signalfx_rotate_org_token.ingest
+action "signalfx_rotate_org_token" "ingest" {
+  config {
+    name         = signalfx_org_token.ingest.name
+    grace_period = "1h"
+  }
+}
@@ -0,0 +1,13 @@
+# Checks the detector is accepted by the API without creating it using:
+#   terraform apply -invoke=action.signalfx_validate_detector.cpu
+action "signalfx_validate_detector" "cpu" {
+  config {
+    name         = "High CPU utilization"
+    program_text = "detect(when(data('cpu.utilization').mean(by=['host']) > 90)).publish('High CPU')"
+
+    rule {
+      detect_label = "High CPU"
+      severity     = "Critical"
+    }
+  }
+}
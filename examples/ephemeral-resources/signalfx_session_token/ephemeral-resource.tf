@@ -0,0 +1,14 @@
+# Creates a short lived session token that is never written to state.
+ephemeral "signalfx_session_token" "example" {
+  email           = var.email
+  password        = var.password
+  organization_id = var.organization_id
+}
+
+# The token can be passed to anything that accepts ephemeral values,
+# such as the configuration of another provider.
+provider "signalfx" {
+  alias      = "session"
+  api_url    = "https://api.us1.signalfx.com"
+  auth_token = ephemeral.signalfx_session_token.example.access_token
+}
@@ -2,7 +2,7 @@
 provider "signalfx" {
   auth_token = "${var.signalfx_auth_token}"
   # If your organization uses a different realm
-  # api_url = "https://api.<realm>.observability.splunkcloud.com"
+  # realm = "us1"
 }
 
 # Create a new detector
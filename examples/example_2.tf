@@ -4,7 +4,7 @@ provider "signalfx" {
   password        = "${var.service_account_password}"
   organization_id = "${var.service_account_org_id}"
   # If your organization uses a different realm
-  # api_url = "https://api.<realm>.observability.splunkcloud.com"
+  # realm = "us1"
   # If your organization uses a custom URL
   # custom_app_url = "https://myorg.observability.splunkcloud.com"
 }
@@ -0,0 +1,9 @@
+# Finds the muting rules that are currently active for deployments.
+list "signalfx_alert_muting_rule" "deploys" {
+  provider = signalfx
+
+  config {
+    description = "deploy"
+    include     = "Ongoing"
+  }
+}
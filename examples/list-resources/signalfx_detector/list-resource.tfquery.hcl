@@ -0,0 +1,12 @@
+# Finds the existing detectors owned by a team that are tagged for production,
+# running `terraform query -generate-config-out=detectors.tf` will write
+# the import blocks required to bring them under management.
+list "signalfx_detector" "production" {
+  provider = signalfx
+
+  config {
+    name  = "latency"
+    tags  = ["production"]
+    teams = [var.team_id]
+  }
+}
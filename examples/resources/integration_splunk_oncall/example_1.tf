@@ -0,0 +1,5 @@
+resource "signalfx_integration_splunk_oncall" "splunk_oncall_myteam" {
+  name     = "Splunk On-Call - My Team"
+  enabled  = true
+  post_url = "https://alert.victorops.com/integrations/generic/1234/alert/$key/$routing_key"
+}
@@ -0,0 +1,12 @@
+# Replaces the previous signalfx_victor_ops_integration resource
+# with the same name, keeping the existing integration.
+moved {
+  from = signalfx_victor_ops_integration.splunk_oncall_myteam
+  to   = signalfx_integration_splunk_oncall.splunk_oncall_myteam
+}
+
+resource "signalfx_integration_splunk_oncall" "splunk_oncall_myteam" {
+  name     = "Splunk On-Call - My Team"
+  enabled  = true
+  post_url = "https://alert.victorops.com/integrations/generic/1234/alert/$key/$routing_key"
+}
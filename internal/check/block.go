@@ -0,0 +1,37 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// RequiredBlock ensures that the named block is set at least once within the resource config.
+// It is used for blocks that are marked as computed so that they can be modified
+// while the plan is calculated, which stops them from being marked as required.
+func RequiredBlock(block string) schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		raw := req.RawConfig
+		if !raw.IsKnown() || raw.IsNull() || !raw.Type().HasAttribute(block) {
+			return
+		}
+
+		elems := raw.GetAttr(block)
+		if !elems.IsKnown() {
+			return
+		}
+		if elems.IsNull() || elems.LengthInt() == 0 {
+			resp.Diagnostics = append(resp.Diagnostics, tfext.AsErrorDiagnostics(
+				fmt.Errorf("at least one %q block is required", block),
+				cty.GetAttrPath(block),
+			)...)
+		}
+	}
+}
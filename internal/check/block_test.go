@@ -0,0 +1,72 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredBlock(t *testing.T) {
+	t.Parallel()
+
+	ruleType := cty.Object(map[string]cty.Type{"severity": cty.String})
+
+	for _, tc := range []struct {
+		name   string
+		rules  cty.Value
+		expect diag.Diagnostics
+	}{
+		{
+			name: "block set",
+			rules: cty.SetVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{"severity": cty.StringVal("Critical")}),
+			}),
+			expect: nil,
+		},
+		{
+			name:  "block not set",
+			rules: cty.SetValEmpty(ruleType),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "at least one \"rule\" block is required",
+					AttributePath: cty.GetAttrPath("rule"),
+				},
+			},
+		},
+		{
+			name:  "null block",
+			rules: cty.NullVal(cty.Set(ruleType)),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "at least one \"rule\" block is required",
+					AttributePath: cty.GetAttrPath("rule"),
+				},
+			},
+		},
+		{
+			name:   "unknown block",
+			rules:  cty.UnknownVal(cty.Set(ruleType)),
+			expect: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			RequiredBlock("rule")(t.Context(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"rule": tc.rules,
+				}),
+			}, resp)
+			assert.Equal(t, tc.expect, resp.Diagnostics, "Must match the expected value")
+		})
+	}
+}
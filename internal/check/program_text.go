@@ -0,0 +1,106 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// ProgramText checks that the SignalFlow program is syntactically valid
+// without needing to send it to the API.
+// Issues are only reported as warnings since the offline parser may not support
+// all of the syntax accepted by the API, which remains responsible for rejecting invalid programs.
+func ProgramText() schema.SchemaValidateDiagFunc {
+	return func(i any, p cty.Path) diag.Diagnostics {
+		value, ok := i.(string)
+		if !ok {
+			return tfext.AsErrorDiagnostics(
+				fmt.Errorf("expected %v to be of type string", i),
+				p,
+			)
+		}
+
+		if _, err := signalflow.Parse(value); err != nil {
+			return tfext.AsWarnDiagnostics(
+				fmt.Errorf("invalid program text: %w", err),
+				p,
+			)
+		}
+		return nil
+	}
+}
+
+// DetectLabels ensures that every `rule.detect_label` within the resource config
+// refers to a label that is published by `program_text`.
+// The check is skipped when either value is unknown or when the program
+// publishes labels that can only be known once it has been evaluated.
+func DetectLabels() schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		resp.Diagnostics = append(resp.Diagnostics, checkPublishLabels(req.RawConfig, "rule", "detect_label", tfext.AsErrorDiagnostics)...)
+	}
+}
+
+// PublishLabels warns when a `label` within the named block does not refer to
+// a label that is published by `program_text`, since the options are silently
+// ignored by the API. It is only a warning as labels published from within
+// SignalFlow library functions can not be known without evaluating the program.
+func PublishLabels(block string) schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		resp.Diagnostics = append(resp.Diagnostics, checkPublishLabels(req.RawConfig, block, "label", tfext.AsWarnDiagnostics)...)
+	}
+}
+
+func checkPublishLabels(raw cty.Value, block, attr string, report func(error, ...cty.Path) diag.Diagnostics) (issues diag.Diagnostics) {
+	if !raw.IsKnown() || raw.IsNull() || !raw.Type().HasAttribute(block) {
+		return nil
+	}
+
+	text := raw.GetAttr("program_text")
+	if !text.IsKnown() || text.IsNull() {
+		return nil
+	}
+
+	// Syntax errors are already reported by ProgramText.
+	prog, err := signalflow.Parse(text.AsString())
+	if err != nil {
+		return nil
+	}
+
+	labels, complete := prog.Labels()
+	if !complete {
+		return nil
+	}
+
+	elems := raw.GetAttr(block)
+	if !elems.IsKnown() || elems.IsNull() {
+		return nil
+	}
+
+	for it := elems.ElementIterator(); it.Next(); {
+		key, elem := it.Element()
+		if !elem.IsKnown() || elem.IsNull() {
+			continue
+		}
+
+		label := elem.GetAttr(attr)
+		if !label.IsKnown() || label.IsNull() || slices.Contains(labels, label.AsString()) {
+			continue
+		}
+
+		issues = append(issues, report(
+			fmt.Errorf("%s.%s %q is not published by program_text; must be one of: %q", block, attr, label.AsString(), labels),
+			cty.GetAttrPath(block).Index(key).GetAttr(attr),
+		)...)
+	}
+	return issues
+}
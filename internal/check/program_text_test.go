@@ -0,0 +1,208 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramText(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		val    any
+		expect diag.Diagnostics
+	}{
+		{
+			name: "No value provided",
+			val:  nil,
+			expect: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected <nil> to be of type string"},
+			},
+		},
+		{
+			name:   "valid program",
+			val:    "detect(when(data('cpu.utilization') > 90)).publish('High CPU')",
+			expect: nil,
+		},
+		{
+			name: "invalid program",
+			val:  "A = data('cpu.utilization'\ndetect(when(A > 90)).publish('High CPU')",
+			expect: diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "invalid program text: line 2, column 1: unexpected name \"detect\", expected \")\"",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, ProgramText()(tc.val, cty.Path{}), "Must match the expected value")
+		})
+	}
+}
+
+func TestDetectLabels(t *testing.T) {
+	t.Parallel()
+
+	newRule := func(label cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"detect_label": label,
+			"severity":     cty.StringVal("Critical"),
+		})
+	}
+	ruleType := newRule(cty.StringVal("")).Type()
+
+	for _, tc := range []struct {
+		name   string
+		text   cty.Value
+		rules  cty.Value
+		expect diag.Diagnostics
+	}{
+		{
+			name:   "matching labels",
+			text:   cty.StringVal("detect(when(data('cpu') > 90)).publish('High CPU')"),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.StringVal("High CPU"))}),
+			expect: nil,
+		},
+		{
+			name: "missing labels",
+			text: cty.StringVal("detect(when(data('cpu') > 90)).publish('High CPU')"),
+			rules: cty.SetVal([]cty.Value{
+				newRule(cty.StringVal("High CPU")),
+				newRule(cty.StringVal("Low CPU")),
+			}),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "rule.detect_label \"Low CPU\" is not published by program_text; must be one of: [\"High CPU\"]",
+					AttributePath: cty.GetAttrPath("rule").Index(newRule(cty.StringVal("Low CPU"))).GetAttr("detect_label"),
+				},
+			},
+		},
+		{
+			name:   "unknown program text",
+			text:   cty.UnknownVal(cty.String),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.StringVal("High CPU"))}),
+			expect: nil,
+		},
+		{
+			name:   "unknown detect label",
+			text:   cty.StringVal("detect(when(data('cpu') > 90)).publish('High CPU')"),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.UnknownVal(cty.String))}),
+			expect: nil,
+		},
+		{
+			name:   "computed publish label",
+			text:   cty.StringVal("label = 'High CPU'\ndetect(when(data('cpu') > 90)).publish(label)"),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.StringVal("High CPU"))}),
+			expect: nil,
+		},
+		{
+			name: "library published label",
+			text: cty.StringVal(`from signalfx.detectors.against_recent import against_recent
+against_recent.detector_mean_std(stream=data('cpu'), current_window='5m', historical_window='1h').publish('Recent CPU')`),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.StringVal("CPU is anomalous"))}),
+			expect: nil,
+		},
+		{
+			name:   "invalid program text",
+			text:   cty.StringVal("detect(when(data('cpu') > 90)"),
+			rules:  cty.SetVal([]cty.Value{newRule(cty.StringVal("High CPU"))}),
+			expect: nil,
+		},
+		{
+			name:   "unknown rules",
+			text:   cty.StringVal("detect(when(data('cpu') > 90)).publish('High CPU')"),
+			rules:  cty.UnknownVal(cty.Set(ruleType)),
+			expect: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			DetectLabels()(t.Context(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"program_text": tc.text,
+					"rule":         tc.rules,
+				}),
+			}, resp)
+			assert.Equal(t, tc.expect, resp.Diagnostics, "Must match the expected value")
+		})
+	}
+}
+
+func TestPublishLabels(t *testing.T) {
+	t.Parallel()
+
+	newOption := func(label string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"label": cty.StringVal(label),
+			"color": cty.StringVal("blue"),
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config cty.Value
+		expect diag.Diagnostics
+	}{
+		{
+			name: "matching labels",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish(label='A')\nB = data('mem').publish(label='B')"),
+				"viz_options":  cty.SetVal([]cty.Value{newOption("A"), newOption("B")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "misspelt label",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish(label='A')"),
+				"viz_options":  cty.SetVal([]cty.Value{newOption("A"), newOption("a")}),
+			}),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Warning,
+					Summary:       "viz_options.label \"a\" is not published by program_text; must be one of: [\"A\"]",
+					AttributePath: cty.GetAttrPath("viz_options").Index(newOption("a")).GetAttr("label"),
+				},
+			},
+		},
+		{
+			name: "no viz options set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish(label='A')"),
+				"viz_options":  cty.NullVal(cty.Set(newOption("").Type())),
+			}),
+			expect: nil,
+		},
+		{
+			name: "resource without block",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish(label='A')"),
+			}),
+			expect: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			PublishLabels("viz_options")(t.Context(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: tc.config,
+			}, resp)
+			assert.Equal(t, tc.expect, resp.Diagnostics, "Must match the expected value")
+		})
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/signalfx/signalfx-go/notification"
 )
 
+// NewNotificationStringFromAPI converts the notification into the notification string.
+//
+// The secret of a webhook notification is sensitive, so it is left empty
+// instead of being rendered into the string that is stored within the state.
 func NewNotificationStringFromAPI(n *notification.Notification) (string, error) {
 	if n == nil {
 		return "", fmt.Errorf("nil value provided")
@@ -41,13 +45,28 @@ func NewNotificationStringFromAPI(n *notification.Notification) (string, error)
 	case *notification.VictorOpsNotification:
 		return fmt.Sprintf("%s,%s,%s", n.Type, v.CredentialId, v.RoutingKey), nil
 	case *notification.WebhookNotification:
-		return fmt.Sprintf("%s,%s,%s,%s", n.Type, v.CredentialId, v.Secret, v.Url), nil
+		return fmt.Sprintf("%s,%s,,%s", n.Type, v.CredentialId, v.Url), nil
 	case *notification.XMattersNotification:
 		return fmt.Sprintf("%s,%s", n.Type, v.CredentialId), nil
 	}
 	return "", fmt.Errorf("unknown type %T provided", n.Value)
 }
 
+// RedactNotificationString returns the notification string without any sensitive values,
+// matching the string rendered by [NewNotificationStringFromAPI].
+// An invalid notification string is returned unchanged.
+func RedactNotificationString(str string) string {
+	n, err := NewNotificationFromString(str)
+	if err != nil {
+		return str
+	}
+	redacted, err := NewNotificationStringFromAPI(n)
+	if err != nil {
+		return str
+	}
+	return redacted
+}
+
 func formatEmailNotificationString(v *notification.EmailNotification) string {
 	if len(v.Cc) == 0 && len(v.Bcc) == 0 {
 		return fmt.Sprintf("%s,%s", v.Type, v.Email)
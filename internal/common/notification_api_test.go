@@ -233,7 +233,7 @@ func TestNewStringFromAPI(t *testing.T) {
 					Url:          "http://localhost",
 				},
 			},
-			expect: "Webhook,jjj,hunter2,http://localhost",
+			expect: "Webhook,jjj,,http://localhost",
 			errVal: "",
 		},
 		{
@@ -262,3 +262,40 @@ func TestNewStringFromAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestRedactNotificationString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		str    string
+		expect string
+	}{
+		{
+			name:   "no sensitive values",
+			str:    "Slack,aaa,alerts",
+			expect: "Slack,aaa,alerts",
+		},
+		{
+			name:   "webhook secret",
+			str:    "Webhook,,hunter2,http://localhost",
+			expect: "Webhook,,,http://localhost",
+		},
+		{
+			name:   "webhook credential",
+			str:    "Webhook,aaa,,",
+			expect: "Webhook,aaa,,",
+		},
+		{
+			name:   "invalid notification",
+			str:    "Webhook,hunter2",
+			expect: "Webhook,hunter2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expect, RedactNotificationString(tc.str), "Must match the expected notification string")
+		})
+	}
+}
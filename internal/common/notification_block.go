@@ -0,0 +1,242 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/signalfx/signalfx-go/notification"
+)
+
+// The names of the nested blocks within a notification block,
+// each one matches one of the notification types.
+const (
+	AmazonEventBridgeNotificationBlock = "amazon_eventbridge"
+	BigPandaNotificationBlock          = "bigpanda"
+	EmailNotificationBlock             = "email"
+	JiraNotificationBlock              = "jira"
+	Office365NotificationBlock         = "office365"
+	OpsgenieNotificationBlock          = "opsgenie"
+	PagerDutyNotificationBlock         = "pagerduty"
+	ServiceNowNotificationBlock        = "servicenow"
+	SlackNotificationBlock             = "slack"
+	SplunkPlatformNotificationBlock    = "splunk_platform"
+	TeamNotificationBlock              = "team"
+	TeamEmailNotificationBlock         = "team_email"
+	VictorOpsNotificationBlock         = "victorops"
+	WebhookNotificationBlock           = "webhook"
+	XMattersNotificationBlock          = "xmatters"
+)
+
+// NotificationBlocks maps each of the notification block names to the notification type.
+var NotificationBlocks = map[string]string{
+	AmazonEventBridgeNotificationBlock: AmazonEventBrigeNotificationType,
+	BigPandaNotificationBlock:          BigPandaNotificationType,
+	EmailNotificationBlock:             EmailNotificationType,
+	JiraNotificationBlock:              JiraNotificationType,
+	Office365NotificationBlock:         Office365NotificationType,
+	OpsgenieNotificationBlock:          OpsgenieNotificationType,
+	PagerDutyNotificationBlock:         PagerDutyNotificationType,
+	ServiceNowNotificationBlock:        ServiceNowNotificationType,
+	SlackNotificationBlock:             SlackNotificationType,
+	SplunkPlatformNotificationBlock:    SplunkPlatformNotificationType,
+	TeamNotificationBlock:              TeamNotificationType,
+	TeamEmailNotificationBlock:         TeamEmailNotificationType,
+	VictorOpsNotificationBlock:         VictorOpsNotificationType,
+	WebhookNotificationBlock:           WebhookNotificationType,
+	XMattersNotificationBlock:          XMattersNotificationType,
+}
+
+// NewNotificationFromBlock converts the notification block into the API type.
+// The block must set exactly one of the nested notification type blocks.
+func NewNotificationFromBlock(block map[string]any) (*notification.Notification, error) {
+	var (
+		name   string
+		fields map[string]any
+	)
+	for _, n := range slices.Sorted(maps.Keys(NotificationBlocks)) {
+		items, ok := block[n].([]any)
+		if !ok || len(items) == 0 {
+			continue
+		}
+		if name != "" {
+			return nil, fmt.Errorf("notification block sets both %q and %q, only one can be set", name, n)
+		}
+		if len(items) > 1 {
+			return nil, fmt.Errorf("only one %q block can be set", n)
+		}
+		name = n
+		// An empty nested block is read as a nil value.
+		fields, _ = items[0].(map[string]any)
+	}
+	if name == "" {
+		return nil, errors.New("notification block must set one of the notification types")
+	}
+
+	var (
+		typ   = NotificationBlocks[name]
+		value any
+	)
+	str := func(field string) string {
+		s, _ := fields[field].(string)
+		return s
+	}
+
+	switch name {
+	case AmazonEventBridgeNotificationBlock:
+		value = &notification.AmazonEventBrigeNotification{Type: typ, CredentialId: str("credential_id")}
+	case BigPandaNotificationBlock:
+		value = &notification.BigPandaNotification{Type: typ, CredentialId: str("credential_id")}
+	case EmailNotificationBlock:
+		email, err := parseEmailNotificationFromBlock(typ, fields)
+		if err != nil {
+			return nil, err
+		}
+		value = email
+	case JiraNotificationBlock:
+		value = &notification.JiraNotification{Type: typ, CredentialId: str("credential_id")}
+	case Office365NotificationBlock:
+		value = &notification.Office365Notification{Type: typ, CredentialId: str("credential_id")}
+	case OpsgenieNotificationBlock:
+		value = &notification.OpsgenieNotification{
+			Type:          typ,
+			CredentialId:  str("credential_id"),
+			ResponderName: str("responder_name"),
+			ResponderId:   str("responder_id"),
+			ResponderType: str("responder_type"),
+		}
+	case PagerDutyNotificationBlock:
+		value = &notification.PagerDutyNotification{Type: typ, CredentialId: str("credential_id")}
+	case ServiceNowNotificationBlock:
+		value = &notification.ServiceNowNotification{Type: typ, CredentialId: str("credential_id")}
+	case SlackNotificationBlock:
+		if strings.Contains(str("channel"), "#") {
+			return nil, fmt.Errorf("exclude the # from channel names in %q", str("channel"))
+		}
+		value = &notification.SlackNotification{Type: typ, CredentialId: str("credential_id"), Channel: str("channel")}
+	case SplunkPlatformNotificationBlock:
+		value = &notification.SplunkPlatformNotification{Type: typ, CredentialId: str("credential_id")}
+	case TeamNotificationBlock:
+		value = &notification.TeamNotification{Type: typ, Team: str("team_id")}
+	case TeamEmailNotificationBlock:
+		value = &notification.TeamEmailNotification{Type: typ, Team: str("team_id")}
+	case VictorOpsNotificationBlock:
+		value = &notification.VictorOpsNotification{Type: typ, CredentialId: str("credential_id"), RoutingKey: str("routing_key")}
+	case WebhookNotificationBlock:
+		switch {
+		case str("credential_id") != "" && str("url") != "":
+			return nil, errors.New("invalid webhook notification block, use one of url and secret or credential_id")
+		case str("credential_id") != "":
+			// Do nothing, credentialId is set
+		case str("url") != "":
+			if _, err := url.ParseRequestURI(str("url")); err != nil {
+				return nil, fmt.Errorf("invalid Webhook URL %q", str("url"))
+			}
+		default:
+			return nil, errors.New("invalid webhook notification block, use one of url or credential_id")
+		}
+		value = &notification.WebhookNotification{Type: typ, CredentialId: str("credential_id"), Secret: str("secret"), Url: str("url")}
+	case XMattersNotificationBlock:
+		value = &notification.XMattersNotification{Type: typ, CredentialId: str("credential_id")}
+	}
+
+	return &notification.Notification{Type: typ, Value: value}, nil
+}
+
+func parseEmailNotificationFromBlock(typ string, fields map[string]any) (*notification.EmailNotification, error) {
+	email := &notification.EmailNotification{Type: typ}
+	email.Email, _ = fields["email"].(string)
+	if _, err := mail.ParseAddress(email.Email); err != nil {
+		return nil, err
+	}
+	for field, ref := range map[string]*[]string{
+		"cc":  &email.Cc,
+		"bcc": &email.Bcc,
+	} {
+		addrs, _ := fields[field].([]any)
+		for _, addr := range addrs {
+			s, _ := addr.(string)
+			if _, err := mail.ParseAddress(s); err != nil {
+				return nil, err
+			}
+			*ref = append(*ref, s)
+		}
+	}
+	return email, nil
+}
+
+// NewNotificationBlockFromAPI converts the API type into a notification block.
+func NewNotificationBlockFromAPI(n *notification.Notification) (map[string]any, error) {
+	if n == nil {
+		return nil, errors.New("nil value provided")
+	}
+
+	var (
+		name   string
+		fields map[string]any
+	)
+	switch v := n.Value.(type) {
+	case *notification.AmazonEventBrigeNotification:
+		name, fields = AmazonEventBridgeNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.BigPandaNotification:
+		name, fields = BigPandaNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.EmailNotification:
+		name, fields = EmailNotificationBlock, map[string]any{
+			"email": v.Email,
+			"cc":    toAnySlice(v.Cc),
+			"bcc":   toAnySlice(v.Bcc),
+		}
+	case *notification.JiraNotification:
+		name, fields = JiraNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.Office365Notification:
+		name, fields = Office365NotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.OpsgenieNotification:
+		name, fields = OpsgenieNotificationBlock, map[string]any{
+			"credential_id":  v.CredentialId,
+			"responder_name": v.ResponderName,
+			"responder_id":   v.ResponderId,
+			"responder_type": v.ResponderType,
+		}
+	case *notification.PagerDutyNotification:
+		name, fields = PagerDutyNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.ServiceNowNotification:
+		name, fields = ServiceNowNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.SlackNotification:
+		name, fields = SlackNotificationBlock, map[string]any{"credential_id": v.CredentialId, "channel": v.Channel}
+	case *notification.SplunkPlatformNotification:
+		name, fields = SplunkPlatformNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	case *notification.TeamNotification:
+		name, fields = TeamNotificationBlock, map[string]any{"team_id": v.Team}
+	case *notification.TeamEmailNotification:
+		name, fields = TeamEmailNotificationBlock, map[string]any{"team_id": v.Team}
+	case *notification.VictorOpsNotification:
+		name, fields = VictorOpsNotificationBlock, map[string]any{"credential_id": v.CredentialId, "routing_key": v.RoutingKey}
+	case *notification.WebhookNotification:
+		name, fields = WebhookNotificationBlock, map[string]any{"credential_id": v.CredentialId, "secret": v.Secret, "url": v.Url}
+	case *notification.XMattersNotification:
+		name, fields = XMattersNotificationBlock, map[string]any{"credential_id": v.CredentialId}
+	default:
+		return nil, fmt.Errorf("unknown type %T provided", n.Value)
+	}
+	return map[string]any{name: []any{fields}}, nil
+}
+
+// toAnySlice matches how terraform reads list values
+// so that the block can be decoded again.
+func toAnySlice(values []string) []any {
+	if len(values) == 0 {
+		return nil
+	}
+	items := make([]any, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
@@ -0,0 +1,183 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotificationFromBlock(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		block  map[string]any
+		expect *notification.Notification
+		errVal string
+	}{
+		{
+			name:   "no block set",
+			block:  nil,
+			errVal: "notification block must set one of the notification types",
+		},
+		{
+			name: "multiple types set",
+			block: map[string]any{
+				"slack":    []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}},
+				"xmatters": []any{map[string]any{"credential_id": "bbb"}},
+			},
+			errVal: "notification block sets both \"slack\" and \"xmatters\", only one can be set",
+		},
+		{
+			name: "type set multiple times",
+			block: map[string]any{
+				"xmatters": []any{map[string]any{"credential_id": "aaa"}, map[string]any{"credential_id": "bbb"}},
+			},
+			errVal: "only one \"xmatters\" block can be set",
+		},
+		{
+			name: "empty types ignored",
+			block: map[string]any{
+				"email":    []any{},
+				"xmatters": []any{map[string]any{"credential_id": "bbb"}},
+			},
+			expect: &notification.Notification{
+				Type:  XMattersNotificationType,
+				Value: &notification.XMattersNotification{Type: XMattersNotificationType, CredentialId: "bbb"},
+			},
+		},
+		{
+			name: "email",
+			block: map[string]any{
+				"email": []any{map[string]any{"email": "example@localhost", "cc": []any{"cc@localhost"}}},
+			},
+			expect: &notification.Notification{
+				Type: EmailNotificationType,
+				Value: &notification.EmailNotification{
+					Type:  EmailNotificationType,
+					Email: "example@localhost",
+					Cc:    []string{"cc@localhost"},
+				},
+			},
+		},
+		{
+			name: "invalid email",
+			block: map[string]any{
+				"email": []any{map[string]any{"email": "example"}},
+			},
+			errVal: "mail: missing '@' or angle-addr",
+		},
+		{
+			name: "invalid email cc",
+			block: map[string]any{
+				"email": []any{map[string]any{"email": "example@localhost", "bcc": []any{"bcc"}}},
+			},
+			errVal: "mail: missing '@' or angle-addr",
+		},
+		{
+			name: "slack with hash",
+			block: map[string]any{
+				"slack": []any{map[string]any{"credential_id": "aaa", "channel": "#alerts"}},
+			},
+			errVal: "exclude the # from channel names in \"#alerts\"",
+		},
+		{
+			name: "webhook with url",
+			block: map[string]any{
+				"webhook": []any{map[string]any{"url": "https://example.com", "secret": "shh", "credential_id": ""}},
+			},
+			expect: &notification.Notification{
+				Type: WebhookNotificationType,
+				Value: &notification.WebhookNotification{
+					Type:   WebhookNotificationType,
+					Url:    "https://example.com",
+					Secret: "shh",
+				},
+			},
+		},
+		{
+			name: "webhook with url and credential",
+			block: map[string]any{
+				"webhook": []any{map[string]any{"url": "https://example.com", "credential_id": "aaa"}},
+			},
+			errVal: "invalid webhook notification block, use one of url and secret or credential_id",
+		},
+		{
+			name: "webhook without url or credential",
+			block: map[string]any{
+				"webhook": []any{nil},
+			},
+			errVal: "invalid webhook notification block, use one of url or credential_id",
+		},
+		{
+			name: "webhook with invalid url",
+			block: map[string]any{
+				"webhook": []any{map[string]any{"url": "example"}},
+			},
+			errVal: "invalid Webhook URL \"example\"",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewNotificationFromBlock(tc.block)
+			assert.Equal(t, tc.expect, actual, "Must match the expected notification")
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error message")
+			} else {
+				assert.NoError(t, err, "Must not error parsing the block")
+			}
+		})
+	}
+}
+
+func TestNewNotificationBlockFromAPI(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewNotificationBlockFromAPI(nil)
+	assert.EqualError(t, err, "nil value provided", "Must error with a nil value")
+
+	_, err = NewNotificationBlockFromAPI(&notification.Notification{Type: "brrr"})
+	assert.EqualError(t, err, "unknown type <nil> provided", "Must error with an unknown type")
+
+	// Each notification string must be the same notification when it is read as a block.
+	for _, str := range []string{
+		"AmazonEventBridge,aaa",
+		"BigPanda,aaa",
+		"Email,example@localhost",
+		"Email,example@localhost,cc@localhost|other@localhost,bcc@localhost",
+		"Jira,aaa",
+		"Office365,aaa",
+		"Opsgenie,aaa,name,id,Team",
+		"PagerDuty,aaa",
+		"ServiceNow,aaa",
+		"Slack,aaa,alerts",
+		"SplunkPlatform,aaa",
+		"Team,aaa",
+		"TeamEmail,aaa",
+		"VictorOps,aaa,routing",
+		"Webhook,aaa,,",
+		"Webhook,,secret,https://example.com",
+		"XMatters,aaa",
+	} {
+		t.Run(str, func(t *testing.T) {
+			t.Parallel()
+
+			expect, err := NewNotificationFromString(str)
+			require.NoError(t, err, "Must parse the notification string")
+
+			block, err := NewNotificationBlockFromAPI(expect)
+			require.NoError(t, err, "Must convert the notification into a block")
+			assert.Len(t, block, 1, "Must set exactly one notification type")
+
+			actual, err := NewNotificationFromBlock(block)
+			require.NoError(t, err, "Must parse the notification block")
+			assert.Equal(t, expect, actual, "Must match the notification from the string")
+		})
+	}
+}
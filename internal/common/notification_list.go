@@ -36,3 +36,36 @@ func NewNotificationStringList(items []*notification.Notification) ([]string, er
 	}
 	return values, nil
 }
+
+// NewNotificationListFromBlocks converts each of the notification blocks into the API type.
+func NewNotificationListFromBlocks(items []any) ([]*notification.Notification, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	values := make([]*notification.Notification, len(items))
+	for i, v := range items {
+		block, _ := v.(map[string]any)
+		n, err := NewNotificationFromBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = n
+	}
+	return values, nil
+}
+
+// NewNotificationBlockList converts each of the notifications into a notification block.
+func NewNotificationBlockList(items []*notification.Notification) ([]any, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	values := make([]any, len(items))
+	for i, v := range items {
+		block, err := NewNotificationBlockFromAPI(v)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = block
+	}
+	return values, nil
+}
@@ -111,3 +111,102 @@ func TestNewNotificationStringList(t *testing.T) {
 		})
 	}
 }
+
+func TestNewNotificationListFromBlocks(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		items  []any
+		expect []*notification.Notification
+		errVal string
+	}{
+		{
+			name:   "no values provided",
+			items:  nil,
+			expect: nil,
+			errVal: "",
+		},
+		{
+			name:   "invalid notification block",
+			items:  []any{map[string]any{}},
+			expect: nil,
+			errVal: "notification block must set one of the notification types",
+		},
+		{
+			name: "valid notification block",
+			items: []any{
+				map[string]any{"email": []any{map[string]any{"email": "example@localhost"}}},
+			},
+			expect: []*notification.Notification{
+				{
+					Type: "Email",
+					Value: &notification.EmailNotification{
+						Type:  "Email",
+						Email: "example@localhost",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewNotificationListFromBlocks(tc.items)
+			assert.Equal(t, tc.expect, actual, "Must match the expected notifications")
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must much the expected error message")
+			} else {
+				require.NoError(t, err, "Must not error parsing blocks")
+			}
+		})
+	}
+}
+
+func TestNewNotificationBlockList(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		items  []*notification.Notification
+		expect []any
+		errVal string
+	}{
+		{
+			name:   "nil",
+			items:  nil,
+			expect: nil,
+			errVal: "",
+		},
+		{
+			name: "valid notification",
+			items: []*notification.Notification{
+				{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "example@com"}},
+			},
+			expect: []any{
+				map[string]any{"email": []any{map[string]any{"email": "example@com", "cc": []any(nil), "bcc": []any(nil)}}},
+			},
+			errVal: "",
+		},
+		{
+			name: "invalid notification",
+			items: []*notification.Notification{
+				{Type: "Provider", Value: nil},
+			},
+			expect: nil,
+			errVal: "unknown type <nil> provided",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := NewNotificationBlockList(tc.items)
+			assert.Equal(t, tc.expect, items, "Must match the expected values")
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected value")
+			} else {
+				assert.NoError(t, err, "Must not error")
+			}
+		})
+	}
+}
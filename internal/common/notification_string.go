@@ -162,6 +162,41 @@ func NewNotificationFromString(str string) (*notification.Notification, error) {
 	return &notification.Notification{Type: values[0], Value: value}, nil
 }
 
+// NewNotificationString joins the fields into the notification string of the notification type,
+// the string is then validated using [NewNotificationFromString].
+func NewNotificationString(typ string, fields ...string) (string, error) {
+	for _, field := range fields {
+		if strings.Contains(field, ",") {
+			return "", fmt.Errorf("%s notification field %q must not contain a comma", typ, field)
+		}
+	}
+	str := strings.Join(append([]string{typ}, fields...), ",")
+	if _, err := NewNotificationFromString(str); err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
+// NewEmailNotificationString returns the Email notification string
+// that sends the notification to the email address, along with the cc and bcc recipients.
+func NewEmailNotificationString(email string, cc, bcc []string) (string, error) {
+	for _, addr := range slices.Concat([]string{email}, cc, bcc) {
+		if strings.ContainsAny(addr, ","+emailRecipientListSeparator) {
+			return "", fmt.Errorf("email address %q must not contain a comma or %q", addr, emailRecipientListSeparator)
+		}
+	}
+	str := formatEmailNotificationString(&notification.EmailNotification{
+		Type:  EmailNotificationType,
+		Email: email,
+		Cc:    cc,
+		Bcc:   bcc,
+	})
+	if _, err := NewNotificationFromString(str); err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
 func parseEmailNotificationFromString(values []string) (*notification.EmailNotification, error) {
 	if _, err := mail.ParseAddress(values[1]); err != nil {
 		return nil, err
@@ -406,3 +406,101 @@ func TestFormatEmailRecipientList(t *testing.T) {
 	assert.Empty(t, formatEmailRecipientList([]string{}))
 	assert.Equal(t, "a@example.com|b@example.com", formatEmailRecipientList([]string{"b@example.com", "a@example.com"}))
 }
+
+func TestNewNotificationString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		typ    string
+		fields []string
+		expect string
+		errVal string
+	}{
+		{
+			name:   "slack",
+			typ:    SlackNotificationType,
+			fields: []string{"aaa", "alerts"},
+			expect: "Slack,aaa,alerts",
+		},
+		{
+			name:   "field contains comma",
+			typ:    SlackNotificationType,
+			fields: []string{"aaa", "alerts,other"},
+			errVal: `Slack notification field "alerts,other" must not contain a comma`,
+		},
+		{
+			name:   "invalid notification",
+			typ:    SlackNotificationType,
+			fields: []string{"aaa", "#alerts"},
+			errVal: `exclude the # from channel names in "#alerts"`,
+		},
+		{
+			name:   "missing fields",
+			typ:    OpsgenieNotificationType,
+			fields: []string{"aaa"},
+			errVal: "invalid OpsGenie notification string, please consult the documentation (not enough parts)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewNotificationString(tc.typ, tc.fields...)
+			assert.Equal(t, tc.expect, actual, "Must match the expected notification string")
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error creating the notification string")
+			}
+		})
+	}
+}
+
+func TestNewEmailNotificationString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		email  string
+		cc     []string
+		bcc    []string
+		expect string
+		errVal string
+	}{
+		{
+			name:   "email only",
+			email:  "alerts@example.com",
+			expect: "Email,alerts@example.com",
+		},
+		{
+			name:   "recipients sorted",
+			email:  "alerts@example.com",
+			cc:     []string{"ops@example.com", "oncall@example.com"},
+			bcc:    []string{"audit@example.com"},
+			expect: "Email,alerts@example.com,oncall@example.com|ops@example.com,audit@example.com",
+		},
+		{
+			name:   "address contains separator",
+			email:  "alerts@example.com",
+			cc:     []string{"a@example.com|b@example.com"},
+			errVal: `email address "a@example.com|b@example.com" must not contain a comma or "|"`,
+		},
+		{
+			name:   "invalid address",
+			email:  "alerts",
+			errVal: "mail: missing '@' or angle-addr",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewEmailNotificationString(tc.email, tc.cc, tc.bcc)
+			assert.Equal(t, tc.expect, actual, "Must match the expected notification string")
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error creating the notification string")
+			}
+		})
+	}
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go/detector"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
@@ -38,7 +40,16 @@ func NewResource() *schema.Resource {
 		StateUpgraders: []schema.StateUpgrader{
 			{Type: v0state().CoreConfigSchema().ImpliedType(), Upgrade: v0stateMigration, Version: 0},
 		},
-		CustomizeDiff: customdiff.If(resourceValidateCond, resourceValidateFunc),
+		CustomizeDiff: customdiff.All(
+			rule.CustomizeDiffDefault,
+			rule.CustomizeDiffNotifications,
+			customdiff.If(resourceValidateCond, resourceValidateFunc),
+		),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.RequiredBlock("rule"),
+			check.DetectLabels(),
+			check.PublishLabels("viz_options"),
+		},
 	}
 }
 
@@ -63,7 +74,7 @@ func resourceCreate(ctx context.Context, data *schema.ResourceData, meta any) (i
 		MaxDelay:          dt.MaxDelay,
 		MinDelay:          dt.MinDelay,
 		ProgramText:       dt.ProgramText,
-		Rules:             dt.Rules,
+		Rules:             pmeta.MergeProviderDefaultRule(ctx, meta, dt.Rules),
 		Tags: common.Unique(
 			pmeta.LoadProviderTags(ctx, meta),
 			dt.Tags,
@@ -147,7 +158,7 @@ func resourceUpdate(ctx context.Context, data *schema.ResourceData, meta any) (i
 		MaxDelay:          dt.MaxDelay,
 		MinDelay:          dt.MinDelay,
 		ProgramText:       dt.ProgramText,
-		Rules:             dt.Rules,
+		Rules:             pmeta.MergeProviderDefaultRule(ctx, meta, dt.Rules),
 		Tags: common.Unique(
 			pmeta.LoadProviderTags(ctx, meta),
 			dt.Tags,
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
 )
@@ -166,6 +167,38 @@ func TestResourceCreate(t *testing.T) {
 				VisualizationOptions: &detector.Visualization{},
 			},
 		},
+		{
+			Name:     "Create with fake api",
+			Resource: NewResource(),
+			Meta:     tftest.NewFakeAPIMeta(fakeapi.New()),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &detector.Detector{
+				Name:              "test detector",
+				AuthorizedWriters: &detector.AuthorizedWriters{},
+				TimeZone:          "Australia/Sydney",
+				MaxDelay:          common.AsPointer[int32](1000),
+				MinDelay:          common.AsPointer[int32](1000),
+				ProgramText:       `detect(when(data('*').count() < 1)).publish('no data')`,
+				Rules: []*detector.Rule{
+					{DetectLabel: "no data", Severity: detector.CRITICAL},
+				},
+				VisualizationOptions: &detector.Visualization{},
+			},
+			Expect: &detector.Detector{
+				Id:                "AAAAAAAAAAE",
+				Name:              "test detector",
+				AuthorizedWriters: &detector.AuthorizedWriters{},
+				TimeZone:          "Australia/Sydney",
+				MaxDelay:          common.AsPointer[int32](1000),
+				MinDelay:          common.AsPointer[int32](1000),
+				ProgramText:       `detect(when(data('*').count() < 1)).publish('no data')`,
+				Rules: []*detector.Rule{
+					{DetectLabel: "no data", Severity: detector.CRITICAL},
+				},
+				VisualizationOptions: &detector.Visualization{},
+			},
+		},
 	} {
 		tc.TestCreate(t)
 	}
@@ -24,10 +24,13 @@ func newSchema() map[string]*schema.Schema {
 			Description: "Name of the detector",
 		},
 		"program_text": {
-			Type:         schema.TypeString,
-			Required:     true,
-			Description:  "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
-			ValidateFunc: validation.StringLenBetween(1, 50000),
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			ValidateDiagFunc: validation.AllDiag(
+				validation.ToDiagFunc(validation.StringLenBetween(1, 50000)),
+				check.ProgramText(),
+			),
 		},
 		"description": {
 			Type:        schema.TypeString,
@@ -105,8 +108,11 @@ func newSchema() map[string]*schema.Schema {
 			Description: "Team IDs to associate the detector to",
 		},
 		"rule": {
-			Type:        schema.TypeSet,
-			Required:    true,
+			Type: schema.TypeSet,
+			// Computed allows the provider default rule to be merged into the plan,
+			// the block is still required by check.RequiredBlock.
+			Optional:    true,
+			Computed:    true,
 			Description: "Set of rules used for alerting",
 			Elem: &schema.Resource{
 				SchemaFunc: rule.NewSchema,
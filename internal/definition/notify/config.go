@@ -0,0 +1,79 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/signalfx/signalfx-go/notification"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// ConfigValue converts the value into the types used by [schema.ResourceData],
+// so that nested blocks can be read from the raw config.
+func ConfigValue(v cty.Value) any {
+	switch {
+	case v.IsNull() || !v.IsKnown():
+		return nil
+	case v.Type() == cty.String:
+		return v.AsString()
+	case v.Type().IsObjectType():
+		values := make(map[string]any, len(v.Type().AttributeTypes()))
+		for name := range v.Type().AttributeTypes() {
+			values[name] = ConfigValue(v.GetAttr(name))
+		}
+		return values
+	case v.CanIterateElements():
+		values := make([]any, 0, v.LengthInt())
+		for _, item := range v.AsValueSlice() {
+			values = append(values, ConfigValue(item))
+		}
+		return values
+	}
+	return nil
+}
+
+// FromConfig reads the notifications of the item within the raw config,
+// along with the name of the attribute that sets them.
+//
+// Each notification keeps its position within the attribute, any notification
+// that is not known yet or is not valid is left as nil since it is reported
+// when the configuration is validated.
+func (f Field) FromConfig(item cty.Value) (string, []*notification.Notification) {
+	if item.IsNull() || !item.IsKnown() || !item.Type().IsObjectType() {
+		return f.Strings, nil
+	}
+
+	name := f.Strings
+	if item.Type().HasAttribute(f.Blocks) {
+		if blocks := item.GetAttr(f.Blocks); !blocks.IsNull() && blocks.IsKnown() && blocks.LengthInt() > 0 {
+			name = f.Blocks
+		}
+	}
+	if !item.Type().HasAttribute(name) {
+		return name, nil
+	}
+
+	values := item.GetAttr(name)
+	if values.IsNull() || !values.IsKnown() {
+		return name, nil
+	}
+
+	ns := make([]*notification.Notification, 0, values.LengthInt())
+	for _, v := range values.AsValueSlice() {
+		var n *notification.Notification
+		switch {
+		case v.IsNull() || !v.IsWhollyKnown():
+			// Left as nil since it can not be read yet.
+		case v.Type() == cty.String:
+			n, _ = common.NewNotificationFromString(v.AsString())
+		default:
+			if block, ok := ConfigValue(v).(map[string]any); ok {
+				n, _ = common.NewNotificationFromBlock(block)
+			}
+		}
+		ns = append(ns, n)
+	}
+	return name, ns
+}
@@ -0,0 +1,110 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldFromConfig(t *testing.T) {
+	t.Parallel()
+
+	itemType := (&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"notifications": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"notification": NewSchema(""),
+		},
+	}).CoreConfigSchema().ImpliedType()
+	blockType := itemType.AttributeType("notification").ElementType()
+
+	newItem := func(values map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, t := range itemType.AttributeTypes() {
+			attrs[name] = cty.NullVal(t)
+		}
+		for name, v := range values {
+			attrs[name] = v
+		}
+		return cty.ObjectVal(attrs)
+	}
+	newBlock := func(name string, fields map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for n, t := range blockType.AttributeTypes() {
+			attrs[n] = cty.NullVal(t)
+		}
+		attrs[name] = cty.ListVal([]cty.Value{cty.ObjectVal(fields)})
+		return cty.ObjectVal(attrs)
+	}
+
+	slack := &notification.Notification{
+		Type:  "Slack",
+		Value: &notification.SlackNotification{Type: "Slack", CredentialId: "aaa", Channel: "alerts"},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		item   cty.Value
+		field  string
+		expect []*notification.Notification
+	}{
+		{
+			name:   "null item",
+			item:   cty.NullVal(itemType),
+			field:  "notifications",
+			expect: nil,
+		},
+		{
+			name:   "no notifications",
+			item:   newItem(nil),
+			field:  "notifications",
+			expect: nil,
+		},
+		{
+			name: "notification strings",
+			item: newItem(map[string]cty.Value{
+				"notifications": cty.ListVal([]cty.Value{
+					cty.StringVal("Slack,aaa,alerts"),
+					cty.UnknownVal(cty.String),
+					cty.StringVal("Slack,aaa"),
+				}),
+			}),
+			field:  "notifications",
+			expect: []*notification.Notification{slack, nil, nil},
+		},
+		{
+			name: "notification blocks",
+			item: newItem(map[string]cty.Value{
+				"notification": cty.ListVal([]cty.Value{
+					newBlock("slack", map[string]cty.Value{
+						"credential_id": cty.StringVal("aaa"),
+						"channel":       cty.StringVal("alerts"),
+					}),
+					newBlock("slack", map[string]cty.Value{
+						"credential_id": cty.UnknownVal(cty.String),
+						"channel":       cty.StringVal("alerts"),
+					}),
+				}),
+			}),
+			field:  "notification",
+			expect: []*notification.Notification{slack, nil},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			field, ns := Notifications.FromConfig(tc.item)
+			assert.Equal(t, tc.field, field, "Must match the expected attribute")
+			assert.Equal(t, tc.expect, ns, "Must match the expected notifications")
+		})
+	}
+}
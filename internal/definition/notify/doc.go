@@ -0,0 +1,8 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package notify does not export a resource or data source.
+// It defines the notification block, which is the typed alternative
+// to the comma delimited notification strings, so that it can be
+// shared by every resource that accepts notifications.
+package notify
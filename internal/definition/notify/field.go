@@ -0,0 +1,135 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/signalfx/signalfx-go/notification"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// Field is a pair of attributes that set the same notifications,
+// one using the notification strings and the other using the notification blocks.
+type Field struct {
+	Strings string
+	Blocks  string
+}
+
+// Notifications is the pair of attributes used by detector rules and org tokens.
+var Notifications = Field{Strings: "notifications", Blocks: "notification"}
+
+// FromMap allows the fields of a nested block to be read like the resource attributes.
+func FromMap(item map[string]any) func(string) any {
+	return func(name string) any {
+		return item[name]
+	}
+}
+
+// Decode reads the notifications from whichever of the attributes has been set,
+// get reads the attribute value, such as [schema.ResourceData.Get] or [FromMap].
+func (f Field) Decode(get func(string) any) ([]*notification.Notification, error) {
+	strs, _ := get(f.Strings).([]any)
+	blocks, _ := get(f.Blocks).([]any)
+	if len(strs) > 0 && len(blocks) > 0 {
+		return nil, fmt.Errorf("only one of %q or %q can be set", f.Strings, f.Blocks)
+	}
+	if len(blocks) > 0 {
+		return common.NewNotificationListFromBlocks(blocks)
+	}
+	return common.NewNotificationList(strs)
+}
+
+// UsesBlocks reports if the notifications are set using the notification blocks.
+func (f Field) UsesBlocks(get func(string) any) bool {
+	blocks, _ := get(f.Blocks).([]any)
+	return len(blocks) > 0
+}
+
+// ByKey returns the items keyed by the value of their key attribute,
+// so the prior state of each item can be passed to [Field.Select].
+func ByKey(items []any, key string) map[string]map[string]any {
+	keyed := make(map[string]map[string]any, len(items))
+	for _, v := range items {
+		if item, ok := v.(map[string]any); ok {
+			keyed[fmt.Sprint(item[key])] = item
+		}
+	}
+	return keyed
+}
+
+// Encode converts the notifications into both of the attributes,
+// [Field.Select] is then used to keep the one that was configured.
+func (f Field) Encode(ns []*notification.Notification) (map[string]any, error) {
+	strs, err := common.NewNotificationStringList(ns)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := common.NewNotificationBlockList(ns)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		f.Strings: strs,
+		f.Blocks:  blocks,
+	}, nil
+}
+
+// Select removes the notifications from the attribute that is not used by the prior state,
+// get reads the prior state, so that only the configured form is stored, the strings are used by default.
+//
+// The notification strings do not contain the webhook secrets, so the prior string
+// is kept instead when it only differs by the secret that was configured.
+func (f Field) Select(item map[string]any, get func(string) any) {
+	if f.UsesBlocks(get) {
+		item[f.Strings] = nil
+		return
+	}
+	item[f.Blocks] = nil
+
+	strs, _ := item[f.Strings].([]string)
+	prior, _ := get(f.Strings).([]any)
+	for i, s := range strs {
+		for _, v := range prior {
+			if p, ok := v.(string); ok && common.RedactNotificationString(p) == s {
+				strs[i] = p
+				break
+			}
+		}
+	}
+}
+
+// HashValues returns the notifications as sorted notification strings
+// regardless of the attribute used, so they can be included within a set hash.
+// The values do not include any secrets, matching the strings stored within the state.
+func (f Field) HashValues(item map[string]any) []string {
+	var values []string
+	if strs, ok := item[f.Strings].([]any); ok {
+		for _, v := range strs {
+			s, _ := v.(string)
+			values = append(values, common.RedactNotificationString(s))
+		}
+	}
+	if blocks, ok := item[f.Blocks].([]any); ok {
+		for _, b := range blocks {
+			block, _ := b.(map[string]any)
+			n, err := common.NewNotificationFromBlock(block)
+			if err != nil {
+				// The block is still hashed so that an invalid block is not ignored.
+				values = append(values, fmt.Sprint(block))
+				continue
+			}
+			s, err := common.NewNotificationStringFromAPI(n)
+			if err != nil {
+				values = append(values, fmt.Sprint(block))
+				continue
+			}
+			values = append(values, s)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,146 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var slackBlock = map[string]any{
+	"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}},
+}
+
+func TestFieldDecode(t *testing.T) {
+	t.Parallel()
+
+	expect := []*notification.Notification{
+		{Type: "Slack", Value: &notification.SlackNotification{Type: "Slack", CredentialId: "aaa", Channel: "alerts"}},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		item   map[string]any
+		expect []*notification.Notification
+		errVal string
+	}{
+		{
+			name:   "no values set",
+			item:   map[string]any{},
+			expect: nil,
+		},
+		{
+			name:   "strings set",
+			item:   map[string]any{"notifications": []any{"Slack,aaa,alerts"}},
+			expect: expect,
+		},
+		{
+			name:   "blocks set",
+			item:   map[string]any{"notification": []any{slackBlock}},
+			expect: expect,
+		},
+		{
+			name: "both set",
+			item: map[string]any{
+				"notifications": []any{"Slack,aaa,alerts"},
+				"notification":  []any{slackBlock},
+			},
+			errVal: `only one of "notifications" or "notification" can be set`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Notifications.Decode(FromMap(tc.item))
+			assert.Equal(t, tc.expect, actual, "Must match the expected notifications")
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error message")
+			} else {
+				assert.NoError(t, err, "Must not error decoding notifications")
+			}
+		})
+	}
+}
+
+func TestFieldEncode(t *testing.T) {
+	t.Parallel()
+
+	ns, err := Notifications.Decode(FromMap(map[string]any{"notifications": []any{"Slack,aaa,alerts"}}))
+	require.NoError(t, err, "Must decode the notifications")
+
+	item, err := Notifications.Encode(ns)
+	require.NoError(t, err, "Must encode the notifications")
+	assert.Equal(t, []string{"Slack,aaa,alerts"}, item["notifications"], "Must encode the notification strings")
+	assert.Equal(t, []any{slackBlock}, item["notification"], "Must encode the notification blocks")
+
+	Notifications.Select(item, FromMap(map[string]any{"notification": []any{slackBlock}}))
+	assert.Nil(t, item["notifications"], "Must remove the notification strings")
+	assert.True(t, Notifications.UsesBlocks(FromMap(item)), "Must use the notification blocks")
+
+	item, err = Notifications.Encode(ns)
+	require.NoError(t, err, "Must encode the notifications")
+	Notifications.Select(item, FromMap(nil))
+	assert.Nil(t, item["notification"], "Must remove the notification blocks")
+	assert.False(t, Notifications.UsesBlocks(FromMap(item)), "Must use the notification strings")
+
+	_, err = Notifications.Encode([]*notification.Notification{{Type: "Unset"}})
+	assert.EqualError(t, err, "unknown type <nil> provided", "Must error with an unknown notification")
+}
+
+func TestFieldSelectSecrets(t *testing.T) {
+	t.Parallel()
+
+	ns, err := Notifications.Decode(FromMap(map[string]any{
+		"notifications": []any{"Webhook,,hunter2,http://localhost", "Webhook,,other,http://example.com"},
+	}))
+	require.NoError(t, err, "Must decode the notifications")
+
+	item, err := Notifications.Encode(ns)
+	require.NoError(t, err, "Must encode the notifications")
+	assert.Equal(t, []string{"Webhook,,,http://localhost", "Webhook,,,http://example.com"}, item["notifications"], "Must not encode the webhook secrets")
+
+	Notifications.Select(item, FromMap(map[string]any{
+		"notifications": []any{"Webhook,,hunter2,http://localhost"},
+	}))
+	assert.Equal(t, []string{"Webhook,,hunter2,http://localhost", "Webhook,,,http://example.com"}, item["notifications"], "Must only keep the configured webhook secrets")
+}
+
+func TestByKey(t *testing.T) {
+	t.Parallel()
+
+	blocks := map[string]any{"detect_label": "blocks", "notification": []any{slackBlock}}
+	strs := map[string]any{"detect_label": "strings", "notifications": []any{"Slack,aaa,alerts"}}
+	keyed := ByKey([]any{blocks, strs, nil}, "detect_label")
+	assert.Equal(t, map[string]map[string]any{"blocks": blocks, "strings": strs}, keyed, "Must match the expected items")
+}
+
+func TestFieldHashValues(t *testing.T) {
+	t.Parallel()
+
+	strs := Notifications.HashValues(map[string]any{
+		"notifications": []any{"Slack,aaa,alerts", "Email,example@com"},
+	})
+	assert.Equal(t, []string{"Email,example@com", "Slack,aaa,alerts"}, strs, "Must sort the notification strings")
+
+	blocks := Notifications.HashValues(map[string]any{
+		"notification": []any{
+			slackBlock,
+			map[string]any{"email": []any{map[string]any{"email": "example@com"}}},
+		},
+	})
+	assert.Equal(t, strs, blocks, "Must match the notification strings")
+
+	secret := Notifications.HashValues(map[string]any{
+		"notifications": []any{"Webhook,,hunter2,http://localhost"},
+	})
+	assert.Equal(t, []string{"Webhook,,,http://localhost"}, secret, "Must not include the webhook secret")
+
+	invalid := Notifications.HashValues(map[string]any{
+		"notification": []any{map[string]any{}},
+	})
+	assert.Equal(t, []string{"map[]"}, invalid, "Must still hash invalid blocks")
+}
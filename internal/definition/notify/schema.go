@@ -0,0 +1,166 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// NewSchema returns the notification block, which sets the same notifications
+// as the notification strings with a nested block for each notification type.
+func NewSchema(description string) *schema.Schema {
+	return newSchema(description, 1)
+}
+
+// NewProviderSchema returns the notification block used within the provider schema.
+//
+// The nested blocks do not set MaxItems so that they match the blocks
+// defined by the framework provider, [common.NewNotificationFromBlock]
+// ensures that they are only set once instead.
+func NewProviderSchema(description string) *schema.Schema {
+	return newSchema(description, 0)
+}
+
+func newSchema(description string, maxItems int) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: newTypeSchema(maxItems),
+		},
+	}
+}
+
+func newTypeSchema(maxItems int) map[string]*schema.Schema {
+	sm := map[string]*schema.Schema{
+		common.EmailNotificationBlock: newTypeBlock(maxItems, "Sends the notification to an email address", map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Email address to send the notification to",
+			},
+			"cc": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Email addresses to copy on the notification",
+			},
+			"bcc": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Email addresses to blind copy on the notification",
+			},
+		}),
+		common.OpsgenieNotificationBlock: newTypeBlock(maxItems, "Sends the notification to an Opsgenie responder", map[string]*schema.Schema{
+			"credential_id": newCredentialSchema(),
+			"responder_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Opsgenie responder",
+			},
+			"responder_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Opsgenie responder",
+			},
+			"responder_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Type of the Opsgenie responder",
+			},
+		}),
+		common.SlackNotificationBlock: newTypeBlock(maxItems, "Sends the notification to a Slack channel", map[string]*schema.Schema{
+			"credential_id": newCredentialSchema(),
+			"channel": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringDoesNotContainAny("#")),
+				Description:      "Name of the Slack channel, without the leading #",
+			},
+		}),
+		common.TeamNotificationBlock: newTypeBlock(maxItems, "Sends the notification to the notification policy of a team", map[string]*schema.Schema{
+			"team_id": newTeamSchema(),
+		}),
+		common.TeamEmailNotificationBlock: newTypeBlock(maxItems, "Sends the notification to the members of a team by email", map[string]*schema.Schema{
+			"team_id": newTeamSchema(),
+		}),
+		common.VictorOpsNotificationBlock: newTypeBlock(maxItems, "Sends the notification to Splunk On-Call (formerly VictorOps)", map[string]*schema.Schema{
+			"credential_id": newCredentialSchema(),
+			"routing_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Routing key used to route the notification",
+			},
+		}),
+		common.WebhookNotificationBlock: newTypeBlock(maxItems, "Sends the notification to a webhook, set either credential_id or url", map[string]*schema.Schema{
+			"credential_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the webhook integration",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret sent with the notification when url is set",
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "URL of the webhook",
+			},
+		}),
+	}
+
+	// The remaining types only need the integration credential.
+	for name, description := range map[string]string{
+		common.AmazonEventBridgeNotificationBlock: "Sends the notification to Amazon EventBridge",
+		common.BigPandaNotificationBlock:          "Sends the notification to BigPanda",
+		common.JiraNotificationBlock:              "Creates a Jira issue for the notification",
+		common.Office365NotificationBlock:         "Sends the notification to Microsoft Teams",
+		common.PagerDutyNotificationBlock:         "Sends the notification to PagerDuty",
+		common.ServiceNowNotificationBlock:        "Creates a ServiceNow incident for the notification",
+		common.SplunkPlatformNotificationBlock:    "Sends the notification to the Splunk platform",
+		common.XMattersNotificationBlock:          "Sends the notification to xMatters",
+	} {
+		sm[name] = newTypeBlock(maxItems, description, map[string]*schema.Schema{
+			"credential_id": newCredentialSchema(),
+		})
+	}
+	return sm
+}
+
+func newTypeBlock(maxItems int, description string, fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    maxItems,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func newCredentialSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "ID of the integration that sends the notification",
+	}
+}
+
+func newTeamSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "ID of the team",
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+func TestNewSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []*schema.Schema{
+		NewSchema("notification block"),
+		NewProviderSchema("notification block"),
+	} {
+		res := &schema.Resource{Schema: map[string]*schema.Schema{"notification": s}}
+		assert.NoError(t, res.InternalValidate(nil, true), "Must be a valid schema")
+
+		types := s.Elem.(*schema.Resource).Schema
+		for name := range common.NotificationBlocks {
+			assert.Contains(t, types, name, "Must define a block for each notification type")
+		}
+		assert.Len(t, types, len(common.NotificationBlocks), "Must only define the notification types")
+	}
+
+	assert.Equal(t, 1, NewSchema("").Elem.(*schema.Resource).Schema["slack"].MaxItems, "Must only allow one type block")
+	assert.Zero(t, NewProviderSchema("").Elem.(*schema.Resource).Schema["slack"].MaxItems, "Must not set MaxItems within the provider schema")
+}
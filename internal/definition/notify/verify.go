@@ -0,0 +1,92 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go/notification"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// Verify checks that the integration or team used by each of the notifications exists,
+// path is the attribute that sets the notifications and is used to report the position
+// of any notification that uses a missing target. Any nil notification is skipped.
+//
+// Requires the feature preview `provider.verify_notifications` to be enabled,
+// otherwise nothing is checked.
+func Verify(ctx context.Context, meta any, path string, ns []*notification.Notification) error {
+	targets := pmeta.LoadNotificationTargets(ctx, meta)
+	if targets == nil || len(ns) == 0 {
+		return nil
+	}
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for i, n := range ns {
+		kind, id := target(n)
+		if id == "" {
+			continue
+		}
+		found, err := targets.Exists(ctx, client, kind, id)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to check notification target, skipping", tfext.ErrorLogFields(err).
+				Field("path", fmt.Sprintf("%s[%d]", path, i)),
+			)
+			continue
+		}
+		if !found {
+			errs = errors.Join(errs, fmt.Errorf("%s[%d]: %s %q used by the %s notification does not exist", path, i, kind, id, n.Type))
+		}
+	}
+	return errs
+}
+
+// target returns the kind and id of the object that the notification is sent to,
+// the id is empty when the notification is not sent to an integration or team.
+func target(n *notification.Notification) (kind, id string) {
+	if n == nil {
+		return "", ""
+	}
+	switch v := n.Value.(type) {
+	case *notification.AmazonEventBrigeNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.BigPandaNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.JiraNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.Office365Notification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.OpsgenieNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.PagerDutyNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.ServiceNowNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.SlackNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.SplunkPlatformNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.VictorOpsNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.WebhookNotification:
+		// Webhooks sent to a URL do not use an integration.
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.XMattersNotification:
+		return pmeta.NotificationTargetIntegration, v.CredentialId
+	case *notification.TeamNotification:
+		return pmeta.NotificationTargetTeam, v.Team
+	case *notification.TeamEmailNotification:
+		return pmeta.NotificationTargetTeam, v.Team
+	}
+	return "", ""
+}
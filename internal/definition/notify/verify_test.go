@@ -0,0 +1,91 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			http.Error(w, "not found", http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/broken"):
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := signalfx.NewClient("token", signalfx.HTTPClient(s.Client()), signalfx.APIUrl(s.URL))
+	require.NoError(t, err, "Must create the client")
+
+	newMeta := func(enabled bool) *pmeta.Meta {
+		r := feature.NewRegistry()
+		if enabled {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications, feature.WithPreviewGlobalAvailable())
+		} else {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications)
+		}
+		return &pmeta.Meta{
+			Registry:            r,
+			Client:              client,
+			NotificationTargets: pmeta.NewNotificationTargets(),
+		}
+	}
+
+	ns := []*notification.Notification{
+		{Type: "Slack", Value: &notification.SlackNotification{Type: "Slack", CredentialId: "aaa", Channel: "alerts"}},
+		nil,
+		{Type: "PagerDuty", Value: &notification.PagerDutyNotification{Type: "PagerDuty", CredentialId: "missing"}},
+		{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "oncall@example.com"}},
+		{Type: "Webhook", Value: &notification.WebhookNotification{Type: "Webhook", Url: "http://localhost"}},
+		{Type: "Opsgenie", Value: &notification.OpsgenieNotification{Type: "Opsgenie", CredentialId: "broken"}},
+		{Type: "TeamEmail", Value: &notification.TeamEmailNotification{Type: "TeamEmail", Team: "missing"}},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		meta   *pmeta.Meta
+		errVal string
+	}{
+		{
+			name:   "preview not enabled",
+			meta:   newMeta(false),
+			errVal: "",
+		},
+		{
+			name: "missing targets reported",
+			meta: newMeta(true),
+			errVal: "rule.notifications[2]: integration \"missing\" used by the PagerDuty notification does not exist\n" +
+				"rule.notifications[6]: team \"missing\" used by the TeamEmail notification does not exist",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Verify(t.Context(), tc.meta, "rule.notifications", ns)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, err, "Must not error verifying the notifications")
+			}
+		})
+	}
+}
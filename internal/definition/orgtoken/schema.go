@@ -12,6 +12,7 @@ import (
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 )
 
 func newSchema() map[string]*schema.Schema {
@@ -46,14 +47,16 @@ func newSchema() map[string]*schema.Schema {
 			Description: "The value of the token used for API actions.",
 		},
 		"notifications": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
 			},
 			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": notify.NewSchema("Where notifications will be sent when an incident occurs, set using a nested block for each notification type. Can not be set with notifications"),
 		"host_or_usage_limits": {
 			Type:          schema.TypeSet,
 			Optional:      true,
@@ -156,13 +159,11 @@ func decodeTerraform(data *schema.ResourceData) (*orgtoken.Token, error) {
 		}
 	}
 
-	if values, ok := data.GetOk("notifications"); ok {
-		notifys, err := common.NewNotificationList(values.([]any))
-		if err != nil {
-			return nil, err
-		}
-		token.Notifications = notifys
+	notifys, err := notify.Notifications.Decode(data.Get)
+	if err != nil {
+		return nil, err
 	}
+	token.Notifications = notifys
 
 	if v, ok := data.GetOk("host_or_usage_limits"); ok {
 		limits := v.(*schema.Set).List()[0].(map[string]any)
@@ -194,17 +195,20 @@ func decodeTerraform(data *schema.ResourceData) (*orgtoken.Token, error) {
 }
 
 func encodeTerraform(token *orgtoken.Token, data *schema.ResourceData) error {
-	notifys, err := common.NewNotificationStringList(token.Notifications)
+	notifys, err := notify.Notifications.Encode(token.Notifications)
 	if err != nil {
 		return fmt.Errorf("notifications: %w", err)
 	}
+	// The notifications are kept in the form used by the prior state.
+	notify.Notifications.Select(notifys, data.Get)
 
 	errs := multierr.Combine(
 		data.Set("name", token.Name),
 		data.Set("description", token.Description),
 		data.Set("disabled", token.Disabled),
 		data.Set("auth_scopes", token.AuthScopes),
-		data.Set("notifications", notifys),
+		data.Set("notifications", notifys[notify.Notifications.Strings]),
+		data.Set("notification", notifys[notify.Notifications.Blocks]),
 		data.Set("secret", token.Secret),
 		data.Set("expires_at", token.Expiry),
 	)
@@ -106,6 +106,21 @@ func TestSchemaDecode(t *testing.T) {
 			},
 			errVal: "",
 		},
+		{
+			name: "notification block set",
+			values: map[string]any{
+				"notification": []any{
+					map[string]any{"email": []any{map[string]any{"email": "example@com"}}},
+				},
+			},
+			expect: &orgtoken.Token{
+				Limits: &orgtoken.Limit{},
+				Notifications: []*notification.Notification{
+					{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "example@com"}},
+				},
+			},
+			errVal: "",
+		},
 		{
 			name: "invalid notification",
 			values: map[string]any{
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -24,10 +25,13 @@ import (
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/detector"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/dimension"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/organization"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/tagging"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/team"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tracing"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 	"github.com/splunk-terraform/terraform-provider-signalfx/version"
 )
@@ -45,13 +49,28 @@ func New() *schema.Provider {
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", "https://api.signalfx.com"),
+				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", nil),
 				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
 			},
+			"credential_process": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SFX_CREDENTIAL_PROCESS", nil),
+				ConflictsWith: []string{"auth_token"},
+				Description:   "Command that is run to fetch the auth token, and optionally the API URL, written to stdout as JSON with the fields auth_token, api_url, and expires_at. The command is run again once the token expires. Conflicts with auth_token",
+			},
+			"realm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SFX_REALM", nil),
+				ConflictsWith: []string{"api_url"},
+				ValidateFunc:  validation.StringMatch(pmeta.RealmPattern, "must be a realm name such as us1"),
+				Description:   "Realm of your Splunk Observability Cloud org, such as us1, used to set the API URL and application URL. Conflicts with api_url",
+			},
 			"custom_app_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", "https://app.signalfx.com"),
+				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", nil),
 				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO",
 			},
 			"timeout_seconds": {
@@ -78,6 +97,20 @@ func New() *schema.Provider {
 				Default:     30,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests sent to the API every second, shared across all resources. Defaults to 0 which does not limit requests",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests to the API that can be in flight at once, shared across all resources and data sources. Defaults to 0 which does not limit requests",
+			},
 			"email": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -90,6 +123,12 @@ func New() *schema.Provider {
 				ConflictsWith: []string{"auth_token"},
 				Description:   "Used to create a session token instead of an API token, it requires the account to be configured to login with Email and Password",
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_PROFILE", nil),
+				Description: "Name of the profile to read from the provider configuration files, /etc/signalfx.conf and ~/.signalfx.conf. Uses the default profile when not set",
+			},
 			"organization_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -122,10 +161,20 @@ func New() *schema.Provider {
 				Optional:    true,
 				Description: "Allows for teams to be defined at a provider level, and apply to all applicable resources created.",
 			},
+			"tracking_tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Optional:    true,
+				Description: "Templates of the tags added to resources by the provider.track feature preview, rendered using the Project, Branch, Experimental, Commit, RunURL, Actor, and Workspace details. Tags that render as empty are not added. Defaults to the project, branch, and experimental tags",
+			},
+			"default_rule": rule.NewDefaultSchema(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			team.ResourceName:                    team.NewResource(),
-			detector.ResourceName:                detector.NewResource(),
+			detector.ResourceName:                tagging.Decorate(detector.NewResource()),
 			autoarchivesettings.ResourceName:     autoarchivesettings.NewResource(),
 			autoarchiveexemptmetric.ResourceName: autoarchiveexemptmetric.NewResource(),
 		},
@@ -139,11 +188,14 @@ func New() *schema.Provider {
 
 func configureProvider(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
 	meta := &pmeta.Meta{
-		Email:          data.Get("email").(string),
-		Password:       data.Get("password").(string),
-		OrganizationID: data.Get("organization_id").(string),
-		Tags:           convert.SliceAll(data.Get("tags").([]any), convert.ToString),
-		Teams:          convert.SliceAll(data.Get("teams").([]any), convert.ToString),
+		Email:             data.Get("email").(string),
+		Password:          data.Get("password").(string),
+		OrganizationID:    data.Get("organization_id").(string),
+		Realm:             data.Get("realm").(string),
+		Profile:           data.Get("profile").(string),
+		CredentialProcess: data.Get("credential_process").(string),
+		Tags:              convert.SliceAll(data.Get("tags").([]any), convert.ToString),
+		Teams:             convert.SliceAll(data.Get("teams").([]any), convert.ToString),
 	}
 
 	for _, lookup := range pmeta.NewDefaultProviderLookups() {
@@ -156,50 +208,107 @@ func configureProvider(ctx context.Context, data *schema.ResourceData) (any, dia
 		}
 	}
 
-	if token, ok := data.GetOk("auth_token"); ok {
+	if meta.Profile != "" && !meta.ProfileLoaded() {
+		return nil, tfext.AsErrorDiagnostics(fmt.Errorf("profile %q is not defined within the provider configuration files", meta.Profile))
+	}
+
+	if err := pmeta.CredentialProcessMetaLookupFunc().Do(ctx, meta); err != nil {
+		return nil, tfext.AsErrorDiagnostics(err)
+	}
+
+	// The credential process takes precedence over the auth token set within the environment.
+	if token, ok := data.GetOk("auth_token"); ok && meta.Credentials == nil {
 		meta.AuthToken = token.(string)
 	}
+	// ConflictsWith does not consider the realm set within the environment,
+	// so the conflict is checked here to match the framework provider.
+	if _, ok := data.GetOk("realm"); ok {
+		if raw := data.GetRawConfig(); !raw.IsNull() && raw.IsKnown() && !raw.GetAttr("api_url").IsNull() {
+			return nil, tfext.AsErrorDiagnostics(pmeta.ErrConflictingAPIEndpoint, cty.GetAttrPath("realm"))
+		}
+	}
 	if url, ok := data.GetOk("api_url"); ok {
 		meta.APIURL = url.(string)
 	}
+	if realm, ok := data.GetOk("realm"); ok {
+		meta.Realm = realm.(string)
+		meta.APIURL = pmeta.RealmAPIURL(meta.Realm)
+	}
 	if url, ok := data.GetOk("custom_app_url"); ok {
 		meta.CustomAppURL = url.(string)
 	}
 
+	// The URLs can be set by a config file, the defaults are used when they were not.
+	switch {
+	case meta.APIURL != "":
+	case meta.Realm != "":
+		meta.APIURL = pmeta.RealmAPIURL(meta.Realm)
+	default:
+		meta.APIURL = "https://api.signalfx.com"
+	}
+	switch {
+	case meta.CustomAppURL != "":
+	case meta.Realm != "":
+		meta.CustomAppURL = pmeta.RealmAppURL(meta.Realm)
+	default:
+		meta.CustomAppURL = "https://app.signalfx.com"
+	}
+
 	err := meta.Validate()
 	if err != nil {
 		return nil, tfext.AsErrorDiagnostics(err)
 	}
 
-	var (
-		attempts = data.Get("retry_max_attempts").(int)
-		timeout  = time.Duration(int64(data.Get("timeout_seconds").(int))) * time.Second
-		waitmin  = time.Duration(int64(data.Get("retry_wait_min_seconds").(int))) * time.Second
-		waitmax  = time.Duration(int64((data.Get("retry_wait_max_seconds").(int)))) * time.Second
-	)
-
-	token, err := meta.LoadSessionToken(ctx)
+	meta.DefaultRule, err = rule.DecodeDefault(data)
 	if err != nil {
-		return nil, tfext.AsErrorDiagnostics(err)
+		return nil, tfext.AsErrorDiagnostics(err, cty.GetAttrPath("default_rule"))
 	}
 
+	var (
+		attempts   = data.Get("retry_max_attempts").(int)
+		timeout    = time.Duration(int64(data.Get("timeout_seconds").(int))) * time.Second
+		waitmin    = time.Duration(int64(data.Get("retry_wait_min_seconds").(int))) * time.Second
+		waitmax    = time.Duration(int64((data.Get("retry_wait_max_seconds").(int)))) * time.Second
+		rps        = data.Get("requests_per_second").(float64)
+		concurrent = data.Get("max_concurrent_requests").(int)
+	)
+
 	rc := retryablehttp.NewClient()
 	rc.RetryMax = attempts
 	rc.RetryWaitMin = waitmin
 	rc.RetryWaitMax = waitmax
+	rc.Backoff = pmeta.RetryBackoff
 	rc.HTTPClient.Timeout = timeout
-	rc.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
-		TLSHandshakeTimeout: 5 * time.Second,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
+	meta.Cache = pmeta.GetResponseCache()
+	meta.NotificationTargets = pmeta.NewNotificationTargets()
+	limits := pmeta.GetRequestLimits()
+	limits.Configure(pmeta.TransportLimits{
+		RequestsPerSecond:     rps,
+		MaxConcurrentRequests: concurrent,
+		MaxRetryWait:          waitmax,
 	})
+	rc.HTTPClient.Transport = meta.LoadSessionSource().Transport(meta.Credentials.Transport(meta.Cache.Transport(limits.Transport(
+		logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 100,
+		}),
+	))))
+	meta.HTTPClient = tracing.NewHTTPClient(rc)
+
+	// The session token is created once the HTTP client is configured,
+	// so it shares the same transport, retries and request limits.
+	token, err := meta.LoadSessionToken(ctx)
+	if err != nil {
+		return nil, tfext.AsErrorDiagnostics(err)
+	}
 
 	meta.Client, err = signalfx.NewClient(
 		token,
 		signalfx.APIUrl(meta.APIURL),
-		signalfx.HTTPClient(rc.StandardClient()),
+		signalfx.HTTPClient(meta.HTTPClient),
 		signalfx.UserAgent(fmt.Sprintf("Terraform terraform-provider-signalfx/%s", version.ProviderVersion)),
 	)
 
@@ -211,7 +320,9 @@ func configureProvider(ctx context.Context, data *schema.ResourceData) (any, dia
 		Field("attempts", attempts).
 		Duration("timeout", timeout).
 		Duration("wait_min", waitmin).
-		Duration("wait_max", waitmax),
+		Duration("wait_max", waitmax).
+		Field("requests_per_second", rps).
+		Field("max_concurrent_requests", concurrent),
 	)
 
 	for feat, val := range data.Get("feature_preview").(map[string]any) {
@@ -220,12 +331,30 @@ func configureProvider(ctx context.Context, data *schema.ResourceData) (any, dia
 		}
 	}
 
+	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderCache); ok {
+		meta.Cache.SetEnabled(gate.Enabled())
+	}
+
+	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracing); ok && gate.Enabled() {
+		if err := tracing.Enable(ctx, version.ProviderVersion); err != nil {
+			tflog.Warn(ctx, "Unable to enable tracing", tfext.ErrorLogFields(err))
+		}
+	}
+
 	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(ctx)
+		tt, err := track.NewTagTemplate(convert.SliceAll(data.Get("tracking_tags").([]any), convert.ToString)...)
+		if err != nil {
+			return nil, tfext.AsErrorDiagnostics(err, cty.GetAttrPath("tracking_tags"))
+		}
+		tracking, err := track.ReadDetails(ctx)
 		if err != nil {
-			tflog.Info(ctx, "Unable to load git details, skipping", tfext.ErrorLogFields(err))
+			tflog.Info(ctx, "Unable to load tracking details, skipping", tfext.ErrorLogFields(err))
 		} else {
-			meta.Tags = append(meta.Tags, tracking.Tags()...)
+			tags, err := tt.Tags(*tracking)
+			if err != nil {
+				return nil, tfext.AsErrorDiagnostics(err, cty.GetAttrPath("tracking_tags"))
+			}
+			meta.Tags = append(meta.Tags, tags...)
 		}
 	}
 
@@ -8,7 +8,9 @@ import (
 	"strings"
 	"testing"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,8 +131,10 @@ func TestProviderConfiguration(t *testing.T) {
 			meta := provider.Meta()
 			if m, ok := meta.(*pmeta.Meta); ok {
 				assert.NotNil(t, m.Client, "Must have a valid client")
-				// Removing the client from the returned provider since it is hard to compare
-				m.Client = nil
+				assert.Same(t, pmeta.GetResponseCache(), m.Cache, "Must use the shared response cache")
+				assert.NotNil(t, m.NotificationTargets, "Must have the notification targets")
+				// Removing the client, cache, and notification targets from the returned provider since they are hard to compare
+				m.Client, m.HTTPClient, m.Cache, m.NotificationTargets = nil, nil, nil, nil
 			}
 
 			assert.Equal(t, tc.meta, meta, "Must match the expected value")
@@ -139,6 +143,27 @@ func TestProviderConfiguration(t *testing.T) {
 	}
 }
 
+func TestProviderConfigureRealmFromEnvironment(t *testing.T) {
+	tftest.CleanEnvVars(t)
+	t.Setenv("SFX_REALM", "eu0")
+
+	p := New()
+	block := schema.InternalMap(p.Schema).CoreConfigSchema()
+	cfg, err := ctyjson.Unmarshal(
+		[]byte(`{"auth_token":"hunter2","api_url":"https://api.us1.signalfx.com"}`),
+		block.ImpliedType(),
+	)
+	require.NoError(t, err, "Must decode the configuration")
+
+	// As done by the plugin server, the raw configuration is kept alongside the shimmed values.
+	rc := terraform.NewResourceConfigShimmed(cfg, block)
+	rc.CtyValue = cfg
+
+	diags := p.Configure(t.Context(), rc)
+	require.True(t, diags.HasError(), "Must error when the api url is configured with the realm set within the environment")
+	assert.Equal(t, pmeta.ErrConflictingAPIEndpoint.Error(), diags[0].Summary, "Must match the expected error")
+}
+
 func TestProviderTracking(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,255 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/detector"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// NewDefaultSchema returns the provider `default_rule` block.
+//
+// The block does not set MaxItems so that it matches the block
+// defined by the framework provider, [DecodeDefault] ensures
+// that it is only set once instead.
+func NewDefaultSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Sets the notifications, runbook URL, tip, reminder notification, and skip clear notification states of any signalfx_detector rule that leaves them empty. Requires the feature preview provider.default_rule to be enabled",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"notifications": {
+					Type:      schema.TypeList,
+					Optional:  true,
+					Sensitive: true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: check.Notification(),
+					},
+					Description: "List of strings specifying where notifications will be sent for any rule that does not set notifications",
+				},
+				"notification": notify.NewProviderSchema("Where notifications will be sent for any rule that does not set notifications, set using a nested block for each notification type. Can not be set with notifications"),
+				"runbook_url_prefix": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "URL that the detect label of the rule is appended to, used for any rule that does not set a runbook URL",
+				},
+				"tip": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Plain text suggested first course of action, used for any rule that does not set a tip",
+				},
+				"skip_clear_notification_states": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: check.AlertClearState(),
+					},
+					Description: "One or more alert clear states for which clear notifications are not sent, used for any rule that does not set them",
+				},
+				"reminder_notification": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Reminder notification used for any rule that does not set one",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"interval_ms": {
+								Type:        schema.TypeInt,
+								Required:    true,
+								Description: "The interval at which you want to receive the notifications, in milliseconds.",
+							},
+							"timeout_ms": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Description: "The duration during which repeat notifications are sent, in milliseconds.",
+							},
+							"type": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: check.NotificationReminderType(),
+								Description:      "Type of reminder notification. Currently, the only supported value is TIMEOUT.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DecodeDefault reads the provider `default_rule` block,
+// nil is returned when the block has not been set.
+func DecodeDefault(rd *schema.ResourceData) (*pmeta.DefaultRule, error) {
+	blocks, _ := rd.Get("default_rule").([]any)
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	if len(blocks) > 1 {
+		return nil, errors.New("only one default_rule block can be set")
+	}
+
+	dr := &pmeta.DefaultRule{}
+
+	// An empty block is read as a nil value.
+	data, ok := blocks[0].(map[string]any)
+	if !ok {
+		return dr, nil
+	}
+
+	notifys, err := notify.Notifications.Decode(notify.FromMap(data))
+	if err != nil {
+		return nil, err
+	}
+	dr.Notifications = notifys
+	dr.RunbookURLPrefix, _ = data["runbook_url_prefix"].(string)
+	dr.Tip, _ = data["tip"].(string)
+
+	if states, ok := data["skip_clear_notification_states"].(*schema.Set); ok {
+		dr.SkipClearNotificationStates = convert.SliceAll(states.List(), convert.ToString)
+	}
+
+	if reminders, ok := data["reminder_notification"].([]any); ok && len(reminders) > 1 {
+		return nil, errors.New("only one default_rule reminder_notification block can be set")
+	}
+	dr.ReminderNotification = convert.ToReminderNotification(data)
+
+	return dr, nil
+}
+
+// CustomizeDiffDefault merges the provider default rule into
+// each of the configured rules so that the values are included within the plan.
+//
+// The rules are read from the raw config, since the reminder notification of a rule
+// can not be read from the diff, and the merge is skipped if they are not yet known.
+// Any rule still missing the defaults has them applied when the resource is created or updated.
+func CustomizeDiffDefault(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	dr := pmeta.LoadProviderDefaultRule(ctx, meta)
+	if dr == nil {
+		return nil
+	}
+
+	raw := diff.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute("rule") {
+		return nil
+	}
+
+	config := raw.GetAttr("rule")
+	if config.IsNull() || !config.IsWhollyKnown() {
+		tflog.Debug(ctx, "Rules are not known, provider default rule is merged when applied")
+		return nil
+	}
+
+	rules, configured, err := decodeConfig(config)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		dr.Apply(r)
+	}
+
+	items, err := encodeRules(rules, configured)
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("rule", items)
+}
+
+// decodeConfig converts the rules from the raw config value,
+// along with the configured notifications of each rule keyed by detect label.
+func decodeConfig(config cty.Value) ([]*detector.Rule, map[string]map[string]any, error) {
+	var (
+		rules      = make([]*detector.Rule, 0, config.LengthInt())
+		configured = make(map[string]map[string]any)
+	)
+	for it := config.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		if v.IsNull() {
+			continue
+		}
+
+		r := &detector.Rule{
+			Description:          configString(v, "description"),
+			DetectLabel:          configString(v, "detect_label"),
+			Severity:             detector.Severity(configString(v, "severity")),
+			ParameterizedBody:    configString(v, "parameterized_body"),
+			ParameterizedSubject: configString(v, "parameterized_subject"),
+			RunbookUrl:           configString(v, "runbook_url"),
+			Tip:                  configString(v, "tip"),
+		}
+
+		if disabled := configAttr(v, "disabled"); !disabled.IsNull() {
+			r.Disabled = disabled.True()
+		}
+
+		notifys := map[string]any{
+			notify.Notifications.Strings: configStrings(v, notify.Notifications.Strings),
+			notify.Notifications.Blocks:  notify.ConfigValue(configAttr(v, notify.Notifications.Blocks)),
+		}
+		n, err := notify.Notifications.Decode(notify.FromMap(notifys))
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Notifications = n
+		configured[r.DetectLabel] = notifys
+
+		for _, s := range configStrings(v, "skip_clear_notification_states") {
+			r.SkipClearNotificationStates = append(r.SkipClearNotificationStates, s.(string))
+		}
+
+		if reminders := configAttr(v, "reminder_notification"); !reminders.IsNull() && reminders.LengthInt() > 0 {
+			reminder := reminders.Index(cty.NumberIntVal(0))
+			r.ReminderNotification = &detector.ReminderNotification{
+				IntervalMs: configInt(reminder, "interval_ms"),
+				TimeoutMs:  configInt(reminder, "timeout_ms"),
+				Type:       configString(reminder, "type"),
+			}
+		}
+
+		rules = append(rules, r)
+	}
+	return rules, configured, nil
+}
+
+func configAttr(v cty.Value, name string) cty.Value {
+	if !v.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return v.GetAttr(name)
+}
+
+func configString(v cty.Value, name string) string {
+	if s := configAttr(v, name); !s.IsNull() {
+		return s.AsString()
+	}
+	return ""
+}
+
+func configInt(v cty.Value, name string) int64 {
+	if n := configAttr(v, name); !n.IsNull() {
+		i, _ := n.AsBigFloat().Int64()
+		return i
+	}
+	return 0
+}
+
+func configStrings(v cty.Value, name string) (values []any) {
+	if items := configAttr(v, name); !items.IsNull() {
+		for _, item := range items.AsValueSlice() {
+			values = append(values, item.AsString())
+		}
+	}
+	return values
+}
@@ -0,0 +1,284 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestDecodeDefault(t *testing.T) {
+	t.Parallel()
+
+	provider := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"default_rule": NewDefaultSchema(),
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		values []any
+		expect *pmeta.DefaultRule
+		errVal string
+	}{
+		{
+			name:   "not set",
+			values: nil,
+			expect: nil,
+		},
+		{
+			name: "values set",
+			values: []any{
+				map[string]any{
+					"notifications":                  []any{"Email,oncall@example.com"},
+					"runbook_url_prefix":             "https://runbooks.example/",
+					"tip":                            "Check the service dashboard",
+					"skip_clear_notification_states": []any{"OK"},
+					"reminder_notification": []any{
+						map[string]any{"interval_ms": 60000, "type": "TIMEOUT"},
+					},
+				},
+			},
+			expect: &pmeta.DefaultRule{
+				Notifications: []*notification.Notification{
+					{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "oncall@example.com"}},
+				},
+				RunbookURLPrefix:            "https://runbooks.example/",
+				Tip:                         "Check the service dashboard",
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+				SkipClearNotificationStates: []string{"OK"},
+			},
+		},
+		{
+			name: "multiple blocks set",
+			values: []any{
+				map[string]any{"tip": "first"},
+				map[string]any{"tip": "second"},
+			},
+			errVal: "only one default_rule block can be set",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := provider.TestResourceData()
+			require.NoError(t, data.Set("default_rule", tc.values), "Must not error setting values")
+
+			dr, err := DecodeDefault(data)
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error decoding default rule")
+			assert.Equal(t, tc.expect, dr, "Must match the expected default rule")
+		})
+	}
+}
+
+func TestCustomizeDiffDefault(t *testing.T) {
+	t.Parallel()
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: NewSchema(),
+				},
+				Set: Hash,
+			},
+		},
+		CustomizeDiff: CustomizeDiffDefault,
+	}
+
+	ruleType := resource.CoreConfigSchema().ImpliedType().AttributeType("rule").ElementType()
+	newRule := func(values map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, t := range ruleType.AttributeTypes() {
+			attrs[name] = cty.NullVal(t)
+		}
+		for name, v := range values {
+			attrs[name] = v
+		}
+		return cty.ObjectVal(attrs)
+	}
+
+	notificationType := ruleType.AttributeType("notification").ElementType()
+	newNotification := func(name string, fields map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for n, t := range notificationType.AttributeTypes() {
+			attrs[n] = cty.NullVal(t)
+		}
+		attrs[name] = cty.ListVal([]cty.Value{cty.ObjectVal(fields)})
+		return cty.ObjectVal(attrs)
+	}
+
+	newMeta := func(enabled bool) *pmeta.Meta {
+		r := feature.NewRegistry()
+		if enabled {
+			_ = r.MustRegister(feature.PreviewProviderDefaultRule, feature.WithPreviewGlobalAvailable())
+		} else {
+			_ = r.MustRegister(feature.PreviewProviderDefaultRule)
+		}
+		return &pmeta.Meta{
+			Registry: r,
+			DefaultRule: &pmeta.DefaultRule{
+				Notifications: []*notification.Notification{
+					{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "oncall@example.com"}},
+				},
+				RunbookURLPrefix:     "https://runbooks.example/",
+				Tip:                  "Check the service dashboard",
+				ReminderNotification: &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		meta   *pmeta.Meta
+		rule   cty.Value
+		expect map[string]string
+	}{
+		{
+			name: "preview not enabled",
+			meta: newMeta(false),
+			rule: newRule(map[string]cty.Value{
+				"severity":     cty.StringVal("Critical"),
+				"detect_label": cty.StringVal("High CPU"),
+			}),
+			expect: map[string]string{
+				"detect_label": "High CPU",
+				"tip":          "",
+				"runbook_url":  "",
+			},
+		},
+		{
+			name: "defaults merged",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":     cty.StringVal("Critical"),
+				"detect_label": cty.StringVal("High CPU"),
+			}),
+			expect: map[string]string{
+				"detect_label":                        "High CPU",
+				"tip":                                 "Check the service dashboard",
+				"runbook_url":                         "https://runbooks.example/High%20CPU",
+				"notifications.0":                     "Email,oncall@example.com",
+				"reminder_notification.0.interval_ms": "60000",
+				"reminder_notification.0.type":        "TIMEOUT",
+			},
+		},
+		{
+			name: "rule values kept",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":      cty.StringVal("Critical"),
+				"detect_label":  cty.StringVal("High CPU"),
+				"tip":           cty.StringVal("Restart the service"),
+				"notifications": cty.ListVal([]cty.Value{cty.StringVal("Team,AAAAAAAAAAA")}),
+				"reminder_notification": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"interval_ms": cty.NumberIntVal(1000),
+						"timeout_ms":  cty.NumberIntVal(5000),
+						"type":        cty.StringVal("TIMEOUT"),
+					}),
+				}),
+			}),
+			expect: map[string]string{
+				"tip":                                 "Restart the service",
+				"runbook_url":                         "https://runbooks.example/High%20CPU",
+				"notifications.0":                     "Team,AAAAAAAAAAA",
+				"reminder_notification.0.interval_ms": "1000",
+				"reminder_notification.0.timeout_ms":  "5000",
+			},
+		},
+		{
+			name: "notification blocks kept",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":     cty.StringVal("Critical"),
+				"detect_label": cty.StringVal("High CPU"),
+				"notification": cty.ListVal([]cty.Value{
+					newNotification("team", map[string]cty.Value{"team_id": cty.StringVal("AAAAAAAAAAA")}),
+				}),
+			}),
+			expect: map[string]string{
+				"notifications.0":               "",
+				"notification.0.team.0.team_id": "AAAAAAAAAAA",
+				"tip":                           "Check the service dashboard",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{"rule": []any{configToMap(tc.rule)}}
+			diff, err := resource.SimpleDiff(
+				t.Context(),
+				&terraform.InstanceState{
+					RawConfig: cty.ObjectVal(map[string]cty.Value{
+						"rule": cty.SetVal([]cty.Value{tc.rule}),
+					}),
+				},
+				terraform.NewResourceConfigRaw(config),
+				tc.meta,
+			)
+			require.NoError(t, err, "Must not error calculating the diff")
+
+			actual := make(map[string]string)
+			for k, v := range diff.Attributes {
+				// Removes the set hash from the attribute name.
+				if parts := strings.SplitN(k, ".", 3); len(parts) == 3 {
+					actual[parts[2]] = v.New
+				}
+			}
+			for k, v := range tc.expect {
+				assert.Equal(t, v, actual[k], "Must match the expected value of %s", k)
+			}
+		})
+	}
+}
+
+// configToMap converts the rule to the values used by [terraform.NewResourceConfigRaw].
+func configToMap(v cty.Value) any {
+	switch {
+	case v.IsNull():
+		return nil
+	case v.Type() == cty.String:
+		return v.AsString()
+	case v.Type() == cty.Number:
+		i, _ := v.AsBigFloat().Int64()
+		return int(i)
+	case v.Type() == cty.Bool:
+		return v.True()
+	case v.Type().IsObjectType():
+		m := make(map[string]any)
+		for name, attr := range v.AsValueMap() {
+			if item := configToMap(attr); item != nil {
+				m[name] = item
+			}
+		}
+		return m
+	default:
+		var items []any
+		for _, item := range v.AsValueSlice() {
+			items = append(items, configToMap(item))
+		}
+		return items
+	}
+}
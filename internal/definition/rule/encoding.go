@@ -6,11 +6,13 @@ package rule
 import (
 	"errors"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go/detector"
 
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 )
 
 func DecodeTerraform(rd *schema.ResourceData) ([]*detector.Rule, error) {
@@ -31,18 +33,19 @@ func DecodeTerraform(rd *schema.ResourceData) ([]*detector.Rule, error) {
 			RunbookUrl:           data["runbook_url"].(string),
 			Tip:                  data["tip"].(string),
 		}
-		if n, ok := data["notifications"].([]any); ok {
-			notifiy, err := common.NewNotificationList(n)
-			if err != nil {
-				return nil, err
-			}
-			rule.Notifications = notifiy
+		notifys, err := notify.Notifications.Decode(notify.FromMap(data))
+		if err != nil {
+			return nil, err
 		}
+		rule.Notifications = notifys
 		if states, ok := data["skip_clear_notification_states"].(*schema.Set); ok {
 			for _, s := range states.List() {
 				rule.SkipClearNotificationStates = append(rule.SkipClearNotificationStates, s.(string))
 			}
 		}
+
+		rule.ReminderNotification = convert.ToReminderNotification(data)
+
 		rules = append(rules, rule)
 	}
 	return rules, nil
@@ -52,26 +55,51 @@ func EncodeTerraform(rules []*detector.Rule, rd *schema.ResourceData) error {
 	if len(rules) == 0 {
 		return nil
 	}
+	// The notifications of each rule are kept in the form used by the prior state.
+	var prior []any
+	if set, ok := rd.Get("rule").(*schema.Set); ok {
+		prior = set.List()
+	}
+	items, err := encodeRules(rules, notify.ByKey(prior, "detect_label"))
+	if err != nil {
+		return err
+	}
+	return rd.Set("rule", items)
+}
 
+// encodeRules converts the rules, prior contains the prior state of the rules
+// keyed by detect label so that their notifications are kept in the same form.
+func encodeRules(rules []*detector.Rule, prior map[string]map[string]any) ([]map[string]any, error) {
 	items := make([]map[string]any, 0, len(rules))
 	for _, r := range rules {
-		notifys, err := common.NewNotificationStringList(r.Notifications)
+		notifys, err := notify.Notifications.Encode(r.Notifications)
 		if err != nil {
-			return fmt.Errorf("notification issue: %w", err)
+			return nil, fmt.Errorf("notification issue: %w", err)
 		}
-		items = append(items, map[string]any{
+		notify.Notifications.Select(notifys, notify.FromMap(prior[r.DetectLabel]))
+
+		item := map[string]any{
 			"detect_label":                   r.DetectLabel,
 			"description":                    r.Description,
 			"disabled":                       r.Disabled,
-			"notifications":                  notifys,
 			"parameterized_body":             r.ParameterizedBody,
 			"parameterized_subject":          r.ParameterizedSubject,
 			"runbook_url":                    r.RunbookUrl,
-			"severity":                       r.Severity,
+			"severity":                       string(r.Severity),
 			"tip":                            r.Tip,
 			"skip_clear_notification_states": r.SkipClearNotificationStates,
-		})
+		}
+		maps.Copy(item, notifys)
+		if r.ReminderNotification != nil {
+			item["reminder_notification"] = []any{
+				map[string]any{
+					"interval_ms": int(r.ReminderNotification.IntervalMs),
+					"timeout_ms":  int(r.ReminderNotification.TimeoutMs),
+					"type":        r.ReminderNotification.Type,
+				},
+			}
+		}
+		items = append(items, item)
 	}
-
-	return rd.Set("rule", items)
+	return items, nil
 }
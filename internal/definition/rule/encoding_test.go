@@ -10,6 +10,7 @@ import (
 	"github.com/signalfx/signalfx-go/detector"
 	"github.com/signalfx/signalfx-go/notification"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecodeTerraform(t *testing.T) {
@@ -63,6 +64,7 @@ func TestDecodeTerraform(t *testing.T) {
 							Notifications: []*notification.Notification{
 								{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "example@com"}},
 							},
+							ReminderNotification: &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
 						},
 					},
 					data,
@@ -77,6 +79,7 @@ func TestDecodeTerraform(t *testing.T) {
 					Notifications: []*notification.Notification{
 						{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "example@com"}},
 					},
+					ReminderNotification: &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
 				},
 			},
 			errVal: "",
@@ -164,3 +167,68 @@ func TestEncodeTerraform(t *testing.T) {
 
 	}
 }
+
+func TestEncodeTerraformNotificationForm(t *testing.T) {
+	t.Parallel()
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: NewSchema(),
+				},
+				Set: Hash,
+			},
+		},
+	}
+
+	data := resource.TestResourceData()
+	require.NoError(t, data.Set("rule", []any{
+		map[string]any{
+			"severity":     "Critical",
+			"detect_label": "blocks",
+			"notification": []any{
+				map[string]any{"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}}},
+			},
+		},
+		map[string]any{
+			"severity":      "Critical",
+			"detect_label":  "strings",
+			"notifications": []any{"Slack,aaa,alerts"},
+		},
+	}), "Must set the prior rules")
+
+	rules, err := DecodeTerraform(data)
+	require.NoError(t, err, "Must decode the rules")
+	require.Len(t, rules, 2, "Must decode both rules")
+	assert.Equal(t, rules[0].Notifications, rules[1].Notifications, "Must decode both forms into the same notifications")
+
+	require.NoError(t, EncodeTerraform(rules, data), "Must encode the rules")
+	for _, v := range data.Get("rule").(*schema.Set).List() {
+		item := v.(map[string]any)
+		switch item["detect_label"] {
+		case "blocks":
+			assert.Empty(t, item["notifications"], "Must not set the notification strings")
+			assert.Len(t, item["notification"], 1, "Must keep the notification blocks")
+		case "strings":
+			assert.Equal(t, []any{"Slack,aaa,alerts"}, item["notifications"], "Must keep the notification strings")
+			assert.Empty(t, item["notification"], "Must not set the notification blocks")
+		}
+	}
+
+	both := resource.TestResourceData()
+	require.NoError(t, both.Set("rule", []any{
+		map[string]any{
+			"severity":      "Critical",
+			"detect_label":  "both",
+			"notifications": []any{"Slack,aaa,alerts"},
+			"notification": []any{
+				map[string]any{"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}}},
+			},
+		},
+	}), "Must set the rules")
+	_, err = DecodeTerraform(both)
+	assert.EqualError(t, err, `only one of "notifications" or "notification" can be set`, "Must error when both forms are set")
+}
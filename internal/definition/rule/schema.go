@@ -8,11 +8,11 @@ import (
 	"hash/crc32"
 	"io"
 	"slices"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 )
 
 func NewSchema() map[string]*schema.Schema {
@@ -34,14 +34,16 @@ func NewSchema() map[string]*schema.Schema {
 			Description: "Description of the rule",
 		},
 		"notifications": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
 			},
 			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": notify.NewSchema("Where notifications will be sent when an incident occurs, set using a nested block for each notification type. Can not be set with notifications"),
 		"disabled": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -132,14 +134,9 @@ func Hash(v any) int {
 		}
 	}
 
-	if notifys, ok := rule["notifications"].([]any); ok {
-		slices.SortFunc(notifys, func(a, b any) int {
-			return strings.Compare(a.(string), b.(string))
-		})
-
-		for _, n := range notifys {
-			_, _ = io.WriteString(hash, fmt.Sprintf("%s-", n))
-		}
+	// Both forms of the same notifications produce the same values.
+	for _, n := range notify.Notifications.HashValues(rule) {
+		_, _ = io.WriteString(hash, fmt.Sprintf("%s-", n))
 	}
 
 	if states, ok := rule["skip_clear_notification_states"].(*schema.Set); ok {
@@ -153,9 +150,9 @@ func Hash(v any) int {
 		}
 	}
 
-	if reminders, ok := rule["reminder_notification"].([]any); ok {
-		if len(reminders) > 0 {
-			reminder := reminders[0].(map[string]any)
+	// The reminder can be unset while the plan is being calculated.
+	if reminders, ok := rule["reminder_notification"].([]any); ok && len(reminders) > 0 {
+		if reminder, ok := reminders[0].(map[string]any); ok {
 			_, _ = io.WriteString(hash, fmt.Sprintf("%s-", reminder["interval_ms"]))
 			_, _ = io.WriteString(hash, fmt.Sprintf("%s-", reminder["timeout_ms"]))
 			_, _ = io.WriteString(hash, fmt.Sprintf("%s-", reminder["type"]))
@@ -61,6 +61,17 @@ func TestHashSchema(t *testing.T) {
 			},
 			code: 2915325511,
 		},
+		{
+			name: "reminder not read",
+			rule: map[string]any{
+				"description":           "my custom rule",
+				"severity":              detector.CRITICAL,
+				"detect_label":          "my-metric",
+				"disabled":              false,
+				"reminder_notification": []any{nil},
+			},
+			code: 3636232935,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -69,3 +80,27 @@ func TestHashSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestHashNotificationForms(t *testing.T) {
+	t.Parallel()
+
+	strs := map[string]any{
+		"severity":      detector.CRITICAL,
+		"detect_label":  "my-metric",
+		"notifications": []any{"Slack,aaa,alerts", "Email,example@com"},
+	}
+	blocks := map[string]any{
+		"severity":     detector.CRITICAL,
+		"detect_label": "my-metric",
+		"notification": []any{
+			map[string]any{"email": []any{map[string]any{"email": "example@com"}}},
+			map[string]any{"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}}},
+		},
+	}
+	assert.Equal(t, Hash(strs), Hash(blocks), "Must hash both forms of the same notifications the same")
+
+	blocks["notification"] = []any{
+		map[string]any{"slack": []any{map[string]any{"credential_id": "aaa", "channel": "other"}}},
+	}
+	assert.NotEqual(t, Hash(strs), Hash(blocks), "Must hash different notifications differently")
+}
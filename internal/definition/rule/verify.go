@@ -0,0 +1,58 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// CustomizeDiffNotifications checks that the integration or team used by
+// each notification of the configured rules exists, so any missing target is reported
+// within the plan. A rule without notifications checks those of the provider default rule.
+//
+// Requires the feature preview `provider.verify_notifications` to be enabled.
+func CustomizeDiffNotifications(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if pmeta.LoadNotificationTargets(ctx, meta) == nil {
+		return nil
+	}
+
+	raw := diff.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute("rule") {
+		return nil
+	}
+
+	config := raw.GetAttr("rule")
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	dr := pmeta.LoadProviderDefaultRule(ctx, meta)
+
+	var errs error
+	for it := config.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		if v.IsNull() || !v.IsKnown() {
+			continue
+		}
+		label := configAttr(v, "detect_label")
+		if label.IsNull() || !label.IsKnown() {
+			continue
+		}
+
+		name, ns := notify.Notifications.FromConfig(v)
+		if len(ns) == 0 && dr != nil {
+			name, ns = notify.Notifications.Strings, dr.Notifications
+		}
+		path := fmt.Sprintf("rule[detect_label=%q].%s", label.AsString(), name)
+		errs = errors.Join(errs, notify.Verify(ctx, meta, path, ns))
+	}
+	return errs
+}
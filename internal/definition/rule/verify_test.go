@@ -0,0 +1,174 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestCustomizeDiffNotifications(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := signalfx.NewClient("token", signalfx.HTTPClient(s.Client()), signalfx.APIUrl(s.URL))
+	require.NoError(t, err, "Must create the client")
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: NewSchema(),
+				},
+				Set: Hash,
+			},
+		},
+		CustomizeDiff: CustomizeDiffNotifications,
+	}
+
+	ruleType := resource.CoreConfigSchema().ImpliedType().AttributeType("rule").ElementType()
+	newRule := func(values map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, t := range ruleType.AttributeTypes() {
+			attrs[name] = cty.NullVal(t)
+		}
+		for name, v := range values {
+			attrs[name] = v
+		}
+		return cty.ObjectVal(attrs)
+	}
+
+	notificationType := ruleType.AttributeType("notification").ElementType()
+	newNotification := func(name string, fields map[string]cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for n, t := range notificationType.AttributeTypes() {
+			attrs[n] = cty.NullVal(t)
+		}
+		attrs[name] = cty.ListVal([]cty.Value{cty.ObjectVal(fields)})
+		return cty.ObjectVal(attrs)
+	}
+
+	newMeta := func(enabled bool) *pmeta.Meta {
+		r := feature.NewRegistry()
+		if enabled {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications, feature.WithPreviewGlobalAvailable())
+		} else {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications)
+		}
+		_ = r.MustRegister(feature.PreviewProviderDefaultRule, feature.WithPreviewGlobalAvailable())
+		return &pmeta.Meta{
+			Registry:            r,
+			Client:              client,
+			NotificationTargets: pmeta.NewNotificationTargets(),
+			DefaultRule: &pmeta.DefaultRule{
+				Notifications: []*notification.Notification{
+					{Type: "Slack", Value: &notification.SlackNotification{Type: "Slack", CredentialId: "missing", Channel: "alerts"}},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		meta   *pmeta.Meta
+		rule   cty.Value
+		errVal string
+	}{
+		{
+			name: "preview not enabled",
+			meta: newMeta(false),
+			rule: newRule(map[string]cty.Value{
+				"severity":      cty.StringVal("Critical"),
+				"detect_label":  cty.StringVal("High CPU"),
+				"notifications": cty.ListVal([]cty.Value{cty.StringVal("Team,missing")}),
+			}),
+			errVal: "",
+		},
+		{
+			name: "targets exist",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":      cty.StringVal("Critical"),
+				"detect_label":  cty.StringVal("High CPU"),
+				"notifications": cty.ListVal([]cty.Value{cty.StringVal("Team,AAAAAAAAAAA"), cty.StringVal("Slack,BBBBBBBBBBB,alerts")}),
+			}),
+			errVal: "",
+		},
+		{
+			name: "missing team within notification strings",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":      cty.StringVal("Critical"),
+				"detect_label":  cty.StringVal("High CPU"),
+				"notifications": cty.ListVal([]cty.Value{cty.StringVal("Team,AAAAAAAAAAA"), cty.StringVal("Team,missing")}),
+			}),
+			errVal: `rule[detect_label="High CPU"].notifications[1]: team "missing" used by the Team notification does not exist`,
+		},
+		{
+			name: "missing integration within notification blocks",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":     cty.StringVal("Critical"),
+				"detect_label": cty.StringVal("High CPU"),
+				"notification": cty.ListVal([]cty.Value{
+					newNotification("pagerduty", map[string]cty.Value{"credential_id": cty.StringVal("missing")}),
+				}),
+			}),
+			errVal: `rule[detect_label="High CPU"].notification[0]: integration "missing" used by the PagerDuty notification does not exist`,
+		},
+		{
+			name: "missing integration within default rule",
+			meta: newMeta(true),
+			rule: newRule(map[string]cty.Value{
+				"severity":     cty.StringVal("Critical"),
+				"detect_label": cty.StringVal("High CPU"),
+			}),
+			errVal: `rule[detect_label="High CPU"].notifications[0]: integration "missing" used by the Slack notification does not exist`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{"rule": []any{configToMap(tc.rule)}}
+			_, err := resource.SimpleDiff(
+				t.Context(),
+				&terraform.InstanceState{
+					RawConfig: cty.ObjectVal(map[string]cty.Value{
+						"rule": cty.SetVal([]cty.Value{tc.rule}),
+					}),
+				},
+				terraform.NewResourceConfigRaw(config),
+				tc.meta,
+			)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, err, "Must not error calculating the diff")
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tagging does not export a resource or data source.
+// Instead, it decorates the resources that have tags or teams
+// so that the values set by the provider are reported using the
+// computed `tags_all` and `teams_all` attributes, which keeps them
+// out of the configured `tags` and `teams` to avoid perpetual diffs.
+package tagging
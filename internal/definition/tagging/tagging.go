@@ -0,0 +1,213 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tagging
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// field is a resource attribute that has values set by the provider merged into it.
+type field struct {
+	name     string
+	provider func(ctx context.Context, meta any) []string
+}
+
+// All returns the name of the computed attribute that holds every applied value.
+func (f field) All() string {
+	return f.name + "_all"
+}
+
+var fields = []field{
+	{name: "tags", provider: pmeta.LoadProviderTags},
+	{name: "teams", provider: pmeta.LoadProviderTeams},
+}
+
+// Decorate adds the computed `tags_all` and `teams_all` attributes
+// to the resource for each of the `tags` and `teams` attributes it has.
+//
+// The attributes are planned as the provider values merged with the configured values,
+// and once the resource has been created, read, or updated, they are set to the values
+// returned by the API. Any provider value that was not configured is then removed from
+// the `tags` and `teams` attributes so they continue to match the configuration.
+func Decorate(res *schema.Resource) *schema.Resource {
+	if res == nil {
+		return nil
+	}
+
+	sm := res.SchemaMap()
+
+	var decorated []field
+	for _, f := range fields {
+		if _, ok := sm[f.name]; ok {
+			decorated = append(decorated, f)
+		}
+	}
+	if len(decorated) == 0 {
+		return res
+	}
+
+	if fn := res.SchemaFunc; fn != nil {
+		res.SchemaFunc = func() map[string]*schema.Schema {
+			return withAllSchema(fn(), decorated)
+		}
+	} else {
+		res.Schema = withAllSchema(res.Schema, decorated)
+	}
+
+	if res.CustomizeDiff != nil {
+		res.CustomizeDiff = customdiff.All(res.CustomizeDiff, customizeDiff(decorated))
+	} else {
+		res.CustomizeDiff = customizeDiff(decorated)
+	}
+
+	if res.Create != nil {
+		res.Create = wrapMethod(res.Create, decorated)
+	}
+	if res.Read != nil {
+		res.Read = wrapMethod(res.Read, decorated)
+	}
+	if res.Update != nil {
+		res.Update = wrapMethod(res.Update, decorated)
+	}
+
+	if res.CreateContext != nil {
+		res.CreateContext = wrapContextMethod(res.CreateContext, decorated)
+	}
+	if res.ReadContext != nil {
+		res.ReadContext = wrapContextMethod(res.ReadContext, decorated)
+	}
+	if res.UpdateContext != nil {
+		res.UpdateContext = wrapContextMethod(res.UpdateContext, decorated)
+	}
+
+	return res
+}
+
+func withAllSchema(sm map[string]*schema.Schema, decorated []field) map[string]*schema.Schema {
+	sm = maps.Clone(sm)
+	for _, f := range decorated {
+		sm[f.All()] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "All " + f.name + " of the resource, including those set by the provider",
+		}
+	}
+	return sm
+}
+
+func customizeDiff(decorated []field) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+		for _, f := range decorated {
+			if !diff.NewValueKnown(f.name) {
+				tflog.Debug(ctx, "Value is not known, setting computed", tfext.NewLogFields().Field("field", f.All()))
+				if err := diff.SetNewComputed(f.All()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			merged := common.Unique(f.provider(ctx, meta), values(diff.Get(f.name)))
+			if err := diff.SetNew(f.All(), merged); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func wrapMethod[Func schema.CreateFunc | schema.ReadFunc | schema.UpdateFunc](fn Func, decorated []field) Func {
+	return func(data *schema.ResourceData, meta any) error {
+		configured := loadConfigured(data, decorated)
+		if err := fn(data, meta); err != nil {
+			return err
+		}
+		return setApplied(context.Background(), data, meta, decorated, configured)
+	}
+}
+
+func wrapContextMethod[Func schema.CreateContextFunc | schema.ReadContextFunc | schema.UpdateContextFunc](fn Func, decorated []field) Func {
+	return func(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+		configured := loadConfigured(data, decorated)
+		issues := fn(ctx, data, meta)
+		if issues.HasError() {
+			return issues
+		}
+		return tfext.AppendDiagnostics(issues,
+			tfext.AsErrorDiagnostics(setApplied(ctx, data, meta, decorated, configured))...,
+		)
+	}
+}
+
+// loadConfigured reads the values before the method is called,
+// which are the planned values for create and update, and the
+// prior state for read.
+func loadConfigured(data *schema.ResourceData, decorated []field) map[string][]string {
+	configured := make(map[string][]string, len(decorated))
+	for _, f := range decorated {
+		configured[f.name] = values(data.Get(f.name))
+	}
+	return configured
+}
+
+func setApplied(ctx context.Context, data *schema.ResourceData, meta any, decorated []field, configured map[string][]string) error {
+	// The resource has been removed from the API
+	// so there are no values to update.
+	if data.Id() == "" {
+		return nil
+	}
+
+	for _, f := range decorated {
+		applied := values(data.Get(f.name))
+		if err := data.Set(f.All(), applied); err != nil {
+			return err
+		}
+		if err := data.Set(f.name, removeProviderValues(applied, f.provider(ctx, meta), configured[f.name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeProviderValues returns the applied values without any of the provider
+// values that were not also configured. The configured values are returned first,
+// in the order they were configured, so that list attributes do not report a diff
+// when the API returns the provider values ahead of them.
+func removeProviderValues(applied, provider, configured []string) []string {
+	values := make([]string, 0, len(applied))
+	for _, v := range configured {
+		if slices.Contains(applied, v) && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	for _, v := range applied {
+		if slices.Contains(values, v) || slices.Contains(provider, v) {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func values(v any) []string {
+	switch v := v.(type) {
+	case *schema.Set:
+		return convert.SchemaListAll(v, convert.ToString)
+	case []any:
+		return convert.SliceAll(v, convert.ToString)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tagging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func newMeta(enabled bool) *pmeta.Meta {
+	r := feature.NewRegistry()
+	if enabled {
+		_ = r.MustRegister(feature.PreviewProviderTags, feature.WithPreviewGlobalAvailable())
+		_ = r.MustRegister(feature.PreviewProviderTeams, feature.WithPreviewGlobalAvailable())
+	} else {
+		_ = r.MustRegister(feature.PreviewProviderTags)
+		_ = r.MustRegister(feature.PreviewProviderTeams)
+	}
+	return &pmeta.Meta{
+		Registry: r,
+		Tags:     []string{"provider-tag", "shared-tag"},
+		Teams:    []string{"provider-team"},
+	}
+}
+
+// newResource returns a resource that acts like the API by
+// merging the provider tags and teams into the values it stores.
+func newResource() *schema.Resource {
+	api := make(map[string][]string)
+	store := func(ctx context.Context, data *schema.ResourceData, meta any) {
+		api["tags"] = common.Unique(pmeta.LoadProviderTags(ctx, meta), values(data.Get("tags")))
+		api["teams"] = pmeta.MergeProviderTeams(ctx, meta, values(data.Get("teams")))
+	}
+	read := func(_ context.Context, data *schema.ResourceData, _ any) diag.Diagnostics {
+		_ = data.Set("tags", api["tags"])
+		_ = data.Set("teams", api["teams"])
+		return nil
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+			"tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"teams": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CreateContext: func(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+			store(ctx, data, meta)
+			data.SetId("id")
+			return read(ctx, data, meta)
+		},
+		ReadContext: read,
+		UpdateContext: func(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+			store(ctx, data, meta)
+			return read(ctx, data, meta)
+		},
+		DeleteContext: schema.NoopContext,
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Decorate(nil), "Must return nil when no resource is provided")
+
+	plain := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	assert.Same(t, plain, Decorate(plain), "Must return the resource")
+	assert.NotContains(t, plain.Schema, "tags_all", "Must not add tags_all without tags")
+	assert.Nil(t, plain.CustomizeDiff, "Must not add a customize diff without tags")
+
+	res := Decorate(newResource())
+	assert.Contains(t, res.Schema, "tags_all", "Must add tags_all")
+	assert.Contains(t, res.Schema, "teams_all", "Must add teams_all")
+	assert.NoError(t, res.InternalValidate(nil, true), "Must be a valid resource")
+
+	fromFunc := Decorate(&schema.Resource{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"tags": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			}
+		},
+	})
+	sm := fromFunc.SchemaMap()
+	assert.Contains(t, sm, "tags_all", "Must add tags_all to the schema func")
+	assert.NotContains(t, sm, "teams_all", "Must not add teams_all without teams")
+}
+
+func TestCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   *pmeta.Meta
+		config map[string]any
+		tags   []string
+		teams  []string
+	}{
+		{
+			name:   "preview not enabled",
+			meta:   newMeta(false),
+			config: map[string]any{"tags": []any{"my-tag"}, "teams": []any{"my-team"}},
+			tags:   []string{"my-tag"},
+			teams:  []string{"my-team"},
+		},
+		{
+			name:   "preview enabled",
+			meta:   newMeta(true),
+			config: map[string]any{"tags": []any{"my-tag", "shared-tag"}},
+			tags:   []string{"my-tag", "provider-tag", "shared-tag"},
+			teams:  []string{"provider-team"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := Decorate(newResource()).SimpleDiff(
+				t.Context(),
+				&terraform.InstanceState{},
+				terraform.NewResourceConfigRaw(tc.config),
+				tc.meta,
+			)
+			require.NoError(t, err, "Must not error calculating the diff")
+
+			actual := make(map[string][]string)
+			for k, v := range diff.Attributes {
+				name, hash, ok := strings.Cut(k, ".")
+				if ok && hash != "#" && strings.HasSuffix(name, "_all") {
+					actual[name] = append(actual[name], v.New)
+				}
+			}
+			assert.ElementsMatch(t, tc.tags, actual["tags_all"], "Must plan the merged tags")
+			assert.ElementsMatch(t, tc.teams, actual["teams_all"], "Must plan the merged teams")
+		})
+	}
+}
+
+func TestApplied(t *testing.T) {
+	t.Parallel()
+
+	res := Decorate(newResource())
+	meta := newMeta(true)
+
+	data := res.TestResourceData()
+	require.NoError(t, data.Set("tags", []string{"my-tag", "shared-tag"}), "Must set tags")
+	require.NoError(t, data.Set("teams", []string{"my-team"}), "Must set teams")
+
+	require.Empty(t, res.CreateContext(t.Context(), data, meta), "Must not error creating the resource")
+	assert.Equal(t, []any{"my-tag", "shared-tag"}, data.Get("tags"), "Must keep the configured tags")
+	assert.ElementsMatch(t, []string{"provider-tag", "shared-tag", "my-tag"}, values(data.Get("tags_all")), "Must set all applied tags")
+	assert.Equal(t, []string{"my-team"}, convert.SchemaListAll(data.Get("teams"), convert.ToString), "Must keep the configured teams")
+	assert.ElementsMatch(t, []string{"provider-team", "my-team"}, values(data.Get("teams_all")), "Must set all applied teams")
+
+	// Importing the resource starts with no prior values.
+	imported := res.TestResourceData()
+	imported.SetId("id")
+	require.Empty(t, res.ReadContext(t.Context(), imported, meta), "Must not error reading the resource")
+	assert.Equal(t, []any{"my-tag"}, imported.Get("tags"), "Must remove the provider tags")
+	assert.ElementsMatch(t, []string{"provider-tag", "shared-tag", "my-tag"}, values(imported.Get("tags_all")), "Must set all applied tags")
+
+	removed := res.TestResourceData()
+	require.Empty(t, res.ReadContext(t.Context(), removed, meta), "Must not error reading a removed resource")
+	assert.Empty(t, values(removed.Get("tags_all")), "Must not set applied tags of a removed resource")
+}
+
+func TestRemoveProviderValues(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		applied    []string
+		provider   []string
+		configured []string
+		expect     []string
+	}{
+		{
+			name:    "no provider values",
+			applied: []string{"a", "b"},
+			expect:  []string{"a", "b"},
+		},
+		{
+			name:     "provider values removed",
+			applied:  []string{"p", "a"},
+			provider: []string{"p"},
+			expect:   []string{"a"},
+		},
+		{
+			name:       "configured provider values kept",
+			applied:    []string{"p", "a"},
+			provider:   []string{"p"},
+			configured: []string{"a", "p"},
+			expect:     []string{"a", "p"},
+		},
+		{
+			name:       "values not configured kept",
+			applied:    []string{"p", "b", "a"},
+			provider:   []string{"p"},
+			configured: []string{"a"},
+			expect:     []string{"a", "b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, removeProviderValues(tc.applied, tc.provider, tc.configured), "Must match the expected values")
+		})
+	}
+}
@@ -5,6 +5,9 @@ package team
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -12,6 +15,7 @@ import (
 	"github.com/signalfx/signalfx-go/team"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
@@ -31,9 +35,27 @@ func NewResource() *schema.Resource {
 		ReadContext:   newResourceRead(),
 		UpdateContext: newResourceUpdate(),
 		DeleteContext: newResourceDelete(),
+		CustomizeDiff: customizeDiffNotifications,
 	}
 }
 
+// customizeDiffNotifications checks that the integration or team used by
+// each notification of the notification policy exists.
+//
+// Requires the feature preview `provider.verify_notifications` to be enabled.
+func customizeDiffNotifications(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if pmeta.LoadNotificationTargets(ctx, meta) == nil {
+		return nil
+	}
+
+	var errs error
+	for _, category := range slices.Sorted(maps.Keys(severityFields)) {
+		name, ns := severityFields[category].FromConfig(diff.GetRawConfig())
+		errs = errors.Join(errs, notify.Verify(ctx, meta, name, ns))
+	}
+	return errs
+}
+
 func newResourceCreate() schema.CreateContextFunc {
 	return func(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
 		payload, err := decodeTerraform(rd)
@@ -9,11 +9,21 @@ import (
 	"github.com/signalfx/signalfx-go/team"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 )
 
+// severityFields are the notification lists of the team for each alert category.
+var severityFields = map[string]notify.Field{
+	"critical": {Strings: "notifications_critical", Blocks: "notification_critical"},
+	"default":  {Strings: "notifications_default", Blocks: "notification_default"},
+	"info":     {Strings: "notifications_info", Blocks: "notification_info"},
+	"major":    {Strings: "notifications_major", Blocks: "notification_major"},
+	"minor":    {Strings: "notifications_minor", Blocks: "notification_minor"},
+	"warning":  {Strings: "notifications_warning", Blocks: "notification_warning"},
+}
+
 func newSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	sm := map[string]*schema.Schema{
 		"name": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -31,8 +41,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "Members of team",
 		},
 		"notifications_critical": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -40,8 +51,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "List of notification destinations to use for the critical alerts category.",
 		},
 		"notifications_default": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -49,8 +61,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "List of notification destinations to use for the default alerts category.",
 		},
 		"notifications_info": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -58,8 +71,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "List of notification destinations to use for the info alerts category.",
 		},
 		"notifications_major": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -67,8 +81,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "List of notification destinations to use for the major alerts category.",
 		},
 		"notifications_minor": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -76,8 +91,9 @@ func newSchema() map[string]*schema.Schema {
 			Description: "List of notification destinations to use for the minor alerts category.",
 		},
 		"notifications_warning": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
@@ -90,6 +106,22 @@ func newSchema() map[string]*schema.Schema {
 			Description: "URL of the team",
 		},
 	}
+	for category, f := range severityFields {
+		sm[f.Blocks] = notify.NewSchema("Notification destinations to use for the " + category + " alerts category, set using a nested block for each notification type. Can not be set with " + f.Strings)
+	}
+	return sm
+}
+
+// notificationLists maps each alert category of the team to its notification list.
+func notificationLists(t *team.Team) map[string]*[]*notification.Notification {
+	return map[string]*[]*notification.Notification{
+		"critical": &t.NotificationLists.Critical,
+		"default":  &t.NotificationLists.Default,
+		"info":     &t.NotificationLists.Info,
+		"major":    &t.NotificationLists.Major,
+		"minor":    &t.NotificationLists.Minor,
+		"warning":  &t.NotificationLists.Warning,
+	}
 }
 
 func decodeTerraform(rd *schema.ResourceData) (*team.Team, error) {
@@ -102,21 +134,12 @@ func decodeTerraform(rd *schema.ResourceData) (*team.Team, error) {
 	for _, m := range rd.Get("members").(*schema.Set).List() {
 		t.Members = append(t.Members, m.(string))
 	}
-	for name, field := range map[string]*[]*notification.Notification{
-		"notifications_default":  &t.NotificationLists.Default,
-		"notifications_info":     &t.NotificationLists.Info,
-		"notifications_minor":    &t.NotificationLists.Minor,
-		"notifications_warning":  &t.NotificationLists.Warning,
-		"notifications_major":    &t.NotificationLists.Major,
-		"notifications_critical": &t.NotificationLists.Critical,
-	} {
-		if val, ok := rd.Get(name).([]any); ok && len(val) > 0 {
-			var err error
-			(*field), err = common.NewNotificationList(val)
-			if err != nil {
-				return nil, err
-			}
+	for category, list := range notificationLists(t) {
+		values, err := severityFields[category].Decode(rd.Get)
+		if err != nil {
+			return nil, err
 		}
+		*list = values
 	}
 	return t, nil
 }
@@ -140,28 +163,21 @@ func encodeTerraform(tm *team.Team, rd *schema.ResourceData) error {
 		}
 	}
 
-	for name, values := range map[string][]*notification.Notification{
-		"notifications_default":  tm.NotificationLists.Default,
-		"notifications_info":     tm.NotificationLists.Info,
-		"notifications_minor":    tm.NotificationLists.Minor,
-		"notifications_warning":  tm.NotificationLists.Warning,
-		"notifications_major":    tm.NotificationLists.Major,
-		"notifications_critical": tm.NotificationLists.Critical,
-	} {
-		if len(values) == 0 {
+	for category, list := range notificationLists(tm) {
+		if len(*list) == 0 {
 			continue
 		}
-		items := make([]string, len(values))
-		for i, n := range values {
-			s, err := common.NewNotificationStringFromAPI(n)
-			if err != nil {
+		f := severityFields[category]
+		items, err := f.Encode(*list)
+		if err != nil {
+			return err
+		}
+		// The notifications are kept in the form used by the prior state.
+		f.Select(items, rd.Get)
+		for _, name := range []string{f.Strings, f.Blocks} {
+			if err := rd.Set(name, items[name]); err != nil {
 				return err
 			}
-			items[i] = s
-		}
-
-		if err := rd.Set(name, items); err != nil {
-			return err
 		}
 	}
 	return nil
@@ -63,6 +63,37 @@ func TestDecodeTerraform(t *testing.T) {
 	}
 }
 
+func TestNotificationBlocks(t *testing.T) {
+	t.Parallel()
+
+	rd := schema.TestResourceDataRaw(t, newSchema(), map[string]any{
+		"name": "team",
+		"notification_critical": []any{
+			map[string]any{"team": []any{map[string]any{"team_id": "AAAAAAAAAAA"}}},
+		},
+		"notifications_major": []any{"Team,AAAAAAAAAAA"},
+	})
+
+	tm, err := decodeTerraform(rd)
+	assert.NoError(t, err, "Must not error performing decode")
+	assert.Equal(t, tm.NotificationLists.Major, tm.NotificationLists.Critical, "Must decode both forms into the same notifications")
+
+	assert.NoError(t, encodeTerraform(tm, rd), "Must not error encoding data")
+	assert.Empty(t, rd.Get("notifications_critical"), "Must not set the notification strings")
+	assert.Len(t, rd.Get("notification_critical"), 1, "Must keep the notification blocks")
+	assert.Equal(t, []any{"Team,AAAAAAAAAAA"}, rd.Get("notifications_major"), "Must keep the notification strings")
+	assert.Empty(t, rd.Get("notification_major"), "Must not set the notification blocks")
+
+	both := schema.TestResourceDataRaw(t, newSchema(), map[string]any{
+		"notification_info": []any{
+			map[string]any{"team": []any{map[string]any{"team_id": "AAAAAAAAAAA"}}},
+		},
+		"notifications_info": []any{"Team,AAAAAAAAAAA"},
+	})
+	_, err = decodeTerraform(both)
+	assert.EqualError(t, err, `only one of "notifications_info" or "notification_info" can be set`, "Must error when both forms are set")
+}
+
 func TestEncodeTerraform(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,324 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeapi provides a stateful, in memory implementation of the
+// Splunk Observability Cloud API that is used by the provider so that
+// resources can be created, read, updated, and deleted without network access.
+//
+// The fake can be used directly as an [http.Handler], or the routes can
+// be registered with the existing test mocks:
+//
+//	api := fakeapi.New()
+//	provider := fwtest.NewMock(t, api.Endpoints(), fwtest.WithMockResources(...))
+package fakeapi
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	Charts          = "chart"
+	Dashboards      = "dashboard"
+	DashboardGroups = "dashboardgroup"
+	Detectors       = "detector"
+	Integrations    = "integration"
+	MutingRules     = "alertmuting"
+	Teams           = "team"
+	Tokens          = "token"
+)
+
+const (
+	// DefaultOrganizationID is the organization the fake API belongs to.
+	DefaultOrganizationID = "FakeApiOrg0"
+	// DefaultUserID is recorded as the creator of all objects.
+	DefaultUserID = "FakeApiUser"
+	// DefaultAppURL is the application url reported by the organization.
+	DefaultAppURL = "https://app.signalfx.com"
+)
+
+// API holds the state of all objects stored within the fake.
+type API struct {
+	mu sync.Mutex
+
+	seq         uint64
+	now         func() time.Time
+	appURL      string
+	orgID       string
+	collections map[string]*collection
+	sessions    map[string]struct{}
+
+	endpoints map[string]http.Handler
+	mux       *http.ServeMux
+}
+
+var _ http.Handler = (*API)(nil)
+
+// Option allows for the fake API to be configured before it is used.
+type Option func(*API)
+
+// WithOrganization sets the organization id and app url returned by `/v2/organization`.
+func WithOrganization(id, appURL string) Option {
+	return func(api *API) {
+		api.orgID, api.appURL = id, appURL
+	}
+}
+
+// WithClock overrides the time used to set the created and updated timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(api *API) {
+		api.now = now
+	}
+}
+
+// New returns an empty fake API.
+func New(opts ...Option) *API {
+	api := &API{
+		now:      time.Now,
+		appURL:   DefaultAppURL,
+		orgID:    DefaultOrganizationID,
+		sessions: make(map[string]struct{}),
+		collections: map[string]*collection{
+			Charts:          {name: Charts, key: "id", deleted: http.StatusOK},
+			Dashboards:      {name: Dashboards, key: "id", deleted: http.StatusOK},
+			DashboardGroups: {name: DashboardGroups, key: "id", deleted: http.StatusNoContent},
+			Detectors:       {name: Detectors, key: "id", deleted: http.StatusNoContent},
+			Integrations:    {name: Integrations, key: "id", deleted: http.StatusNoContent},
+			MutingRules:     {name: MutingRules, key: "id", created: http.StatusCreated, deleted: http.StatusNoContent},
+			Teams:           {name: Teams, key: "id", deleted: http.StatusNoContent},
+			Tokens:          {name: Tokens, key: "name", deleted: http.StatusNoContent},
+		},
+	}
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	api.collections[Detectors].validate = validateDetector
+	api.collections[Dashboards].onCreate = api.addDashboardToGroup
+	api.collections[Dashboards].onDelete = api.removeDashboardFromGroup
+	api.collections[DashboardGroups].onCreate = api.addImplicitDashboard
+	api.collections[DashboardGroups].onDelete = api.deleteGroupDashboards
+	api.collections[DashboardGroups].preserve = []string{"dashboards"}
+	api.collections[Tokens].onCreate = api.newTokenSecret
+	api.collections[Tokens].preserve = []string{"secret", "latestRotation"}
+
+	api.endpoints = map[string]http.Handler{
+		"GET /v2/organization": http.HandlerFunc(api.getOrganization),
+		"POST /v2/session":     http.HandlerFunc(api.createSession),
+		"DELETE /v2/session":   http.HandlerFunc(api.deleteSession),
+
+		"POST /v2/chart/createSloChart":              api.handle(Charts, api.create),
+		"PUT /v2/chart/updateSloChart/{id}":          api.handle(Charts, api.update),
+		"POST /v2/chart/validate":                    http.HandlerFunc(api.validate(nil)),
+		"POST /v2/dashboard/validate":                http.HandlerFunc(api.validate(nil)),
+		"POST /v2/dashboardgroup/validate":           http.HandlerFunc(api.validate(nil)),
+		"POST /v2/detector/validate":                 http.HandlerFunc(api.validate(validateDetector)),
+		"PUT /v2/detector/{id}/disable":              api.handle(Detectors, api.setDetectorRules(true)),
+		"PUT /v2/detector/{id}/enable":               api.handle(Detectors, api.setDetectorRules(false)),
+		"POST /v2/token/{id}/rotate":                 api.handle(Tokens, api.rotateToken),
+		"POST /v2/team/{id}/detector/{link}":         api.handle(Teams, api.noContent),
+		"DELETE /v2/team/{id}/detector/{link}":       api.handle(Teams, api.noContent),
+		"POST /v2/team/{id}/dashboardgroup/{link}":   api.handle(Teams, api.noContent),
+		"DELETE /v2/team/{id}/dashboardgroup/{link}": api.handle(Teams, api.noContent),
+	}
+	for name := range api.collections {
+		api.endpoints["POST /v2/"+name] = api.handle(name, api.create)
+		api.endpoints["GET /v2/"+name] = api.handle(name, api.search)
+		api.endpoints["GET /v2/"+name+"/{id}"] = api.handle(name, api.read)
+		api.endpoints["PUT /v2/"+name+"/{id}"] = api.handle(name, api.update)
+		api.endpoints["DELETE /v2/"+name+"/{id}"] = api.handle(name, api.delete)
+	}
+
+	api.mux = http.NewServeMux()
+	for pattern, h := range api.endpoints {
+		api.mux.Handle(pattern, h)
+	}
+	api.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no route for %s %s", r.Method, r.URL.Path)
+	})
+
+	return api
+}
+
+// Endpoints returns the routes served by the fake API so that they
+// can be registered with another [http.ServeMux].
+func (api *API) Endpoints() map[string]http.Handler {
+	return maps.Clone(api.endpoints)
+}
+
+func (api *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mux.ServeHTTP(w, r)
+}
+
+// Put stores the object within the named collection, replacing any existing value,
+// and returns the key of the object. The object is assigned an id if it does not have one.
+func (api *API) Put(name string, obj map[string]any) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	c := api.collections[name]
+	obj = maps.Clone(obj)
+	if _, ok := obj[c.key].(string); !ok {
+		obj[c.key] = api.newID()
+	}
+	c.put(obj)
+	return obj[c.key].(string)
+}
+
+// Get returns a copy of the stored object.
+func (api *API) Get(name, key string) (map[string]any, bool) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	obj, ok := api.collections[name].items[key]
+	return maps.Clone(obj), ok
+}
+
+// Len returns the number of objects stored within the named collection.
+func (api *API) Len(name string) int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return len(api.collections[name].items)
+}
+
+// newID generates ids in the same format as the API,
+// they are unique and predictable to simplify testing.
+func (api *API) newID() string {
+	api.seq++
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], api.seq)
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+func (api *API) timestamp() int64 {
+	return api.now().UnixMilli()
+}
+
+// handle ensures that the request is authenticated and holds the lock
+// while the operation is performed on the collection.
+func (api *API) handle(name string, op func(*collection, http.ResponseWriter, *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-SF-Token") == "" {
+			writeError(w, http.StatusUnauthorized, "missing authentication token")
+			return
+		}
+
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		op(api.collections[name], w, r)
+	})
+}
+
+func (api *API) getOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-SF-Token") == "" {
+		writeError(w, http.StatusUnauthorized, "missing authentication token")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":               api.orgID,
+		"organizationName": "fakeapi",
+		"url":              api.appURL,
+	})
+}
+
+func (api *API) createSession(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" || req.Password == "" {
+		writeError(w, http.StatusBadRequest, "email and password are required")
+		return
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	token := api.newID()
+	api.sessions[token] = struct{}{}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"accessToken":    token,
+		"email":          req.Email,
+		"organizationId": api.orgID,
+		"userId":         DefaultUserID,
+		"sessionType":    "Session",
+		"createdMs":      api.timestamp(),
+	})
+}
+
+func (api *API) deleteSession(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	token := r.Header.Get("X-SF-Token")
+	if _, ok := api.sessions[token]; !ok {
+		writeError(w, http.StatusUnauthorized, "unknown session token")
+		return
+	}
+	delete(api.sessions, token)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *API) validate(check func(map[string]any) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		obj, err := decodeObject(r.Body)
+		if err == nil && check != nil {
+			err = check(obj)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%s", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (api *API) noContent(c *collection, w http.ResponseWriter, r *http.Request) {
+	if _, ok := c.items[r.PathValue("id")]; !ok {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newDecoder keeps numbers as [json.Number] so that
+// large values, such as timestamps, are returned unchanged.
+func newDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return dec
+}
+
+func decodeObject(r io.Reader) (map[string]any, error) {
+	var obj map[string]any
+	if err := newDecoder(r).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if obj == nil {
+		return nil, errors.New("invalid request body: expected an object")
+	}
+	return obj, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]any{
+		"code":    status,
+		"message": fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,216 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(tb testing.TB, api *API, token string) *signalfx.Client {
+	tb.Helper()
+
+	s := httptest.NewServer(api)
+	tb.Cleanup(s.Close)
+
+	client, err := signalfx.NewClient(token, signalfx.HTTPClient(s.Client()), signalfx.APIUrl(s.URL))
+	require.NoError(tb, err, "Must create client")
+	return client
+}
+
+func TestDetectorLifecycle(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Unix(1700000000, 0)
+	api := New(WithClock(func() time.Time { return clock }))
+	client := newTestClient(t, api, t.Name())
+
+	req := &detector.CreateUpdateDetectorRequest{
+		Name:        "CPU detector",
+		ProgramText: "detect(when(data('cpu.utilization') > 90)).publish('High CPU')",
+		Rules: []*detector.Rule{
+			{DetectLabel: "High CPU", Severity: detector.CRITICAL},
+		},
+	}
+
+	created, err := client.CreateDetector(t.Context(), req)
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, "AAAAAAAAAAE", created.Id, "Must assign an id in the API format")
+	assert.Equal(t, clock.UnixMilli(), created.Created, "Must set the created time")
+	assert.Equal(t, DefaultUserID, created.Creator, "Must set the creator")
+
+	read, err := client.GetDetector(t.Context(), created.Id)
+	require.NoError(t, err, "Must read the detector")
+	assert.Equal(t, created, read, "Must return the stored detector")
+
+	req.Name = "Renamed detector"
+	updated, err := client.UpdateDetector(t.Context(), created.Id, req)
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, "Renamed detector", updated.Name, "Must store the updated name")
+	assert.Equal(t, created.Created, updated.Created, "Must keep the created time")
+
+	require.NoError(t, client.DisableDetector(t.Context(), created.Id, []string{"High CPU"}), "Must disable the rule")
+	read, err = client.GetDetector(t.Context(), created.Id)
+	require.NoError(t, err, "Must read the detector")
+	assert.True(t, read.Rules[0].Disabled, "Must have disabled the rule")
+
+	require.NoError(t, client.DeleteDetector(t.Context(), created.Id), "Must delete the detector")
+	_, err = client.GetDetector(t.Context(), created.Id)
+	re, ok := signalfx.AsResponseError(err)
+	require.True(t, ok, "Must return a response error")
+	assert.Equal(t, http.StatusNotFound, re.Code(), "Must report the detector is missing")
+	assert.Equal(t, http.StatusNotFound, responseCode(client.DeleteDetector(t.Context(), created.Id)), "Must not delete twice")
+}
+
+func TestDetectorValidation(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, New(), t.Name())
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		label   string
+		status  int
+	}{
+		{name: "valid", program: "detect(when(data('cpu') > 90)).publish('High CPU')", label: "High CPU", status: 0},
+		{name: "syntax error", program: "detect(when(data('cpu') > 90).publish('High CPU')", label: "High CPU", status: http.StatusBadRequest},
+		{name: "unknown label", program: "detect(when(data('cpu') > 90)).publish('High CPU')", label: "Low CPU", status: http.StatusBadRequest},
+	} {
+		err := client.ValidateDetector(t.Context(), &detector.ValidateDetectorRequestModel{
+			Name:        tc.name,
+			ProgramText: tc.program,
+			Rules:       []*detector.Rule{{DetectLabel: tc.label, Severity: detector.MAJOR}},
+		})
+		assert.Equal(t, tc.status, responseCode(err), "Must match the expected status for %s", tc.name)
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	t.Parallel()
+
+	api := New()
+	client := newTestClient(t, api, t.Name())
+
+	for _, name := range []string{"alpha", "beta", "gamma", "alphabet"} {
+		api.Put(MutingRules, map[string]any{"description": name})
+		_, err := client.CreateOrgToken(t.Context(), &orgtoken.CreateUpdateTokenRequest{Name: name})
+		require.NoError(t, err, "Must create token")
+	}
+
+	results, err := client.SearchOrgTokens(t.Context(), 2, "", 0)
+	require.NoError(t, err, "Must search tokens")
+	assert.Equal(t, int32(4), results.Count, "Must report the total count")
+	if assert.Len(t, results.Results, 2, "Must limit the page size") {
+		assert.Equal(t, "alpha", results.Results[0].Name)
+		assert.Equal(t, "beta", results.Results[1].Name)
+	}
+
+	results, err = client.SearchOrgTokens(t.Context(), 2, "", 2)
+	require.NoError(t, err, "Must search tokens")
+	if assert.Len(t, results.Results, 2, "Must return the next page") {
+		assert.Equal(t, "gamma", results.Results[0].Name)
+		assert.Equal(t, "alphabet", results.Results[1].Name)
+	}
+
+	results, err = client.SearchOrgTokens(t.Context(), 10, "ALPHA", 0)
+	require.NoError(t, err, "Must search tokens")
+	assert.Equal(t, int32(2), results.Count, "Must filter by name")
+
+	rules, err := client.SearchAlertMutingRules(t.Context(), "", 3, "", 3)
+	require.NoError(t, err, "Must search muting rules")
+	assert.Equal(t, int32(4), rules.Count, "Must report the total count")
+	assert.Len(t, rules.Results, 1, "Must return the remaining rules")
+}
+
+func TestConflictsAndAuth(t *testing.T) {
+	t.Parallel()
+
+	api := New()
+	client := newTestClient(t, api, t.Name())
+
+	token, err := client.CreateOrgToken(t.Context(), &orgtoken.CreateUpdateTokenRequest{Name: "ingest"})
+	require.NoError(t, err, "Must create token")
+	assert.NotEmpty(t, token.Secret, "Must generate a secret")
+
+	_, err = client.CreateOrgToken(t.Context(), &orgtoken.CreateUpdateTokenRequest{Name: "ingest"})
+	assert.Equal(t, http.StatusConflict, responseCode(err), "Must reject duplicate token names")
+
+	updated, err := client.UpdateOrgToken(t.Context(), "ingest", &orgtoken.CreateUpdateTokenRequest{Name: "ingest", Description: "updated"})
+	require.NoError(t, err, "Must update token")
+	assert.Equal(t, token.Secret, updated.Secret, "Must keep the secret")
+
+	_, err = newTestClient(t, api, "").GetOrgToken(t.Context(), "ingest")
+	assert.Equal(t, http.StatusUnauthorized, responseCode(err), "Must require authentication")
+
+	rule, err := client.CreateAlertMutingRule(t.Context(), &alertmuting.CreateUpdateAlertMutingRuleRequest{Description: "mute"})
+	require.NoError(t, err, "Must create muting rule with the created status")
+	assert.NotEmpty(t, rule.Id, "Must assign an id")
+
+	bp, err := client.CreateBigPandaIntegration(t.Context(), &integration.BigPandaIntegration{Name: "bp", Type: "BigPanda"})
+	require.NoError(t, err, "Must create integration")
+	_, err = client.GetIntegration(t.Context(), bp.Id)
+	assert.NoError(t, err, "Must read the generic integration")
+}
+
+func TestDashboardGroups(t *testing.T) {
+	t.Parallel()
+
+	api := New()
+	client := newTestClient(t, api, t.Name())
+
+	group, err := client.CreateDashboardGroup(t.Context(), &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "group"}, false)
+	require.NoError(t, err, "Must create dashboard group")
+	assert.Len(t, group.Dashboards, 1, "Must create an implicit dashboard")
+
+	empty, err := client.CreateDashboardGroup(t.Context(), &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "empty"}, true)
+	require.NoError(t, err, "Must create dashboard group")
+	assert.Empty(t, empty.Dashboards, "Must not create an implicit dashboard")
+
+	dash, err := client.CreateDashboard(t.Context(), &dashboard.CreateUpdateDashboardRequest{Name: "dash", GroupId: empty.Id})
+	require.NoError(t, err, "Must create dashboard")
+
+	empty, err = client.GetDashboardGroup(t.Context(), empty.Id)
+	require.NoError(t, err, "Must read dashboard group")
+	assert.Equal(t, []string{dash.Id}, empty.Dashboards, "Must link the dashboard to the group")
+
+	require.NoError(t, client.DeleteDashboardGroup(t.Context(), empty.Id), "Must delete dashboard group")
+	_, err = client.GetDashboard(t.Context(), dash.Id)
+	assert.Equal(t, http.StatusNotFound, responseCode(err), "Must delete the dashboards within the group")
+	assert.Equal(t, 1, api.Len(Dashboards), "Must keep the other dashboards")
+}
+
+func TestSessionTokens(t *testing.T) {
+	t.Parallel()
+
+	api := New(WithOrganization("org-id", "https://example.signalfx.com"))
+	client := newTestClient(t, api, "")
+
+	session, err := client.CreateSessionToken(t.Context(), &sessiontoken.CreateTokenRequest{Email: "admin@example.com", Password: "secret"})
+	require.NoError(t, err, "Must create a session")
+	assert.Equal(t, "org-id", session.OrganizationID, "Must belong to the organization")
+
+	require.NoError(t, client.DeleteSessionToken(t.Context(), session.AccessToken), "Must delete the session")
+	assert.Equal(t, http.StatusUnauthorized, responseCode(client.DeleteSessionToken(t.Context(), session.AccessToken)), "Must not delete the session twice")
+}
+
+func responseCode(err error) int {
+	if re, ok := signalfx.AsResponseError(err); ok {
+		return re.Code()
+	}
+	return 0
+}
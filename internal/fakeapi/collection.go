@@ -0,0 +1,198 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultLimit matches the page size used by the API when no limit is provided.
+const defaultLimit = 50
+
+// collection stores the objects of a single API type in the order they were created.
+type collection struct {
+	name string
+	// key is the field used to identify an object within the path.
+	key string
+	// created and deleted are the status codes returned by the API,
+	// created defaults to [http.StatusOK] when unset.
+	created int
+	deleted int
+	// preserve are server managed fields that are kept when an object is updated.
+	preserve []string
+
+	validate func(obj map[string]any) error
+	onCreate func(c *collection, obj map[string]any, r *http.Request)
+	onDelete func(c *collection, obj map[string]any)
+
+	items map[string]map[string]any
+	order []string
+}
+
+func (c *collection) put(obj map[string]any) {
+	if c.items == nil {
+		c.items = make(map[string]map[string]any)
+	}
+	key := obj[c.key].(string)
+	if _, exists := c.items[key]; !exists {
+		c.order = append(c.order, key)
+	}
+	c.items[key] = obj
+}
+
+func (c *collection) remove(key string) {
+	delete(c.items, key)
+	c.order = slices.DeleteFunc(c.order, func(k string) bool { return k == key })
+}
+
+func (api *API) create(c *collection, w http.ResponseWriter, r *http.Request) {
+	obj, err := decodeObject(r.Body)
+	if err == nil && c.validate != nil {
+		err = c.validate(obj)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%s", err)
+		return
+	}
+
+	if c.key == "id" {
+		obj["id"] = api.newID()
+	}
+	key, _ := obj[c.key].(string)
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "%s is required", c.key)
+		return
+	}
+	if _, exists := c.items[key]; exists {
+		writeError(w, http.StatusConflict, "%s with %s %q already exists", c.name, c.key, key)
+		return
+	}
+
+	now := api.timestamp()
+	obj["created"] = now
+	obj["lastUpdated"] = now
+	obj["creator"] = DefaultUserID
+	obj["lastUpdatedBy"] = DefaultUserID
+
+	if c.onCreate != nil {
+		c.onCreate(c, obj, r)
+	}
+	c.put(obj)
+
+	status := c.created
+	if status == 0 {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, obj)
+}
+
+func (api *API) read(c *collection, w http.ResponseWriter, r *http.Request) {
+	obj, ok := c.items[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (api *API) update(c *collection, w http.ResponseWriter, r *http.Request) {
+	existing, ok := c.items[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+		return
+	}
+
+	obj, err := decodeObject(r.Body)
+	if err == nil && c.validate != nil {
+		err = c.validate(obj)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%s", err)
+		return
+	}
+
+	for _, field := range append([]string{c.key, "created", "creator"}, c.preserve...) {
+		if v, ok := existing[field]; ok {
+			obj[field] = v
+		}
+	}
+	obj["lastUpdated"] = api.timestamp()
+	obj["lastUpdatedBy"] = DefaultUserID
+
+	c.put(obj)
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (api *API) delete(c *collection, w http.ResponseWriter, r *http.Request) {
+	obj, ok := c.items[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+		return
+	}
+	c.remove(r.PathValue("id"))
+	if c.onDelete != nil {
+		c.onDelete(c, obj)
+	}
+	w.WriteHeader(c.deleted)
+}
+
+// search returns a page of objects that match the optional
+// `name` and `tags` query parameters in the order they were created.
+func (api *API) search(c *collection, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := queryInt(query.Get("limit"), defaultLimit)
+	if err != nil || limit < 0 {
+		writeError(w, http.StatusBadRequest, "invalid limit %q", query.Get("limit"))
+		return
+	}
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, "invalid offset %q", query.Get("offset"))
+		return
+	}
+
+	name := strings.ToLower(query.Get("name"))
+	tags := query["tags"]
+
+	results := make([]map[string]any, 0)
+	for _, key := range c.order {
+		obj := c.items[key]
+		if v, _ := obj["name"].(string); name != "" && !strings.Contains(strings.ToLower(v), name) {
+			continue
+		}
+		if len(tags) > 0 && !hasTags(obj, tags) {
+			continue
+		}
+		results = append(results, maps.Clone(obj))
+	}
+
+	count := len(results)
+	results = results[min(offset, count):min(offset+limit, count)]
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":   count,
+		"results": results,
+	})
+}
+
+func hasTags(obj map[string]any, tags []string) bool {
+	values, _ := obj["tags"].([]any)
+	for _, tag := range tags {
+		if !slices.Contains(values, any(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+func queryInt(v string, fallback int) (int, error) {
+	if v == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(v)
+}
@@ -0,0 +1,153 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
+)
+
+// validateDetector rejects detectors that the API would refuse,
+// the program text must be valid and every rule must refer to a published label.
+func validateDetector(obj map[string]any) error {
+	text, _ := obj["programText"].(string)
+	if text == "" {
+		return errors.New("programText is required")
+	}
+	prog, err := signalflow.Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid programText: %w", err)
+	}
+
+	labels, complete := prog.Labels()
+	rules, _ := obj["rules"].([]any)
+	if len(rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+	for _, rule := range rules {
+		rule, _ := rule.(map[string]any)
+		label, _ := rule["detectLabel"].(string)
+		if complete && !slices.Contains(labels, label) {
+			return fmt.Errorf("detect label %q is not published by programText", label)
+		}
+	}
+	return nil
+}
+
+// setDetectorRules enables or disables the rules by the labels provided.
+func (api *API) setDetectorRules(disabled bool) func(*collection, http.ResponseWriter, *http.Request) {
+	return func(c *collection, w http.ResponseWriter, r *http.Request) {
+		obj, ok := c.items[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+			return
+		}
+		var labels []any
+		if err := decodeJSON(r, &labels); err != nil {
+			writeError(w, http.StatusBadRequest, "%s", err)
+			return
+		}
+		rules, _ := obj["rules"].([]any)
+		for _, rule := range rules {
+			if rule, ok := rule.(map[string]any); ok && slices.Contains(labels, rule["detectLabel"]) {
+				rule["disabled"] = disabled
+			}
+		}
+		obj["lastUpdated"] = api.timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// addImplicitDashboard creates a dashboard within the new group
+// unless the request explicitly asked for an empty group.
+func (api *API) addImplicitDashboard(_ *collection, group map[string]any, r *http.Request) {
+	group["dashboards"] = []any{}
+	if r.URL.Query().Get("empty") == "true" {
+		return
+	}
+	now := api.timestamp()
+	dashboard := map[string]any{
+		"id":            api.newID(),
+		"name":          "Dashboard",
+		"groupId":       group["id"],
+		"created":       now,
+		"lastUpdated":   now,
+		"creator":       DefaultUserID,
+		"lastUpdatedBy": DefaultUserID,
+	}
+	api.collections[Dashboards].put(dashboard)
+	group["dashboards"] = []any{dashboard["id"]}
+}
+
+// deleteGroupDashboards removes all the dashboards that belonged to the deleted group.
+func (api *API) deleteGroupDashboards(_ *collection, group map[string]any) {
+	ids, _ := group["dashboards"].([]any)
+	for _, id := range ids {
+		if id, ok := id.(string); ok {
+			api.collections[Dashboards].remove(id)
+		}
+	}
+}
+
+// addDashboardToGroup links the dashboard to its group,
+// a new group is created when the dashboard does not set one.
+func (api *API) addDashboardToGroup(_ *collection, dashboard map[string]any, _ *http.Request) {
+	groups := api.collections[DashboardGroups]
+
+	id, _ := dashboard["groupId"].(string)
+	group, ok := groups.items[id]
+	if !ok {
+		now := api.timestamp()
+		group = map[string]any{
+			"id":            api.newID(),
+			"name":          dashboard["name"],
+			"dashboards":    []any{},
+			"created":       now,
+			"lastUpdated":   now,
+			"creator":       DefaultUserID,
+			"lastUpdatedBy": DefaultUserID,
+		}
+		groups.put(group)
+		dashboard["groupId"] = group["id"]
+	}
+	ids, _ := group["dashboards"].([]any)
+	group["dashboards"] = append(ids, dashboard["id"])
+}
+
+func (api *API) removeDashboardFromGroup(_ *collection, dashboard map[string]any) {
+	group, ok := api.collections[DashboardGroups].items[dashboard["groupId"].(string)]
+	if !ok {
+		return
+	}
+	ids, _ := group["dashboards"].([]any)
+	group["dashboards"] = slices.DeleteFunc(slices.Clone(ids), func(id any) bool { return id == dashboard["id"] })
+}
+
+func (api *API) newTokenSecret(_ *collection, token map[string]any, _ *http.Request) {
+	token["id"] = api.newID()
+	token["secret"] = api.newID() + api.newID()
+}
+
+// rotateToken replaces the secret of the token, the grace period is accepted but not enforced.
+func (api *API) rotateToken(c *collection, w http.ResponseWriter, r *http.Request) {
+	token, ok := c.items[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.name, r.PathValue("id"))
+		return
+	}
+	token["secret"] = api.newID() + api.newID()
+	token["latestRotation"] = api.timestamp()
+	writeJSON(w, http.StatusOK, token)
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	if err := newDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
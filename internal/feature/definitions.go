@@ -21,6 +21,11 @@ const (
 	PreviewProviderTeams    = "provider.teams"
 	PreviewProviderTags     = "provider.tags"
 	PreviewProviderTracking = "provider.track"
+	PreviewProviderCache    = "provider.cache"
+	PreviewProviderTracing  = "provider.tracing"
+
+	PreviewProviderDefaultRule         = "provider.default_rule"
+	PreviewProviderVerifyNotifications = "provider.verify_notifications"
 )
 
 var (
@@ -37,7 +42,31 @@ var (
 
 	_ = GetGlobalRegistry().MustRegister(
 		PreviewProviderTracking,
-		WithPreviewDescription("Allows for the project's VCS information to be added to the global tags to provide additional context for resources created"),
+		WithPreviewDescription("Allows for the project's VCS and CI/CD information to be added to the global tags to provide additional context for resources created, using the provider tracking_tags templates"),
 		WithPreviewAddInVersion("v9.14.0"),
 	)
+
+	_ = GetGlobalRegistry().MustRegister(
+		PreviewProviderCache,
+		WithPreviewDescription("Caches the responses of API reads in memory for the lifetime of the provider, so objects read by several resources during a plan are only fetched once. Any write to an object removes its cached responses"),
+		WithPreviewAddInVersion("v10.0.0"),
+	)
+
+	_ = GetGlobalRegistry().MustRegister(
+		PreviewProviderTracing,
+		WithPreviewDescription("Exports a span for each resource and data source operation, and for each API request made by it, using OTLP over HTTP. The exporter is configured using the standard OTEL_EXPORTER_OTLP_* environment variables"),
+		WithPreviewAddInVersion("v10.0.0"),
+	)
+
+	_ = GetGlobalRegistry().MustRegister(
+		PreviewProviderDefaultRule,
+		WithPreviewDescription("Allows for the provider default_rule block to set the notifications, runbook URL, tip, reminder notification, and skip clear notification states of any detector rule that leaves them empty"),
+		WithPreviewAddInVersion("v10.0.0"),
+	)
+
+	_ = GetGlobalRegistry().MustRegister(
+		PreviewProviderVerifyNotifications,
+		WithPreviewDescription("Checks that the integration or team used by each notification of detector rules, team notification policies, and SLO alert rules exists when the plan is created"),
+		WithPreviewAddInVersion("v10.0.0"),
+	)
 )
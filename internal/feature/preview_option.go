@@ -28,7 +28,7 @@ func WithPreviewGlobalAvailable() PreviewOption {
 
 func WithPreviewAddInVersion(version string) PreviewOption {
 	return func(g *Preview) error {
-		matched, err := regexp.MatchString(`^v[1-9]+\.[0-9]+`, version)
+		matched, err := regexp.MatchString(`^v[1-9][0-9]*\.[0-9]+`, version)
 		if err != nil {
 			return err
 		}
@@ -38,6 +38,11 @@ func TestPreviewOption(t *testing.T) {
 			fn:     WithPreviewAddInVersion("v2.1.0"),
 			errVal: "",
 		},
+		{
+			name:   "Valid AddedInVerison with multiple digits",
+			fn:     WithPreviewAddInVersion("v10.0.0"),
+			errVal: "",
+		},
 		{
 			name:   "Invalid Description",
 			fn:     WithPreviewDescription(""),
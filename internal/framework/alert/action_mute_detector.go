@@ -0,0 +1,117 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	fwtypes "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/types"
+)
+
+// actionMuteDetectorDefaultDescription is used as the muting rule description when one is not provided.
+const actionMuteDetectorDefaultDescription = "Muted by Terraform"
+
+type ActionMuteDetector struct {
+	fwembed.ActionData
+}
+
+type actionMuteDetectorModel struct {
+	DetectorID  types.String      `tfsdk:"detector_id"`
+	Duration    fwtypes.TimeRange `tfsdk:"duration"`
+	Description types.String      `tfsdk:"description"`
+}
+
+var (
+	_ action.Action              = (*ActionMuteDetector)(nil)
+	_ action.ActionWithConfigure = (*ActionMuteDetector)(nil)
+)
+
+func NewActionMuteDetector() action.Action {
+	return &ActionMuteDetector{}
+}
+
+func (md *ActionMuteDetector) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mute_detector"
+}
+
+func (md *ActionMuteDetector) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mutes the notifications of a detector starting from when the action is invoked, " +
+			"this is intended for short lived operations such as deployments. " +
+			"The muting rule created is not managed by Terraform and expires once the duration has passed.",
+		Attributes: map[string]schema.Attribute{
+			"detector_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the detector to mute.",
+			},
+			"duration": schema.StringAttribute{
+				Required:    true,
+				CustomType:  fwtypes.TimeRangeType{},
+				Description: "How long the detector is muted for, for example `2h` or `1d`.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the muting rule, defaults to `" + actionMuteDetectorDefaultDescription + "`.",
+			},
+		},
+	}
+}
+
+func (md *ActionMuteDetector) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model actionMuteDetectorModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	duration, err := model.Duration.ParseDuration()
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("duration %q must be positive", model.Duration.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid duration", err.Error())
+		return
+	}
+
+	description := actionMuteDetectorDefaultDescription
+	if !model.Description.IsNull() && model.Description.ValueString() != "" {
+		description = model.Description.ValueString()
+	}
+
+	now := time.Now()
+	rule := alertMutingRuleModel{
+		Description: types.StringValue(description),
+		Detectors:   types.ListValueMust(types.StringType, []attr.Value{model.DetectorID}),
+		Filter:      types.SetNull(types.ObjectType{}),
+		Recurrence:  types.SetNull(types.ObjectType{}),
+		StartTime:   types.Int64Value(now.Unix()),
+		StopTime:    types.Int64Value(now.Add(duration).Unix()),
+	}
+
+	payload, diags := rule.toRequest(ctx, false, now)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	details, err := md.Details().Client.CreateAlertMutingRule(ctx, payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to mute detector", err.Error())
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Detector %s is muted until %s by rule %s", model.DetectorID.ValueString(), now.Add(duration).UTC().Format(time.RFC3339), details.Id),
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestActionMuteDetectorMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &action.MetadataResponse{}
+	NewActionMuteDetector().Metadata(t.Context(), action.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_mute_detector", resp.TypeName, "Must match the expected type name")
+}
+
+func TestActionMuteDetectorInvoke(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		values      map[string]tftypes.Value
+		endpoints   map[string]http.Handler
+		description string
+		errVal      string
+	}{
+		{
+			name: "mutes detector",
+			values: map[string]tftypes.Value{
+				"detector_id": tftypes.NewValue(tftypes.String, "detector-1"),
+				"duration":    tftypes.NewValue(tftypes.String, "2h"),
+			},
+			endpoints: map[string]http.Handler{
+				"POST /v2/alertmuting": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var payload alertmuting.CreateUpdateAlertMutingRuleRequest
+					if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+
+					assert.Equal(t, actionMuteDetectorDefaultDescription, payload.Description, "Must use the default description")
+					assert.Equal(t, (2 * time.Hour).Milliseconds(), payload.StopTime-payload.StartTime, "Must mute for the duration")
+					if assert.Len(t, payload.Filters, 1, "Must only filter by detector") {
+						assert.Equal(t, alertMutingDetectorIDProperty, payload.Filters[0].Property)
+						assert.Equal(t, []string{"detector-1"}, payload.Filters[0].PropertyValue.Values)
+					}
+
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(&alertmuting.AlertMutingRule{Id: "rule-1"})
+				}),
+			},
+		},
+		{
+			name: "custom description",
+			values: map[string]tftypes.Value{
+				"detector_id": tftypes.NewValue(tftypes.String, "detector-1"),
+				"duration":    tftypes.NewValue(tftypes.String, "30m"),
+				"description": tftypes.NewValue(tftypes.String, "Deploying v2"),
+			},
+			endpoints: map[string]http.Handler{
+				"POST /v2/alertmuting": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var payload alertmuting.CreateUpdateAlertMutingRuleRequest
+					_ = json.NewDecoder(r.Body).Decode(&payload)
+
+					assert.Equal(t, "Deploying v2", payload.Description, "Must use the provided description")
+
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(&alertmuting.AlertMutingRule{Id: "rule-1"})
+				}),
+			},
+		},
+		{
+			name: "negative duration",
+			values: map[string]tftypes.Value{
+				"detector_id": tftypes.NewValue(tftypes.String, "detector-1"),
+				"duration":    tftypes.NewValue(tftypes.String, "-1h"),
+			},
+			endpoints: map[string]http.Handler{},
+			errVal:    `duration "-1h" must be positive`,
+		},
+		{
+			name: "api error",
+			values: map[string]tftypes.Value{
+				"detector_id": tftypes.NewValue(tftypes.String, "detector-1"),
+				"duration":    tftypes.NewValue(tftypes.String, "1h"),
+			},
+			endpoints: map[string]http.Handler{
+				"POST /v2/alertmuting": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					http.Error(w, "Forbidden", http.StatusForbidden)
+				}),
+			},
+			errVal: `route "/v2/alertmuting" had issues with status code 403`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			progress, diags := fwtest.InvokeMockAction(t, tc.endpoints, NewActionMuteDetector, "signalfx_mute_detector", tc.values)
+			if tc.errVal != "" {
+				if assert.Len(t, diags, 1, "Must report an error") {
+					assert.Contains(t, diags[0].Detail, tc.errVal, "Must match the expected error")
+				}
+				assert.Empty(t, progress, "Must not report progress on failure")
+				return
+			}
+
+			assert.Empty(t, diags, "Must not report any issues")
+			if assert.Len(t, progress, 1, "Must report the muting rule created") {
+				assert.Contains(t, progress[0], "rule-1", "Must include the muting rule id")
+			}
+		})
+	}
+}
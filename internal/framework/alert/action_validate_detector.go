@@ -0,0 +1,129 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/detector"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+)
+
+type ActionValidateDetector struct {
+	fwembed.ActionData
+}
+
+type actionValidateDetectorModel struct {
+	Name        types.String                      `tfsdk:"name"`
+	ProgramText types.String                      `tfsdk:"program_text"`
+	Rules       []actionValidateDetectorRuleModel `tfsdk:"rule"`
+}
+
+type actionValidateDetectorRuleModel struct {
+	DetectLabel types.String `tfsdk:"detect_label"`
+	Severity    types.String `tfsdk:"severity"`
+	Description types.String `tfsdk:"description"`
+	Disabled    types.Bool   `tfsdk:"disabled"`
+}
+
+var (
+	_ action.Action              = (*ActionValidateDetector)(nil)
+	_ action.ActionWithConfigure = (*ActionValidateDetector)(nil)
+)
+
+func NewActionValidateDetector() action.Action {
+	return &ActionValidateDetector{}
+}
+
+func (vd *ActionValidateDetector) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_validate_detector"
+}
+
+func (vd *ActionValidateDetector) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Validates the detector program and rules using the API without creating or modifying a detector.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the detector.",
+			},
+			"program_text": schema.StringAttribute{
+				Required:    true,
+				Description: "The SignalFlow program of the detector.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				Description: "The rules of the detector, each rule must reference a label published by the program.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"detect_label": schema.StringAttribute{
+							Required:    true,
+							Description: "A detect label which matches a detect label within the program text.",
+						},
+						"severity": schema.StringAttribute{
+							Required:    true,
+							Description: "The severity of the rule.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("Critical", "Major", "Minor", "Warning", "Info"),
+							},
+						},
+						"description": schema.StringAttribute{
+							Optional:    true,
+							Description: "Description of the rule.",
+						},
+						"disabled": schema.BoolAttribute{
+							Optional:    true,
+							Description: "When true, notifications and events will not be generated for the detect label.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (vd *ActionValidateDetector) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model actionValidateDetectorModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload := &detector.ValidateDetectorRequestModel{
+		Name:        model.Name.ValueString(),
+		ProgramText: model.ProgramText.ValueString(),
+	}
+	for _, rule := range model.Rules {
+		payload.Rules = append(payload.Rules, &detector.Rule{
+			DetectLabel: rule.DetectLabel.ValueString(),
+			Severity:    detector.Severity(rule.Severity.ValueString()),
+			Description: rule.Description.ValueString(),
+			Disabled:    rule.Disabled.ValueBool(),
+		})
+	}
+
+	if err := vd.Details().Client.ValidateDetector(ctx, payload); err != nil {
+		details := err.Error()
+		if re, ok := signalfx.AsResponseError(err); ok {
+			details = fmt.Sprintf("%s: %q", err, re.Details())
+		}
+		resp.Diagnostics.AddError("Detector is not valid", details)
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Detector %q is valid", model.Name.ValueString()),
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestActionValidateDetectorMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &action.MetadataResponse{}
+	NewActionValidateDetector().Metadata(t.Context(), action.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_validate_detector", resp.TypeName, "Must match the expected type name")
+}
+
+func TestActionValidateDetectorInvoke(t *testing.T) {
+	t.Parallel()
+
+	ruleType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"detect_label": tftypes.String,
+		"severity":     tftypes.String,
+		"description":  tftypes.String,
+		"disabled":     tftypes.Bool,
+	}}
+
+	values := map[string]tftypes.Value{
+		"name":         tftypes.NewValue(tftypes.String, "CPU detector"),
+		"program_text": tftypes.NewValue(tftypes.String, "detect(when(data('cpu.utilization') > 90)).publish('High CPU')"),
+		"rule": tftypes.NewValue(tftypes.List{ElementType: ruleType}, []tftypes.Value{
+			tftypes.NewValue(ruleType, map[string]tftypes.Value{
+				"detect_label": tftypes.NewValue(tftypes.String, "High CPU"),
+				"severity":     tftypes.NewValue(tftypes.String, "Critical"),
+				"description":  tftypes.NewValue(tftypes.String, nil),
+				"disabled":     tftypes.NewValue(tftypes.Bool, nil),
+			}),
+		}),
+	}
+
+	for _, tc := range []struct {
+		name   string
+		status int
+		errVal string
+	}{
+		{
+			name:   "valid detector",
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "invalid detector",
+			status: http.StatusBadRequest,
+			errVal: `route "/v2/detector/validate" had issues with status code 400`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoints := map[string]http.Handler{
+				"POST /v2/detector/validate": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var payload detector.ValidateDetectorRequestModel
+					if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+
+					assert.Equal(t, "CPU detector", payload.Name, "Must send the detector name")
+					if assert.Len(t, payload.Rules, 1, "Must send the detector rules") {
+						assert.Equal(t, "High CPU", payload.Rules[0].DetectLabel)
+						assert.Equal(t, detector.CRITICAL, payload.Rules[0].Severity)
+					}
+
+					if tc.status != http.StatusNoContent {
+						http.Error(w, "Unknown detect label", tc.status)
+						return
+					}
+					w.WriteHeader(tc.status)
+				}),
+			}
+
+			progress, diags := fwtest.InvokeMockAction(t, endpoints, NewActionValidateDetector, "signalfx_validate_detector", values)
+			if tc.errVal != "" {
+				if assert.Len(t, diags, 1, "Must report an error") {
+					assert.Contains(t, diags[0].Detail, tc.errVal, "Must match the expected error")
+				}
+				return
+			}
+
+			assert.Empty(t, diags, "Must not report any issues")
+			assert.Equal(t, []string{`Detector "CPU detector" is valid`}, progress, "Must report the detector is valid")
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// alertMutingRuleDefaultInclude only returns muting rules that are
+// currently active or scheduled, since expired rules can not be managed.
+const alertMutingRuleDefaultInclude = "Open"
+
+type ListAlertMutingRule struct {
+	fwembed.ResourceData
+}
+
+type listAlertMutingRuleModel struct {
+	Description types.String `tfsdk:"description"`
+	Include     types.String `tfsdk:"include"`
+}
+
+var (
+	_ list.ListResource              = (*ListAlertMutingRule)(nil)
+	_ list.ListResourceWithConfigure = (*ListAlertMutingRule)(nil)
+)
+
+func NewListAlertMutingRule() list.ListResource {
+	return &ListAlertMutingRule{}
+}
+
+func (lr *ListAlertMutingRule) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_muting_rule"
+}
+
+func (lr *ListAlertMutingRule) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the existing alert muting rules so they can be imported.",
+		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return muting rules with a description containing this value, the match is case insensitive.",
+			},
+			"include": schema.StringAttribute{
+				Optional:    true,
+				Description: "Restricts the muting rules returned by their state, for example `Ongoing` or `Future`. Defaults to `" + alertMutingRuleDefaultInclude + "`.",
+			},
+		},
+	}
+}
+
+func (lr *ListAlertMutingRule) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listAlertMutingRuleModel
+	if diags := req.Config.Get(ctx, &config); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := pmeta.LoadClient(ctx, lr.Details())
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic("Unable to load client", err.Error()),
+		})
+		return
+	}
+
+	include := alertMutingRuleDefaultInclude
+	if v := config.Include.ValueString(); v != "" {
+		include = v
+	}
+	description := strings.ToLower(config.Description.ValueString())
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var (
+			pageSize = 100
+			count    int64
+		)
+
+		for offset := 0; ; offset += pageSize {
+			rules, err := client.SearchAlertMutingRules(ctx, include, pageSize, "", offset)
+			if err != nil {
+				push(list.ListResult{Diagnostics: diag.Diagnostics{
+					diag.NewErrorDiagnostic("Unable to fetch alert muting rules", err.Error()),
+				}})
+				return
+			}
+
+			for _, rule := range rules.Results {
+				if !strings.Contains(strings.ToLower(rule.Description), description) {
+					continue
+				}
+
+				result := req.NewListResult(ctx)
+				result.DisplayName = rule.Description
+				result.Diagnostics.Append(lr.SetIdentity(ctx, result.Identity, rule.Id)...)
+
+				if req.IncludeResource {
+					var model alertMutingRuleModel
+					result.Diagnostics.Append(model.updateFromRule(ctx, &rule)...)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+				}
+
+				if !push(result) {
+					return
+				}
+
+				if count++; req.Limit > 0 && count >= req.Limit {
+					return
+				}
+			}
+
+			if len(rules.Results) < pageSize {
+				return
+			}
+		}
+	}
+}
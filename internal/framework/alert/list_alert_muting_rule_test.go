@@ -0,0 +1,170 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwalert
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestListAlertMutingRuleMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &resource.MetadataResponse{}
+	NewListAlertMutingRule().Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_alert_muting_rule", resp.TypeName, "Must match the expected type name")
+}
+
+func TestListAlertMutingRuleSchema(t *testing.T) {
+	t.Parallel()
+
+	resp := &list.ListResourceSchemaResponse{}
+	NewListAlertMutingRule().ListResourceConfigSchema(t.Context(), list.ListResourceSchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "Must not report any issues")
+	assert.NotEmpty(t, resp.Schema.Description, "Must have a description set")
+	assert.True(t, resp.Schema.Attributes["description"].IsOptional(), "Description filter must be optional")
+	assert.True(t, resp.Schema.Attributes["include"].IsOptional(), "Include filter must be optional")
+}
+
+func TestListAlertMutingRuleMockIntegration(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		description string
+		include     string
+		expect      map[string]string
+	}{
+		{
+			name:    "all open rules",
+			include: "Open",
+			expect: map[string]string{
+				"rule-1": "Weekly maintenance",
+				"rule-2": "Deploy window",
+			},
+		},
+		{
+			name:        "filtered by description",
+			description: "MAINTENANCE",
+			include:     "Ongoing",
+			expect: map[string]string{
+				"rule-1": "Weekly maintenance",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoints := map[string]http.Handler{
+				"GET /v2/alertmuting": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					assert.Equal(t, tc.include, r.URL.Query().Get("include"), "Must request the expected rules")
+					_ = json.NewEncoder(w).Encode(&alertmuting.SearchResult{
+						Count: 2,
+						Results: []alertmuting.AlertMutingRule{
+							{
+								Id:          "rule-1",
+								Description: "Weekly maintenance",
+								StartTime:   1000,
+								Filters: []*alertmuting.AlertMutingRuleFilter{
+									{Property: alertMutingDetectorIDProperty, PropertyValue: alertmuting.StringOrArray{Values: []string{"detector-1"}}},
+								},
+							},
+							{
+								Id:          "rule-2",
+								Description: "Deploy window",
+								StartTime:   2000,
+								Filters: []*alertmuting.AlertMutingRuleFilter{
+									{Property: "service", PropertyValue: alertmuting.StringOrArray{Values: []string{"api"}}},
+								},
+							},
+						},
+					})
+				}),
+			}
+
+			server, err := providerserver.NewProtocol5WithError(fwtest.NewMock(
+				t,
+				endpoints,
+				fwtest.WithMockResources(NewResourceAlertMutingRule),
+				fwtest.WithMockListResources(NewListAlertMutingRule),
+			))()
+			require.NoError(t, err, "Must create provider server")
+
+			providerConfig, err := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+			require.NoError(t, err, "Must create provider config")
+
+			configured, err := server.ConfigureProvider(t.Context(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+			require.NoError(t, err, "Must configure provider")
+			require.Empty(t, configured.Diagnostics, "Must not report issues configuring provider")
+
+			configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"description": tftypes.String,
+				"include":     tftypes.String,
+			}}
+			values := map[string]tftypes.Value{
+				"description": tftypes.NewValue(tftypes.String, nil),
+				"include":     tftypes.NewValue(tftypes.String, nil),
+			}
+			if tc.description != "" {
+				values["description"] = tftypes.NewValue(tftypes.String, tc.description)
+			}
+			if tc.include != alertMutingRuleDefaultInclude {
+				values["include"] = tftypes.NewValue(tftypes.String, tc.include)
+			}
+			config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+			require.NoError(t, err, "Must create list config")
+
+			lister, ok := server.(tfprotov5.ProviderServerWithListResource)
+			require.True(t, ok, "Must support list resources")
+
+			stream, err := lister.ListResource(t.Context(), &tfprotov5.ListResourceRequest{
+				TypeName:        "signalfx_alert_muting_rule",
+				Config:          &config,
+				IncludeResource: true,
+			})
+			require.NoError(t, err, "Must not error listing resources")
+
+			actual := make(map[string]string)
+			for result := range stream.Results {
+				require.Empty(t, result.Diagnostics, "Must not report any issues")
+				require.NotNil(t, result.Identity, "Must set the identity")
+				require.NotNil(t, result.Resource, "Must include the resource")
+
+				identity, err := result.Identity.IdentityData.Unmarshal(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"id":     tftypes.String,
+					"org_id": tftypes.String,
+					"realm":  tftypes.String,
+				}})
+				require.NoError(t, err, "Must be able to read identity")
+
+				var fields map[string]tftypes.Value
+				require.NoError(t, identity.As(&fields))
+
+				var id string
+				require.NoError(t, fields["id"].As(&id))
+				actual[id] = result.DisplayName
+			}
+
+			assert.Equal(t, tc.expect, actual, "Must match the expected results")
+		})
+	}
+}
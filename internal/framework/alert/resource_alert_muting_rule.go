@@ -64,6 +64,7 @@ var (
 	_ resource.Resource                = &ResourceAlertMutingRule{}
 	_ resource.ResourceWithConfigure   = &ResourceAlertMutingRule{}
 	_ resource.ResourceWithImportState = &ResourceAlertMutingRule{}
+	_ resource.ResourceWithIdentity    = &ResourceAlertMutingRule{}
 )
 
 func NewResourceAlertMutingRule() resource.Resource {
@@ -185,6 +186,7 @@ func (amr *ResourceAlertMutingRule) Create(ctx context.Context, req resource.Cre
 	resp.Diagnostics.Append(model.updateFromRule(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(amr.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
@@ -203,6 +205,7 @@ func (amr *ResourceAlertMutingRule) Read(ctx context.Context, req resource.ReadR
 	resp.Diagnostics.Append(model.updateFromRule(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(amr.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
@@ -235,6 +238,7 @@ func (amr *ResourceAlertMutingRule) Update(ctx context.Context, req resource.Upd
 	resp.Diagnostics.Append(model.updateFromRule(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(amr.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
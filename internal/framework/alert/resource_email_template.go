@@ -45,6 +45,7 @@ var (
 	_ resource.Resource                = &ResourceEmailTemplate{}
 	_ resource.ResourceWithConfigure   = &ResourceEmailTemplate{}
 	_ resource.ResourceWithImportState = &ResourceEmailTemplate{}
+	_ resource.ResourceWithIdentity    = &ResourceEmailTemplate{}
 )
 
 func NewResourceEmailTemplate() resource.Resource {
@@ -144,6 +145,7 @@ func (et *ResourceEmailTemplate) Create(ctx context.Context, req resource.Create
 	resp.Diagnostics.Append(model.updateFromEmailTemplate(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(et.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
@@ -162,6 +164,7 @@ func (et *ResourceEmailTemplate) Read(ctx context.Context, req resource.ReadRequ
 	resp.Diagnostics.Append(model.updateFromEmailTemplate(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(et.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
@@ -193,6 +196,7 @@ func (et *ResourceEmailTemplate) Update(ctx context.Context, req resource.Update
 	resp.Diagnostics.Append(model.updateFromEmailTemplate(ctx, details)...)
 	if !resp.Diagnostics.HasError() {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		resp.Diagnostics.Append(et.SetIdentity(ctx, resp.Identity, model.ID.ValueString())...)
 	}
 }
 
@@ -0,0 +1,41 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// ActionData is an embeddable struct that provides common functionality for actions,
+// since it implements the extended method required for [action.ActionWithConfigure].
+type ActionData struct {
+	meta *pmeta.Meta
+}
+
+func (ad *ActionData) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// The configure can be called before the provider has actually been configured.
+	// To avoid against erroring early when this happens, the configure method should just return instead
+	if req.ProviderData == nil {
+		return
+	}
+
+	if meta, ok := req.ProviderData.(*pmeta.Meta); !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Empty(),
+			"Invalid Provider Data",
+			"Provider data must be configured before using the action.",
+		)
+	} else {
+		ad.meta = meta
+	}
+}
+
+func (ad *ActionData) Details() *pmeta.Meta {
+	return ad.meta
+}
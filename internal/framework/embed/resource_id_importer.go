@@ -8,13 +8,38 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 )
 
 // ResourceIDImporter is an embedable type that will
-// enable the resource to be imported by using the provided ID to fetch from the API.
-// It implements the additional method required by [resource.ResourceWithImportState].
+// enable the resource to be imported by using the provided ID or identity to fetch from the API.
+// It implements the additional methods required by [resource.ResourceWithImportState]
+// and [resource.ResourceWithIdentity], the resource must set the identity using [ResourceData.SetIdentity].
 type ResourceIDImporter struct{}
 
+func (ResourceIDImporter) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the resource.",
+			},
+			"org_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the organization that owns the resource.",
+			},
+			"realm": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The realm of the organization that owns the resource.",
+			},
+		},
+	}
+}
+
 func (ResourceIDImporter) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
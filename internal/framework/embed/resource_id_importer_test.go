@@ -83,3 +83,37 @@ func TestResourceIDImporter_ImportState(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceIDImporter_ImportStateWithIdentity(t *testing.T) {
+	t.Parallel()
+
+	identity := newTestIdentity(t, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "identity-id"),
+		"org_id": tftypes.NewValue(tftypes.String, nil),
+		"realm":  tftypes.NewValue(tftypes.String, nil),
+	})
+
+	stateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			Raw: tftypes.NewValue(stateType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+		Identity: identity,
+	}
+
+	ResourceIDImporter{}.ImportState(t.Context(), resource.ImportStateRequest{Identity: identity}, resp)
+	assert.Empty(t, resp.Diagnostics, "Must not report any issues")
+
+	var id string
+	assert.Empty(t, resp.State.GetAttribute(t.Context(), path.Root("id"), &id), "Must be able to read the id")
+	assert.Equal(t, "identity-id", id, "Must set the id from the identity")
+}
@@ -0,0 +1,84 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// ResourceIdentityModel is the identity defined by [ResourceIDImporter],
+// the organization and realm allow for the same configuration to be used across several organizations.
+type ResourceIdentityModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"org_id"`
+	Realm          types.String `tfsdk:"realm"`
+}
+
+// NewIdentity returns the identity of the resource using the configured organization and realm.
+// The organization is looked up the first time it is needed when it has not been configured,
+// the identity is returned without it when the lookup fails.
+func (rd *ResourceData) NewIdentity(ctx context.Context, id string) *ResourceIdentityModel {
+	identity := &ResourceIdentityModel{
+		ID:             types.StringValue(id),
+		OrganizationID: types.StringNull(),
+		Realm:          types.StringNull(),
+	}
+	if rd.meta == nil {
+		return identity
+	}
+	if orgID, err := rd.meta.LookupOrganizationID(ctx); err != nil {
+		tflog.Warn(ctx, "Unable to look up the organization ID", tfext.ErrorLogFields(err))
+	} else if orgID != "" {
+		identity.OrganizationID = types.StringValue(orgID)
+	}
+	if realm := rd.meta.LoadRealm(); realm != "" {
+		identity.Realm = types.StringValue(realm)
+	}
+	return identity
+}
+
+// SetIdentity updates the resource identity with the provided id.
+// An error is reported when the existing identity belongs to a different organization or realm,
+// since the resource is not able to be managed with the current provider configuration.
+func (rd *ResourceData) SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id string) (diags diag.Diagnostics) {
+	if identity == nil {
+		return nil
+	}
+
+	updated := rd.NewIdentity(ctx, id)
+	if !identity.Raw.IsNull() {
+		var existing ResourceIdentityModel
+		if diags.Append(identity.Get(ctx, &existing)...); diags.HasError() {
+			return diags
+		}
+		for _, field := range []struct {
+			name            string
+			existing, value types.String
+		}{
+			{name: "organization", existing: existing.OrganizationID, value: updated.OrganizationID},
+			{name: "realm", existing: existing.Realm, value: updated.Realm},
+		} {
+			if field.existing.ValueString() == "" || field.value.ValueString() == "" || field.existing.Equal(field.value) {
+				continue
+			}
+			diags.AddError(
+				"Resource belongs to a different "+field.name,
+				fmt.Sprintf("The resource %q was created in the %s %q, however the provider is configured to use %q.", id, field.name, field.existing.ValueString(), field.value.ValueString()),
+			)
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, identity.Set(ctx, updated)...)
+}
@@ -0,0 +1,180 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func newTestIdentity(tb testing.TB, values map[string]tftypes.Value) *tfsdk.ResourceIdentity {
+	tb.Helper()
+
+	resp := &resource.IdentitySchemaResponse{}
+	ResourceIDImporter{}.IdentitySchema(tb.Context(), resource.IdentitySchemaRequest{}, resp)
+	require.False(tb, resp.Diagnostics.HasError(), "Must not error creating identity schema")
+
+	typ := resp.IdentitySchema.Type().TerraformType(tb.Context())
+	identity := &tfsdk.ResourceIdentity{
+		Schema: resp.IdentitySchema,
+		Raw:    tftypes.NewValue(typ, nil),
+	}
+	if values != nil {
+		identity.Raw = tftypes.NewValue(typ, values)
+	}
+	return identity
+}
+
+func TestResourceDataNewIdentity(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   *pmeta.Meta
+		expect *ResourceIdentityModel
+	}{
+		{
+			name: "not configured",
+			meta: nil,
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringNull(),
+				Realm:          types.StringNull(),
+			},
+		},
+		{
+			name: "organization and realm configured",
+			meta: &pmeta.Meta{OrganizationID: "org", APIURL: "https://api.us1.signalfx.com"},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("org"),
+				Realm:          types.StringValue("us1"),
+			},
+		},
+		{
+			name: "custom domain",
+			meta: &pmeta.Meta{OrganizationID: "org", APIURL: "https://api.example.com"},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("org"),
+				Realm:          types.StringNull(),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := &ResourceData{meta: tc.meta}
+			assert.Equal(t, tc.expect, rd.NewIdentity(t.Context(), "id"), "Must match the expected identity")
+		})
+	}
+}
+
+func TestResourceDataSetIdentity(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		existing map[string]tftypes.Value
+		expect   *ResourceIdentityModel
+		errVal   string
+	}{
+		{
+			name:     "no existing identity",
+			existing: nil,
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("org"),
+				Realm:          types.StringValue("us1"),
+			},
+		},
+		{
+			name: "imported by id only",
+			existing: map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "id"),
+				"org_id": tftypes.NewValue(tftypes.String, nil),
+				"realm":  tftypes.NewValue(tftypes.String, nil),
+			},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("org"),
+				Realm:          types.StringValue("us1"),
+			},
+		},
+		{
+			name: "matching identity",
+			existing: map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "id"),
+				"org_id": tftypes.NewValue(tftypes.String, "org"),
+				"realm":  tftypes.NewValue(tftypes.String, "us1"),
+			},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("org"),
+				Realm:          types.StringValue("us1"),
+			},
+		},
+		{
+			name: "different organization",
+			existing: map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "id"),
+				"org_id": tftypes.NewValue(tftypes.String, "other"),
+				"realm":  tftypes.NewValue(tftypes.String, "us1"),
+			},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringValue("other"),
+				Realm:          types.StringValue("us1"),
+			},
+			errVal: `The resource "id" was created in the organization "other", however the provider is configured to use "org".`,
+		},
+		{
+			name: "different realm",
+			existing: map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "id"),
+				"org_id": tftypes.NewValue(tftypes.String, nil),
+				"realm":  tftypes.NewValue(tftypes.String, "eu0"),
+			},
+			expect: &ResourceIdentityModel{
+				ID:             types.StringValue("id"),
+				OrganizationID: types.StringNull(),
+				Realm:          types.StringValue("eu0"),
+			},
+			errVal: `The resource "id" was created in the realm "eu0", however the provider is configured to use "us1".`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := &ResourceData{meta: &pmeta.Meta{OrganizationID: "org", APIURL: "https://api.us1.signalfx.com"}}
+			identity := newTestIdentity(t, tc.existing)
+
+			diags := rd.SetIdentity(t.Context(), identity, "id")
+			if tc.errVal != "" {
+				require.True(t, diags.HasError(), "Must report an error")
+				assert.Equal(t, tc.errVal, diags.Errors()[0].Detail(), "Must match the expected error")
+			} else {
+				assert.Empty(t, diags, "Must not report any issues")
+			}
+
+			var actual ResourceIdentityModel
+			require.False(t, identity.Get(t.Context(), &actual).HasError(), "Must be able to read identity")
+			assert.Equal(t, tc.expect, &actual, "Must match the expected identity")
+		})
+	}
+
+	t.Run("identity not supported", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, (&ResourceData{}).SetIdentity(t.Context(), nil, "id"), "Must not report any issues")
+	})
+}
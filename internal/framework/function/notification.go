@@ -0,0 +1,209 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// NotificationString builds the notification string of a single notification type,
+// so the fields are validated when the plan is created instead of being joined using `format`.
+type NotificationString struct {
+	name       string
+	typ        string
+	summary    string
+	parameters []function.StringParameter
+}
+
+var _ function.Function = (*NotificationString)(nil)
+
+// NewNotificationFunctions returns a function for each of the notification types,
+// each one is named `notify_` followed by the name of the matching notification block.
+func NewNotificationFunctions() []func() function.Function {
+	funcs := []func() function.Function{
+		NewEmailNotificationString,
+	}
+	for _, ns := range []NotificationString{
+		newCredentialNotification(common.AmazonEventBridgeNotificationBlock, common.AmazonEventBrigeNotificationType, "Amazon EventBridge"),
+		newCredentialNotification(common.BigPandaNotificationBlock, common.BigPandaNotificationType, "BigPanda"),
+		newCredentialNotification(common.JiraNotificationBlock, common.JiraNotificationType, "Jira"),
+		newCredentialNotification(common.Office365NotificationBlock, common.Office365NotificationType, "Microsoft Teams"),
+		{
+			name:    common.OpsgenieNotificationBlock,
+			typ:     common.OpsgenieNotificationType,
+			summary: "Opsgenie",
+			parameters: []function.StringParameter{
+				newStringParameter("credential_id", "ID of the integration that sends the notification"),
+				newStringParameter("responder_name", "Name of the Opsgenie responder"),
+				newStringParameter("responder_id", "ID of the Opsgenie responder"),
+				newStringParameter("responder_type", "Type of the Opsgenie responder"),
+			},
+		},
+		newCredentialNotification(common.PagerDutyNotificationBlock, common.PagerDutyNotificationType, "PagerDuty"),
+		newCredentialNotification(common.ServiceNowNotificationBlock, common.ServiceNowNotificationType, "ServiceNow"),
+		{
+			name:    common.SlackNotificationBlock,
+			typ:     common.SlackNotificationType,
+			summary: "Slack",
+			parameters: []function.StringParameter{
+				newStringParameter("credential_id", "ID of the integration that sends the notification"),
+				newStringParameter("channel", "Name of the Slack channel, without the leading #"),
+			},
+		},
+		newCredentialNotification(common.SplunkPlatformNotificationBlock, common.SplunkPlatformNotificationType, "the Splunk platform"),
+		{
+			name:    common.TeamNotificationBlock,
+			typ:     common.TeamNotificationType,
+			summary: "the notification policy of a team",
+			parameters: []function.StringParameter{
+				newStringParameter("team_id", "ID of the team"),
+			},
+		},
+		{
+			name:    common.TeamEmailNotificationBlock,
+			typ:     common.TeamEmailNotificationType,
+			summary: "the members of a team by email",
+			parameters: []function.StringParameter{
+				newStringParameter("team_id", "ID of the team"),
+			},
+		},
+		{
+			name:    common.VictorOpsNotificationBlock,
+			typ:     common.VictorOpsNotificationType,
+			summary: "Splunk On-Call (formerly VictorOps)",
+			parameters: []function.StringParameter{
+				newStringParameter("credential_id", "ID of the integration that sends the notification"),
+				newStringParameter("routing_key", "Routing key used to route the notification"),
+			},
+		},
+		{
+			name:    common.WebhookNotificationBlock,
+			typ:     common.WebhookNotificationType,
+			summary: "a webhook",
+			parameters: []function.StringParameter{
+				newStringParameter("credential_id", "ID of the webhook integration, set to an empty string when url is set"),
+				newStringParameter("secret", "Secret sent with the notification when url is set, otherwise an empty string"),
+				newStringParameter("url", "URL of the webhook, set to an empty string when credential_id is set"),
+			},
+		},
+		newCredentialNotification(common.XMattersNotificationBlock, common.XMattersNotificationType, "xMatters"),
+	} {
+		funcs = append(funcs, func() function.Function {
+			return &ns
+		})
+	}
+	return funcs
+}
+
+func newCredentialNotification(name, typ, summary string) NotificationString {
+	return NotificationString{
+		name:    name,
+		typ:     typ,
+		summary: summary,
+		parameters: []function.StringParameter{
+			newStringParameter("credential_id", "ID of the integration that sends the notification"),
+		},
+	}
+}
+
+func newStringParameter(name, description string) function.StringParameter {
+	return function.StringParameter{
+		AllowNullValue: false,
+		Name:           name,
+		Description:    description,
+	}
+}
+
+func (ns NotificationString) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "notify_" + ns.name
+}
+
+func (ns NotificationString) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	params := make([]function.Parameter, len(ns.parameters))
+	for i, p := range ns.parameters {
+		params[i] = p
+	}
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Create a notification string that notifies %s", ns.summary),
+		Description: fmt.Sprintf("Returns the `%s` notification string that can be used within the `notifications` of a detector rule, the fields are checked when the plan is created.", ns.typ),
+		Parameters:  params,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (ns NotificationString) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	fields := make([]string, len(ns.parameters))
+	for i := range fields {
+		resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.GetArgument(ctx, i, &fields[i]))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	if str, err := common.NewNotificationString(ns.typ, fields...); err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+	} else {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, str))
+	}
+}
+
+// EmailNotificationString builds the Email notification string,
+// the cc and bcc recipients are passed as lists that can be empty or null.
+type EmailNotificationString struct{}
+
+var _ function.Function = (*EmailNotificationString)(nil)
+
+func NewEmailNotificationString() function.Function {
+	return &EmailNotificationString{}
+}
+
+func (EmailNotificationString) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "notify_" + common.EmailNotificationBlock
+}
+
+func (EmailNotificationString) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Create a notification string that notifies an email address",
+		Description: "Returns the `Email` notification string that can be used within the `notifications` of a detector rule, the email addresses are checked when the plan is created.",
+		Parameters: []function.Parameter{
+			newStringParameter("email", "Email address to send the notification to"),
+			function.ListParameter{
+				AllowNullValue: true,
+				ElementType:    types.StringType,
+				Name:           "cc",
+				Description:    "Email addresses to copy on the notification",
+			},
+			function.ListParameter{
+				AllowNullValue: true,
+				ElementType:    types.StringType,
+				Name:           "bcc",
+				Description:    "Email addresses to blind copy on the notification",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (EmailNotificationString) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		email   string
+		cc, bcc []string
+	)
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &email, &cc, &bcc))
+	if resp.Error != nil {
+		return
+	}
+
+	if str, err := common.NewEmailNotificationString(email, cc, bcc); err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+	} else {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, str))
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+func newNotificationFunction(t *testing.T, name string) function.Function {
+	t.Helper()
+
+	for _, fn := range NewNotificationFunctions() {
+		f := fn()
+		resp := &function.MetadataResponse{}
+		f.Metadata(t.Context(), function.MetadataRequest{}, resp)
+		if resp.Name == name {
+			return f
+		}
+	}
+	require.FailNow(t, "Must have the notification function", name)
+	return nil
+}
+
+func TestNotificationFunctions_Metadata(t *testing.T) {
+	t.Parallel()
+
+	var names []string
+	for _, fn := range NewNotificationFunctions() {
+		resp := &function.MetadataResponse{}
+		fn().Metadata(t.Context(), function.MetadataRequest{}, resp)
+		names = append(names, resp.Name)
+	}
+
+	expect := make([]string, 0, len(common.NotificationBlocks))
+	for name := range common.NotificationBlocks {
+		expect = append(expect, "notify_"+name)
+	}
+	assert.ElementsMatch(t, expect, names, "Must have a function for each notification type")
+}
+
+func TestNotificationFunctions_Definition(t *testing.T) {
+	t.Parallel()
+
+	for _, fn := range NewNotificationFunctions() {
+		resp := &function.DefinitionResponse{}
+		fn().Definition(t.Context(), function.DefinitionRequest{}, resp)
+
+		assert.NotEmpty(t, resp.Definition.Summary, "Must have a summary")
+		assert.NotEmpty(t, resp.Definition.Description, "Must have a description")
+		assert.NotEmpty(t, resp.Definition.Parameters, "Must have parameters")
+		assert.Equal(t, function.StringReturn{}, resp.Definition.Return, "Must return a string")
+	}
+
+	resp := &function.DefinitionResponse{}
+	newNotificationFunction(t, "notify_opsgenie").Definition(t.Context(), function.DefinitionRequest{}, resp)
+	assert.Len(t, resp.Definition.Parameters, 4, "Must have a parameter for each field")
+}
+
+func TestNotificationFunctions_Run(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		fn     string
+		args   []attr.Value
+		expect string
+		errVal string
+	}{
+		{
+			name:   "slack",
+			fn:     "notify_slack",
+			args:   []attr.Value{types.StringValue("aaa"), types.StringValue("alerts")},
+			expect: "Slack,aaa,alerts",
+		},
+		{
+			name:   "slack channel with hash",
+			fn:     "notify_slack",
+			args:   []attr.Value{types.StringValue("aaa"), types.StringValue("#alerts")},
+			errVal: `exclude the # from channel names in "#alerts"`,
+		},
+		{
+			name:   "field contains comma",
+			fn:     "notify_team",
+			args:   []attr.Value{types.StringValue("aaa,bbb")},
+			errVal: `Team notification field "aaa,bbb" must not contain a comma`,
+		},
+		{
+			name: "opsgenie",
+			fn:   "notify_opsgenie",
+			args: []attr.Value{
+				types.StringValue("aaa"),
+				types.StringValue("ops"),
+				types.StringValue("bbb"),
+				types.StringValue("Team"),
+			},
+			expect: "Opsgenie,aaa,ops,bbb,Team",
+		},
+		{
+			name: "webhook url",
+			fn:   "notify_webhook",
+			args: []attr.Value{
+				types.StringValue(""),
+				types.StringValue("hunter2"),
+				types.StringValue("http://localhost"),
+			},
+			expect: "Webhook,,hunter2,http://localhost",
+		},
+		{
+			name: "webhook missing destination",
+			fn:   "notify_webhook",
+			args: []attr.Value{
+				types.StringValue(""),
+				types.StringValue(""),
+				types.StringValue(""),
+			},
+			errVal: "invalid Webhook notification string, please consult the documentation (use one of URL or credential id)",
+		},
+		{
+			name: "email without recipients",
+			fn:   "notify_email",
+			args: []attr.Value{
+				types.StringValue("alerts@example.com"),
+				types.ListNull(types.StringType),
+				types.ListNull(types.StringType),
+			},
+			expect: "Email,alerts@example.com",
+		},
+		{
+			name: "email with recipients",
+			fn:   "notify_email",
+			args: []attr.Value{
+				types.StringValue("alerts@example.com"),
+				types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("ops@example.com"),
+					types.StringValue("oncall@example.com"),
+				}),
+				types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+			expect: "Email,alerts@example.com,oncall@example.com|ops@example.com,",
+		},
+		{
+			name: "invalid email",
+			fn:   "notify_email",
+			args: []attr.Value{
+				types.StringValue("alerts"),
+				types.ListNull(types.StringType),
+				types.ListNull(types.StringType),
+			},
+			errVal: "mail: missing '@' or angle-addr",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+			newNotificationFunction(t, tc.fn).Run(t.Context(), function.RunRequest{
+				Arguments: function.NewArgumentsData(tc.args),
+			}, resp)
+
+			if tc.errVal != "" {
+				require.NotNil(t, resp.Error, "Must return an error")
+				assert.Equal(t, tc.errVal, resp.Error.Text, "Must match the expected error")
+				return
+			}
+			require.Nil(t, resp.Error, "Must not return an error")
+			assert.Equal(t, function.NewResultData(types.StringValue(tc.expect)), resp.Result, "Must match the expected notification string")
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault describes how the mock server misbehaves for a single request.
+// Fields can be combined, for example a delayed 503 or a slow truncated response.
+type Fault struct {
+	// Status is written instead of calling the route handler when set.
+	Status int
+	// RetryAfter sets the `Retry-After` header, in whole seconds, on the faulted response.
+	RetryAfter time.Duration
+	// Delay holds the response until it has passed or the request is cancelled.
+	Delay time.Duration
+	// Truncate calls the route handler but only writes half of its body
+	// while still declaring the full `Content-Length`.
+	Truncate bool
+	// Times is the number of consecutive requests the fault applies to, defaults to 1.
+	Times int
+}
+
+// FaultScript replays faults in order for every request it handles,
+// once the script is exhausted requests are passed to the route handler unchanged.
+type FaultScript struct {
+	mu       sync.Mutex
+	faults   []Fault
+	requests int
+}
+
+// NewFaultScript returns a script that applies the faults in the order provided.
+func NewFaultScript(faults ...Fault) *FaultScript {
+	return &FaultScript{faults: faults}
+}
+
+// Requests returns the number of requests the script has handled.
+func (fs *FaultScript) Requests() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return fs.requests
+}
+
+// Wrap returns a handler that applies the script before calling h.
+func (fs *FaultScript) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault, ok := fs.next()
+		if !ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter/time.Second)))
+		}
+
+		switch {
+		case fault.Status != 0:
+			http.Error(w, http.StatusText(fault.Status), fault.Status)
+		case fault.Truncate:
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			body := rec.Body.Bytes()
+			for k, v := range rec.Header() {
+				w.Header()[k] = v
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(rec.Code)
+			// The server closes the connection once the handler returns
+			// without writing the declared length, so the client sees an unexpected EOF.
+			_, _ = w.Write(body[:len(body)/2])
+		default:
+			h.ServeHTTP(w, r)
+		}
+	})
+}
+
+func (fs *FaultScript) next() (Fault, bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.requests++
+	if len(fs.faults) == 0 {
+		return Fault{}, false
+	}
+	fault := fs.faults[0]
+	if fault.Times > 1 {
+		fs.faults[0].Times--
+	} else {
+		fs.faults = fs.faults[1:]
+	}
+	return fault, true
+}
+
+// WithMockFaults applies the script to every request made to the mock server,
+// use [FaultScript.Wrap] on a single endpoint to only fault selected routes.
+func WithMockFaults(script *FaultScript) func(*MockProvider) {
+	return func(mp *MockProvider) {
+		mp.faults = script
+	}
+}
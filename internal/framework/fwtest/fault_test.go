@@ -0,0 +1,142 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultScript(t *testing.T) {
+	t.Parallel()
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"AAAAAAAAAAA"}`))
+	})
+
+	for _, tc := range []struct {
+		name   string
+		faults []Fault
+		expect []int
+		retry  string
+	}{
+		{
+			name:   "no faults",
+			faults: nil,
+			expect: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name: "faults are replayed in order",
+			faults: []Fault{
+				{Status: http.StatusBadGateway},
+				{Status: http.StatusServiceUnavailable},
+			},
+			expect: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+		},
+		{
+			name: "fault is repeated",
+			faults: []Fault{
+				{Status: http.StatusInternalServerError, Times: 3},
+			},
+			expect: []int{
+				http.StatusInternalServerError,
+				http.StatusInternalServerError,
+				http.StatusInternalServerError,
+				http.StatusOK,
+			},
+		},
+		{
+			name: "rate limited",
+			faults: []Fault{
+				{Status: http.StatusTooManyRequests, RetryAfter: 2 * time.Second},
+			},
+			expect: []int{http.StatusTooManyRequests, http.StatusOK},
+			retry:  "2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			script := NewFaultScript(tc.faults...)
+			s := httptest.NewServer(script.Wrap(ok))
+			t.Cleanup(s.Close)
+
+			for i, status := range tc.expect {
+				resp, err := s.Client().Get(s.URL)
+				require.NoError(t, err, "Must not error making request")
+				_ = resp.Body.Close()
+
+				assert.Equal(t, status, resp.StatusCode, "Must match the expected status of request %d", i)
+				if i == 0 {
+					assert.Equal(t, tc.retry, resp.Header.Get("Retry-After"), "Must match the expected retry after")
+				}
+			}
+			assert.Equal(t, len(tc.expect), script.Requests(), "Must match the number of requests")
+		})
+	}
+}
+
+func TestFaultScriptTruncate(t *testing.T) {
+	t.Parallel()
+
+	script := NewFaultScript(Fault{Truncate: true})
+	s := httptest.NewServer(script.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"AAAAAAAAAAA"}`))
+	})))
+	t.Cleanup(s.Close)
+
+	resp, err := s.Client().Get(s.URL)
+	require.NoError(t, err, "Must not error making request")
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Must return the handler status")
+	assert.Equal(t, int64(20), resp.ContentLength, "Must declare the full body length")
+
+	body, err := io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "Must report the body was truncated")
+	assert.Equal(t, `{"id":"AAA`, string(body), "Must only write half of the body")
+}
+
+func TestFaultScriptDelay(t *testing.T) {
+	t.Parallel()
+
+	script := NewFaultScript(Fault{Delay: time.Minute})
+	s := httptest.NewServer(script.Wrap(http.NotFoundHandler()))
+	t.Cleanup(s.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, http.NoBody)
+	require.NoError(t, err, "Must not error creating request")
+
+	_, err = s.Client().Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must time out waiting for the response")
+}
+
+func TestWithMockFaults(t *testing.T) {
+	t.Parallel()
+
+	script := NewFaultScript(Fault{Status: http.StatusServiceUnavailable})
+	mock := NewMock(t, map[string]http.Handler{
+		"GET /v2/team/{id}": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":"team-1","name":"my team"}`))
+		}),
+	}, WithMockFaults(script))
+
+	_, err := mock.data.Client.GetTeam(context.Background(), "team-1")
+	assert.ErrorContains(t, err, "status code 503", "Must return the injected fault")
+
+	team, err := mock.data.Client.GetTeam(context.Background(), "team-1")
+	require.NoError(t, err, "Must not error once the script is exhausted")
+	assert.Equal(t, "my team", team.Name, "Must return the handler response")
+	assert.Equal(t, 2, script.Requests(), "Must match the number of requests")
+}
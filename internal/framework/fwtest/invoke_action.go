@@ -0,0 +1,71 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// InvokeMockAction configures the mock provider and invokes the action with the provided config values,
+// any attribute not included within values is set to null.
+// It returns the progress messages sent by the action and the diagnostics of the completed event.
+func InvokeMockAction(tb testing.TB, endpoints map[string]http.Handler, factory func() action.Action, actionType string, values map[string]tftypes.Value) ([]string, []*tfprotov5.Diagnostic) {
+	tb.Helper()
+
+	server, err := providerserver.NewProtocol5WithError(NewMock(tb, endpoints, WithMockActions(factory)))()
+	require.NoError(tb, err, "Must create provider server")
+
+	invoker, ok := server.(tfprotov5.ProviderServerWithActions)
+	require.True(tb, ok, "Must support actions")
+
+	providerConfig, err := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+	require.NoError(tb, err, "Must create provider config")
+
+	configured, err := server.ConfigureProvider(tb.Context(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	require.NoError(tb, err, "Must configure provider")
+	require.Empty(tb, configured.Diagnostics, "Must not report issues configuring provider")
+
+	schemas, err := server.GetProviderSchema(tb.Context(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(tb, err, "Must return provider schema")
+	require.Contains(tb, schemas.ActionSchemas, actionType, "Must define the action schema")
+
+	typ := schemas.ActionSchemas[actionType].Schema.ValueType().(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attr := range typ.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attr, nil)
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		}
+	}
+
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	require.NoError(tb, err, "Must create action config")
+
+	stream, err := invoker.InvokeAction(tb.Context(), &tfprotov5.InvokeActionRequest{
+		ActionType: actionType,
+		Config:     &config,
+	})
+	require.NoError(tb, err, "Must invoke action")
+
+	var (
+		progress []string
+		diags    []*tfprotov5.Diagnostic
+	)
+	for event := range stream.Events {
+		switch e := event.Type.(type) {
+		case tfprotov5.ProgressInvokeActionEventType:
+			progress = append(progress, e.Message)
+		case tfprotov5.CompletedInvokeActionEventType:
+			diags = append(diags, e.Diagnostics...)
+		}
+	}
+	return progress, diags
+}
@@ -0,0 +1,59 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type echoAction struct{}
+
+func (echoAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_echo"
+}
+
+func (echoAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"message": schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+func (echoAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var message types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("message"), &message)...)
+	if message.IsNull() {
+		resp.Diagnostics.AddError("Missing message", "message must be set")
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: message.ValueString()})
+}
+
+func TestInvokeMockAction(t *testing.T) {
+	t.Parallel()
+
+	factory := func() action.Action { return echoAction{} }
+
+	progress, diags := InvokeMockAction(t, map[string]http.Handler{}, factory, "signalfx_echo", map[string]tftypes.Value{
+		"message": tftypes.NewValue(tftypes.String, "hello"),
+	})
+	assert.Equal(t, []string{"hello"}, progress, "Must return the progress messages")
+	assert.Empty(t, diags, "Must not report any issues")
+
+	progress, diags = InvokeMockAction(t, map[string]http.Handler{}, factory, "signalfx_echo", nil)
+	assert.Empty(t, progress, "Must not return any progress messages")
+	if assert.Len(t, diags, 1, "Must report the error") {
+		assert.Equal(t, "message must be set", diags[0].Detail)
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -22,14 +25,21 @@ import (
 )
 
 type MockProvider struct {
-	data *pmeta.Meta
+	data   *pmeta.Meta
+	faults *FaultScript
 
 	resources   []func() resource.Resource
 	datasources []func() datasource.DataSource
+	ephemerals  []func() ephemeral.EphemeralResource
+	lists       []func() list.ListResource
+	actions     []func() action.Action
 }
 
 var (
-	_ provider.Provider = (*MockProvider)(nil)
+	_ provider.Provider                       = (*MockProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*MockProvider)(nil)
+	_ provider.ProviderWithListResources      = (*MockProvider)(nil)
+	_ provider.ProviderWithActions            = (*MockProvider)(nil)
 )
 
 func WithMockResources(resources ...func() resource.Resource) func(*MockProvider) {
@@ -44,6 +54,24 @@ func WithMockDataSources(datasources ...func() datasource.DataSource) func(*Mock
 	}
 }
 
+func WithMockEphemeralResources(ephemerals ...func() ephemeral.EphemeralResource) func(*MockProvider) {
+	return func(mp *MockProvider) {
+		mp.ephemerals = ephemerals
+	}
+}
+
+func WithMockListResources(lists ...func() list.ListResource) func(*MockProvider) {
+	return func(mp *MockProvider) {
+		mp.lists = lists
+	}
+}
+
+func WithMockActions(actions ...func() action.Action) func(*MockProvider) {
+	return func(mp *MockProvider) {
+		mp.actions = actions
+	}
+}
+
 func NewMockProto5Server(tb testing.TB, endpoints map[string]http.Handler, opts ...func(*MockProvider)) map[string]func() (tfprotov5.ProviderServer, error) {
 	return map[string]func() (tfprotov5.ProviderServer, error){
 		"signalfx": providerserver.NewProtocol5WithError(NewMock(tb, endpoints, opts...)),
@@ -56,25 +84,25 @@ func NewMockProto6Server(tb testing.TB, endpoints map[string]http.Handler, opts
 	}
 }
 
+// MockOrganizationID is the organization configured by the mock provider,
+// so resource identities do not require the organization to be looked up.
+const MockOrganizationID = "MockOrg0"
+
 func NewMock(tb testing.TB, handler map[string]http.Handler, opts ...func(*MockProvider)) *MockProvider {
 	tb.Helper()
 
-	mux := http.NewServeMux()
-	for path, h := range handler {
-		mux.Handle(path, h)
-	}
-	// The pattern matchers will match based on the longest prefix matching
-	// so this acts to help identify unmatched paths and will force the test
-	// to fail so it the behavior is not dependant on.
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		tb.Log("Unhandled request:", r.Method, r.URL.Path)
-
-		http.Error(w, "Internal Test Error: "+tb.Name(), http.StatusInternalServerError)
-
-		tb.Fail()
-	})
-
-	s := httptest.NewServer(mux)
+	mock := &MockProvider{}
+
+	mux := NewMockServeMux(tb, handler)
+	// The options are applied once the server is started,
+	// so the fault script is looked up for each request.
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mock.faults != nil {
+			mock.faults.Wrap(mux).ServeHTTP(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
 	tb.Cleanup(s.Close)
 
 	client, _ := signalfx.NewClient(
@@ -83,12 +111,12 @@ func NewMock(tb testing.TB, handler map[string]http.Handler, opts ...func(*MockP
 		signalfx.APIUrl(s.URL),
 	)
 
-	mock := &MockProvider{
-		data: &pmeta.Meta{
-			Client:       client,
-			APIURL:       s.URL,
-			CustomAppURL: s.URL,
-		},
+	mock.data = &pmeta.Meta{
+		Client:         client,
+		AuthToken:      tb.Name(),
+		APIURL:         s.URL,
+		CustomAppURL:   s.URL,
+		OrganizationID: MockOrganizationID,
 	}
 
 	for _, opt := range opts {
@@ -98,6 +126,28 @@ func NewMock(tb testing.TB, handler map[string]http.Handler, opts ...func(*MockP
 	return mock
 }
 
+// NewMockServeMux registers the handlers used by the mock provider
+// so they can be served with a client that is configured outside of the mock.
+func NewMockServeMux(tb testing.TB, handler map[string]http.Handler) *http.ServeMux {
+	tb.Helper()
+
+	mux := http.NewServeMux()
+	for path, h := range handler {
+		mux.Handle(path, h)
+	}
+	// The pattern matchers will match based on the longest prefix matching
+	// so this acts to help identify unmatched paths and will force the test
+	// to fail so it the behavior is not dependant on.
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		tb.Log("Unhandled request:", r.Method, r.URL.Path)
+
+		http.Error(w, "Internal Test Error: "+tb.Name(), http.StatusInternalServerError)
+
+		tb.Fail()
+	})
+	return mux
+}
+
 func (mp MockProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "signalfx"
 	resp.Version = "1.0.0"
@@ -113,6 +163,8 @@ func (mp MockProvider) Configure(_ context.Context, _ provider.ConfigureRequest,
 	resp.ResourceData = mp.data
 	resp.DataSourceData = mp.data
 	resp.EphemeralResourceData = mp.data
+	resp.ListResourceData = mp.data
+	resp.ActionData = mp.data
 }
 
 func (mp MockProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -122,3 +174,15 @@ func (mp MockProvider) DataSources(ctx context.Context) []func() datasource.Data
 func (mp MockProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return mp.resources
 }
+
+func (mp MockProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return mp.ephemerals
+}
+
+func (mp MockProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return mp.lists
+}
+
+func (mp MockProvider) Actions(ctx context.Context) []func() action.Action {
+	return mp.actions
+}
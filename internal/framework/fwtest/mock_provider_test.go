@@ -7,7 +7,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/stretchr/testify/require"
@@ -23,10 +26,16 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 		options    []func(*MockProvider)
 		wantResLen int
 		wantDSLen  int
+		wantERLen  int
+		wantLRLen  int
+		wantACLen  int
 	}
 
 	mockResource := func() resource.Resource { return nil }
 	mockDataSource := func() datasource.DataSource { return nil }
+	mockEphemeral := func() ephemeral.EphemeralResource { return nil }
+	mockList := func() list.ListResource { return nil }
+	mockAction := func() action.Action { return nil }
 
 	tests := []testCase{
 		{
@@ -53,6 +62,27 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 			wantResLen: 1,
 			wantDSLen:  1,
 		},
+		{
+			name:       "with ephemeral resources",
+			options:    []func(*MockProvider){WithMockEphemeralResources(mockEphemeral)},
+			wantResLen: 0,
+			wantDSLen:  0,
+			wantERLen:  1,
+		},
+		{
+			name:       "with list resources",
+			options:    []func(*MockProvider){WithMockListResources(mockList)},
+			wantResLen: 0,
+			wantDSLen:  0,
+			wantLRLen:  1,
+		},
+		{
+			name:       "with actions",
+			options:    []func(*MockProvider){WithMockActions(mockAction)},
+			wantResLen: 0,
+			wantDSLen:  0,
+			wantACLen:  1,
+		},
 	}
 
 	endpoints := map[string]http.Handler{
@@ -80,6 +110,9 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 			}
 			require.Len(t, mockProvider.Resources(t.Context()), tc.wantResLen)
 			require.Len(t, mockProvider.DataSources(t.Context()), tc.wantDSLen)
+			require.Len(t, mockProvider.EphemeralResources(t.Context()), tc.wantERLen)
+			require.Len(t, mockProvider.ListResources(t.Context()), tc.wantLRLen)
+			require.Len(t, mockProvider.Actions(t.Context()), tc.wantACLen)
 		})
 	}
 }
@@ -122,4 +155,6 @@ func TestMockProvider_Configure_SetsDataValues(t *testing.T) {
 	require.Equal(t, mockMeta, resp.ResourceData, "ResourceData should be set to mockMeta")
 	require.Equal(t, mockMeta, resp.DataSourceData, "DataSourceData should be set to mockMeta")
 	require.Equal(t, mockMeta, resp.EphemeralResourceData, "EphemeralResourceData should be set to mockMeta")
+	require.Equal(t, mockMeta, resp.ListResourceData, "ListResourceData should be set to mockMeta")
+	require.Equal(t, mockMeta, resp.ActionData, "ActionData should be set to mockMeta")
 }
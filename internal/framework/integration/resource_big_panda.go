@@ -35,6 +35,7 @@ var (
 	_ resource.Resource                = &ResourceBigPanda{}
 	_ resource.ResourceWithConfigure   = &ResourceBigPanda{}
 	_ resource.ResourceWithImportState = &ResourceBigPanda{}
+	_ resource.ResourceWithIdentity    = &ResourceBigPanda{}
 )
 
 func NewResourceBigPanda() resource.Resource {
@@ -94,6 +95,7 @@ func (bp *ResourceBigPanda) Create(ctx context.Context, req resource.CreateReque
 
 	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(bp.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (bp *ResourceBigPanda) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -115,6 +117,7 @@ func (bp *ResourceBigPanda) Read(ctx context.Context, req resource.ReadRequest,
 
 	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(bp.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (bp *ResourceBigPanda) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -136,6 +139,7 @@ func (bp *ResourceBigPanda) Update(ctx context.Context, req resource.UpdateReque
 
 	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(bp.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (bp *ResourceBigPanda) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
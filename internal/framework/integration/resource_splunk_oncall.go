@@ -5,6 +5,7 @@ package fwintegration
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -32,8 +33,15 @@ var (
 	_ resource.Resource                = &ResourceSplunkOncall{}
 	_ resource.ResourceWithConfigure   = &ResourceSplunkOncall{}
 	_ resource.ResourceWithImportState = &ResourceSplunkOncall{}
+	_ resource.ResourceWithIdentity    = &ResourceSplunkOncall{}
+	_ resource.ResourceWithMoveState   = &ResourceSplunkOncall{}
 )
 
+// victorOpsSourceProvider is the provider that defines the legacy resource type
+// that is able to be moved into this resource, the hostname is not checked to allow for
+// provider mirrors to be used.
+const victorOpsSourceProvider = "/splunk-terraform/signalfx"
+
 func NewResourceSplunkOncall() resource.Resource {
 	return &ResourceSplunkOncall{}
 }
@@ -44,26 +52,72 @@ func (oncall *ResourceSplunkOncall) Metadata(_ context.Context, req resource.Met
 
 func (oncall *ResourceSplunkOncall) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Use this resource to manage a Splunk Oncall Integration",
+		Description: "Use this resource to manage a Splunk On-Call Integration",
 		Attributes: map[string]schema.Attribute{
 			"id": fwshared.ResourceIDAttribute(),
 			"enabled": schema.BoolAttribute{
 				Required:    true,
-				Description: "Enables or disables the Splunk Oncall integration.",
+				Description: "Enables or disables the Splunk On-Call integration.",
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "Used to provide a human-readable name for the Splunk Oncall integration.",
+				Description: "Used to provide a human-readable name for the Splunk On-Call integration.",
 			},
 			"post_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "This is the Splunk OnCall integration URL.",
+				Description: "This is the Splunk On-Call integration URL.",
+			},
+		},
+	}
+}
+
+// MoveState allows for the legacy `signalfx_victor_ops_integration` resource
+// to be moved to this resource using a `moved` block without recreating the integration.
+func (oncall *ResourceSplunkOncall) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			// Matches the schema of the legacy resource with the implicit id attribute.
+			SourceSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":       schema.StringAttribute{Computed: true},
+					"enabled":  schema.BoolAttribute{Required: true},
+					"name":     schema.StringAttribute{Required: true},
+					"post_url": schema.StringAttribute{Optional: true},
+				},
 			},
+			StateMover: oncall.moveVictorOpsState,
 		},
 	}
 }
 
+func (oncall *ResourceSplunkOncall) moveVictorOpsState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "signalfx_victor_ops_integration" ||
+		!strings.HasSuffix(req.SourceProviderAddress, victorOpsSourceProvider) {
+		// Not the legacy resource, so the framework can report that it is unable to be moved.
+		return
+	}
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to move resource state",
+			"The source state of the "+req.SourceTypeName+" resource is not compatible with this resource.",
+		)
+		return
+	}
+
+	var model resourceSplunkOnCallModel
+	if resp.Diagnostics.Append(req.SourceState.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	// The legacy resource stores an unset POST URL as an empty string.
+	if model.PostURL.ValueString() == "" {
+		model.PostURL = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &model)...)
+	resp.Diagnostics.Append(oncall.SetIdentity(ctx, resp.TargetIdentity, model.Id.ValueString())...)
+}
+
 func (oncall *ResourceSplunkOncall) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model resourceSplunkOnCallModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
@@ -88,8 +142,9 @@ func (oncall *ResourceSplunkOncall) Create(ctx context.Context, req resource.Cre
 	}
 
 	model.Id = types.StringValue(details.Id)
-
+	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(oncall.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (oncall *ResourceSplunkOncall) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -113,10 +168,9 @@ func (oncall *ResourceSplunkOncall) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	model.Enabled = types.BoolValue(details.Enabled)
-	model.Name = types.StringValue(details.Name)
-	model.PostURL = types.StringValue(details.PostUrl)
+	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(oncall.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (oncall *ResourceSplunkOncall) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -146,10 +200,9 @@ func (oncall *ResourceSplunkOncall) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	model.Enabled = types.BoolValue(details.Enabled)
-	model.Name = types.StringValue(details.Name)
-	model.PostURL = types.StringValue(details.PostUrl)
+	model.updateFromIntegration(details)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(oncall.SetIdentity(ctx, resp.Identity, model.Id.ValueString())...)
 }
 
 func (oncall *ResourceSplunkOncall) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -166,3 +219,13 @@ func (oncall *ResourceSplunkOncall) Delete(ctx context.Context, req resource.Del
 
 	resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...)
 }
+
+func (model *resourceSplunkOnCallModel) updateFromIntegration(details *integration.VictorOpsIntegration) {
+	model.Enabled = types.BoolValue(details.Enabled)
+	model.Name = types.StringValue(details.Name)
+	// The API does not always return the POST URL,
+	// so the known value is kept to avoid a perpetual diff.
+	if details.PostUrl != "" {
+		model.PostURL = types.StringValue(details.PostUrl)
+	}
+}
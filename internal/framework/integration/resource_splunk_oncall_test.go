@@ -12,6 +12,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
@@ -20,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/signalfx/signalfx-go/integration"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
 )
@@ -40,6 +44,115 @@ func TestResourceSplunkOnCallSchema(t *testing.T) {
 	assert.NoError(t, fwtest.ResourceSchemaValidate(NewResourceSplunkOncall(), resourceSplunkOnCallModel{}))
 }
 
+func TestResourceSplunkOncallMoveState(t *testing.T) {
+	t.Parallel()
+
+	r, ok := NewResourceSplunkOncall().(resource.ResourceWithMoveState)
+	require.True(t, ok, "Must implement moving state")
+
+	movers := r.MoveState(t.Context())
+	require.Len(t, movers, 1, "Must have a single state mover")
+
+	sourceType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":       tftypes.String,
+		"enabled":  tftypes.Bool,
+		"name":     tftypes.String,
+		"post_url": tftypes.String,
+	}}
+	newSourceState := func(postURL string) *tfsdk.State {
+		return &tfsdk.State{
+			Schema: *movers[0].SourceSchema,
+			Raw: tftypes.NewValue(sourceType, map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, "test-id"),
+				"enabled":  tftypes.NewValue(tftypes.Bool, true),
+				"name":     tftypes.NewValue(tftypes.String, "Test Integration"),
+				"post_url": tftypes.NewValue(tftypes.String, postURL),
+			}),
+		}
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(t.Context(), resource.SchemaRequest{}, schemaResp)
+
+	for _, tc := range []struct {
+		name   string
+		req    resource.MoveStateRequest
+		moved  bool
+		expect resourceSplunkOnCallModel
+	}{
+		{
+			name: "legacy resource",
+			req: resource.MoveStateRequest{
+				SourceProviderAddress: "registry.terraform.io/splunk-terraform/signalfx",
+				SourceTypeName:        "signalfx_victor_ops_integration",
+				SourceState:           newSourceState("https://example.com/post"),
+			},
+			moved: true,
+			expect: resourceSplunkOnCallModel{
+				Id:      types.StringValue("test-id"),
+				Enabled: types.BoolValue(true),
+				Name:    types.StringValue("Test Integration"),
+				PostURL: types.StringValue("https://example.com/post"),
+			},
+		},
+		{
+			name: "legacy resource without post url",
+			req: resource.MoveStateRequest{
+				SourceProviderAddress: "registry.terraform.io/splunk-terraform/signalfx",
+				SourceTypeName:        "signalfx_victor_ops_integration",
+				SourceState:           newSourceState(""),
+			},
+			moved: true,
+			expect: resourceSplunkOnCallModel{
+				Id:      types.StringValue("test-id"),
+				Enabled: types.BoolValue(true),
+				Name:    types.StringValue("Test Integration"),
+				PostURL: types.StringNull(),
+			},
+		},
+		{
+			name: "different resource type",
+			req: resource.MoveStateRequest{
+				SourceProviderAddress: "registry.terraform.io/splunk-terraform/signalfx",
+				SourceTypeName:        "signalfx_opsgenie_integration",
+				SourceState:           newSourceState("https://example.com/post"),
+			},
+			moved: false,
+		},
+		{
+			name: "different provider",
+			req: resource.MoveStateRequest{
+				SourceProviderAddress: "registry.terraform.io/example/signalfx",
+				SourceTypeName:        "signalfx_victor_ops_integration",
+				SourceState:           newSourceState("https://example.com/post"),
+			},
+			moved: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+				},
+			}
+			movers[0].StateMover(t.Context(), tc.req, resp)
+			require.False(t, resp.Diagnostics.HasError(), "Must not error moving the state: %v", resp.Diagnostics)
+
+			if !tc.moved {
+				assert.True(t, resp.TargetState.Raw.IsNull(), "Must not move the state")
+				return
+			}
+
+			var actual resourceSplunkOnCallModel
+			require.False(t, resp.TargetState.Get(t.Context(), &actual).HasError(), "Must read the moved state")
+			assert.Equal(t, tc.expect, actual, "Must match the expected state")
+		})
+	}
+}
+
 func TestResourceSplunkOncallUnitTest(t *testing.T) {
 	t.Parallel()
 	for _, tc := range []struct {
@@ -12,15 +12,25 @@ import (
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
@@ -28,20 +38,28 @@ import (
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/builtincontent"
 	internalfunction "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/function"
 	fwintegration "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/integration"
+	fwquery "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/query"
+	fwsession "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/session"
+	fwtoken "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/token"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tracing"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 )
 
 type ollyProvider struct {
-	version  string
-	features *feature.Registry
+	version         string
+	features        *feature.Registry
+	legacyResources map[string]*sdkschema.Resource
 }
 
 var (
-	_ provider.Provider                   = (*ollyProvider)(nil)
-	_ provider.ProviderWithFunctions      = (*ollyProvider)(nil)
-	_ provider.ProviderWithValidateConfig = (*ollyProvider)(nil)
+	_ provider.Provider                       = (*ollyProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*ollyProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*ollyProvider)(nil)
+	_ provider.ProviderWithListResources      = (*ollyProvider)(nil)
+	_ provider.ProviderWithActions            = (*ollyProvider)(nil)
+	_ provider.ProviderWithValidateConfig     = (*ollyProvider)(nil)
 )
 
 func NewProvider(version string, opts ...ProviderOption) provider.Provider {
@@ -72,6 +90,20 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
 			},
+			"credential_process": schema.StringAttribute{
+				Optional:    true,
+				Description: "Command that is run to fetch the auth token, and optionally the API URL, written to stdout as JSON with the fields auth_token, api_url, and expires_at. The command is run again once the token expires. Conflicts with auth_token",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth_token")),
+				},
+			},
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Description: "Realm of your Splunk Observability Cloud org, such as us1, used to set the API URL and application URL. Conflicts with api_url",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pmeta.RealmPattern, "must be a realm name such as us1"),
+				},
+			},
 			"custom_app_url": schema.StringAttribute{
 				Optional:           true,
 				DeprecationMessage: "Remove the definition, the provider will automatically populate the custom app URL as needed",
@@ -93,6 +125,20 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests sent to the API every second, shared across all resources. Defaults to 0 which does not limit requests",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests to the API that can be in flight at once, shared across all resources and data sources. Defaults to 0 which does not limit requests",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 			"email": schema.StringAttribute{
 				Optional:    true,
 				Description: "Used to create a session token instead of an API token, it requires the account to be configured to login with Email and Password",
@@ -102,6 +148,10 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Sensitive:   true,
 				Description: "Used to create a session token instead of an API token, it requires the account to be configured to login with Email and Password",
 			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the profile to read from the provider configuration files, /etc/signalfx.conf and ~/.signalfx.conf. Uses the default profile when not set",
+			},
 			"organization_id": schema.StringAttribute{
 				Optional:    true,
 				Description: "Required if the user is configured to be part of multiple organizations",
@@ -121,6 +171,73 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Allows for teams to be defined at a provider level, and apply to all applicable resources created.",
 			},
+			"tracking_tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Templates of the tags added to resources by the provider.track feature preview, rendered using the Project, Branch, Experimental, Commit, RunURL, Actor, and Workspace details. Tags that render as empty are not added. Defaults to the project, branch, and experimental tags",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_rule": schema.ListNestedBlock{
+				Description: "Sets the notifications, runbook URL, tip, reminder notification, and skip clear notification states of any signalfx_detector rule that leaves them empty. Requires the feature preview provider.default_rule to be enabled",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"notifications": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "List of strings specifying where notifications will be sent for any rule that does not set notifications",
+						},
+						"runbook_url_prefix": schema.StringAttribute{
+							Optional:    true,
+							Description: "URL that the detect label of the rule is appended to, used for any rule that does not set a runbook URL",
+						},
+						"tip": schema.StringAttribute{
+							Optional:    true,
+							Description: "Plain text suggested first course of action, used for any rule that does not set a tip",
+						},
+						"skip_clear_notification_states": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "One or more alert clear states for which clear notifications are not sent, used for any rule that does not set them",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.OneOf("OK", "AUTO_RESOLVED", "STOPPED", "MANUALLY_RESOLVED")),
+							},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"notification": newNotificationBlock("Where notifications will be sent for any rule that does not set notifications, set using a nested block for each notification type. Can not be set with notifications"),
+						"reminder_notification": schema.ListNestedBlock{
+							Description: "Reminder notification used for any rule that does not set one",
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"interval_ms": schema.Int64Attribute{
+										Required:    true,
+										Description: "The interval at which you want to receive the notifications, in milliseconds.",
+									},
+									"timeout_ms": schema.Int64Attribute{
+										Optional:    true,
+										Description: "The duration during which repeat notifications are sent, in milliseconds.",
+									},
+									"type": schema.StringAttribute{
+										Required:    true,
+										Description: "Type of reminder notification. Currently, the only supported value is TIMEOUT.",
+										Validators: []validator.String{
+											stringvalidator.OneOf("TIMEOUT"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -153,10 +270,13 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	model.init()
 
 	meta := &pmeta.Meta{
-		Registry:       op.features,
-		Email:          model.Email.ValueString(),
-		Password:       model.Password.ValueString(),
-		OrganizationID: model.OrganizationID.ValueString(),
+		Registry:          op.features,
+		Email:             model.Email.ValueString(),
+		Password:          model.Password.ValueString(),
+		OrganizationID:    model.OrganizationID.ValueString(),
+		Realm:             model.Realm.ValueString(),
+		Profile:           model.Profile.ValueString(),
+		CredentialProcess: model.CredentialProcess.ValueString(),
 	}
 
 	for _, val := range model.Tags.Elements() {
@@ -171,6 +291,12 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		}
 	}
 
+	defaultRule, diags := model.LoadDefaultRule(ctx)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	meta.DefaultRule = defaultRule
+
 	for _, lookup := range pmeta.NewDefaultProviderLookups() {
 		if err := lookup.Do(ctx, meta); err != nil {
 			tflog.Debug(ctx,
@@ -180,7 +306,26 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		}
 	}
 
-	if !model.AuthToken.IsNull() {
+	if meta.Profile != "" && !meta.ProfileLoaded() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Profile not found",
+			fmt.Sprintf("The profile %q is not defined within the provider configuration files.", meta.Profile),
+		)
+		return
+	}
+
+	if err := pmeta.CredentialProcessMetaLookupFunc().Do(ctx, meta); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Issue running credential process",
+			err.Error(),
+		)
+		return
+	}
+
+	// The credential process takes precedence over the auth token set within the environment.
+	if !model.AuthToken.IsNull() && meta.Credentials == nil {
 		meta.AuthToken = model.AuthToken.ValueString()
 	}
 
@@ -188,41 +333,64 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		meta.APIURL = model.APIURL.ValueString()
 	}
 
+	if !model.Realm.IsNull() {
+		meta.Realm = model.Realm.ValueString()
+		meta.APIURL = pmeta.RealmAPIURL(meta.Realm)
+	} else if meta.APIURL == "" && meta.Realm != "" {
+		meta.APIURL = pmeta.RealmAPIURL(meta.Realm)
+	}
+
 	if err := meta.Validate(); err != nil {
 		resp.Diagnostics.AddError("Issue configuring provider", err.Error())
 		return
 	}
 
 	var (
-		attempts = int(model.RetryMaxAttempts.ValueInt32())
-		timeout  = time.Duration(model.TimeoutSeconds.ValueInt64()) * time.Second
-		waitmin  = time.Duration(model.RetryWaitMinSeconds.ValueInt64()) * time.Second
-		waitmax  = time.Duration(model.RetryWaitMaxSeconds.ValueInt64()) * time.Second
+		attempts   = int(model.RetryMaxAttempts.ValueInt32())
+		timeout    = time.Duration(model.TimeoutSeconds.ValueInt64()) * time.Second
+		waitmin    = time.Duration(model.RetryWaitMinSeconds.ValueInt64()) * time.Second
+		waitmax    = time.Duration(model.RetryWaitMaxSeconds.ValueInt64()) * time.Second
+		rps        = model.RequestsPerSecond.ValueFloat64()
+		concurrent = int(model.MaxConcurrentRequests.ValueInt64())
 	)
 
-	token, err := meta.LoadSessionToken(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Issue loading session token", err.Error())
-		return
-	}
-
 	rc := retryablehttp.NewClient()
 	rc.RetryMax = attempts
 	rc.RetryWaitMin = waitmin
 	rc.RetryWaitMax = waitmax
+	rc.Backoff = pmeta.RetryBackoff
 	rc.HTTPClient.Timeout = timeout
-	rc.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
-		TLSHandshakeTimeout: 5 * time.Second,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
+	meta.Cache = pmeta.GetResponseCache()
+	meta.NotificationTargets = pmeta.NewNotificationTargets()
+	limits := pmeta.GetRequestLimits()
+	limits.Configure(pmeta.TransportLimits{
+		RequestsPerSecond:     rps,
+		MaxConcurrentRequests: concurrent,
+		MaxRetryWait:          waitmax,
 	})
+	rc.HTTPClient.Transport = meta.LoadSessionSource().Transport(meta.Credentials.Transport(meta.Cache.Transport(limits.Transport(
+		logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 100,
+		}),
+	))))
+	meta.HTTPClient = tracing.NewHTTPClient(rc)
+
+	// The session token is created once the HTTP client is configured,
+	// so it shares the same transport, retries and request limits.
+	token, err := meta.LoadSessionToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Issue loading session token", err.Error())
+		return
+	}
 
 	meta.Client, err = signalfx.NewClient(
 		token,
 		signalfx.APIUrl(meta.APIURL),
-		signalfx.HTTPClient(rc.StandardClient()),
+		signalfx.HTTPClient(meta.HTTPClient),
 		signalfx.UserAgent(fmt.Sprintf("Terraform %s terraform-provider-signalfx/%s", req.TerraformVersion, op.version)),
 	)
 
@@ -235,9 +403,15 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		Field("attempts", attempts).
 		Duration("timeout", timeout).
 		Duration("wait_min", waitmin).
-		Duration("wait_max", waitmax),
+		Duration("wait_max", waitmax).
+		Field("requests_per_second", rps).
+		Field("max_concurrent_requests", concurrent),
 	)
 
+	if meta.CustomAppURL == "" && meta.Realm != "" {
+		meta.CustomAppURL = pmeta.RealmAppURL(meta.Realm)
+	}
+
 	if site, err := meta.DetectCustomAPPURL(ctx); err != nil {
 		if !model.CustomAppURL.IsNull() {
 			meta.CustomAppURL = model.CustomAppURL.ValueString()
@@ -257,12 +431,38 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		}
 	}
 
+	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderCache); ok {
+		meta.Cache.SetEnabled(gate.Enabled())
+	}
+
+	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracing); ok && gate.Enabled() {
+		if err := tracing.Enable(ctx, op.version); err != nil {
+			resp.Diagnostics.AddWarning("Unable to enable tracing", err.Error())
+		}
+	}
+
 	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(ctx)
+		var templates []string
+		for _, val := range model.TrackingTags.Elements() {
+			if tmpl, ok := val.(types.String); ok && !tmpl.IsNull() {
+				templates = append(templates, tmpl.ValueString())
+			}
+		}
+		tt, err := track.NewTagTemplate(templates...)
 		if err != nil {
-			tflog.Info(ctx, "Unable to load git details, skipping", tfext.ErrorLogFields(err))
+			resp.Diagnostics.AddAttributeError(path.Root("tracking_tags"), "Invalid tag template", err.Error())
+			return
+		}
+		tracking, err := track.ReadDetails(ctx)
+		if err != nil {
+			tflog.Info(ctx, "Unable to load tracking details, skipping", tfext.ErrorLogFields(err))
 		} else {
-			meta.Tags = append(meta.Tags, tracking.Tags()...)
+			tags, err := tt.Tags(*tracking)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("tracking_tags"), "Invalid tag template", err.Error())
+				return
+			}
+			meta.Tags = append(meta.Tags, tags...)
 		}
 	}
 
@@ -270,6 +470,8 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	resp.DataSourceData = meta
 	resp.ResourceData = meta
 	resp.EphemeralResourceData = meta
+	resp.ListResourceData = meta
+	resp.ActionData = meta
 }
 
 func (op *ollyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -285,15 +487,39 @@ func (op *ollyProvider) Resources(ctx context.Context) []func() resource.Resourc
 		fwalert.NewResourceAlertMutingRule,
 		fwalert.NewResourceEmailTemplate,
 		fwintegration.NewResourceBigPanda,
+		fwintegration.NewResourceSplunkOncall,
 	}
 }
 
-func (op *ollyProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{
-		internalfunction.NewTimeRangeParser,
+func (op *ollyProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		fwsession.NewEphemeralSessionToken,
+	}
+}
+
+func (op *ollyProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return append([]func() list.ListResource{
+		fwalert.NewListAlertMutingRule,
+	}, fwquery.NewListResources(op.legacyResources)...)
+}
+
+func (op *ollyProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		fwalert.NewActionMuteDetector,
+		fwalert.NewActionValidateDetector,
+		fwtoken.NewActionRotateOrgToken,
 	}
 }
 
+func (op *ollyProvider) Functions(ctx context.Context) []func() function.Function {
+	return append(
+		[]func() function.Function{
+			internalfunction.NewTimeRangeParser,
+		},
+		internalfunction.NewNotificationFunctions()...,
+	)
+}
+
 func (op *ollyProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
 	model := newDefaultOllyProviderModel()
 
@@ -304,15 +530,24 @@ func (op *ollyProvider) ValidateConfig(ctx context.Context, req provider.Validat
 
 	model.init()
 
-	if model.APIURL.IsNull() {
+	switch {
+	case model.APIURL.IsNull() && model.Realm.IsNull() && model.CredentialProcess.IsNull():
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_url"),
 			"Missing API Endpoint",
-			"Field must be set to a valid endpoint for the Splunk Observability Cloud provider.",
+			"Field must be set to a valid endpoint for the Splunk Observability Cloud provider, or 'realm' or 'credential_process' must be set.",
+		)
+	case !model.APIURL.IsNull() && !model.Realm.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("realm"),
+			"Conflicting API Endpoint",
+			"Only one of 'realm' or 'api_url' can be set, this includes the realm set by the SFX_REALM environment variable.",
 		)
 	}
 
 	switch {
+	case !model.CredentialProcess.IsNull():
+		tflog.Debug(ctx, "Using credential process for authentication")
 	case !model.AuthToken.IsNull():
 		tflog.Debug(ctx, "Using auth token for authentication")
 	case !model.Email.IsNull() &&
@@ -4,42 +4,96 @@
 package internalframework
 
 import (
+	"context"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/signalfx/signalfx-go/detector"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 type OllyProviderModel struct {
-	APIURL              types.String `tfsdk:"api_url"`
-	AuthToken           types.String `tfsdk:"auth_token"`
-	CustomAppURL        types.String `tfsdk:"custom_app_url"`
-	TimeoutSeconds      types.Int64  `tfsdk:"timeout_seconds"`
-	RetryMaxAttempts    types.Int32  `tfsdk:"retry_max_attempts"`
-	RetryWaitMinSeconds types.Int64  `tfsdk:"retry_wait_min_seconds"`
-	RetryWaitMaxSeconds types.Int64  `tfsdk:"retry_wait_max_seconds"`
-	Email               types.String `tfsdk:"email"`
-	Password            types.String `tfsdk:"password"`
-	OrganizationID      types.String `tfsdk:"organization_id"`
-	FeaturePreview      types.Map    `tfsdk:"feature_preview"`
-	Tags                types.List   `tfsdk:"tags"`
-	Teams               types.List   `tfsdk:"teams"`
+	APIURL                types.String  `tfsdk:"api_url"`
+	Realm                 types.String  `tfsdk:"realm"`
+	AuthToken             types.String  `tfsdk:"auth_token"`
+	CredentialProcess     types.String  `tfsdk:"credential_process"`
+	CustomAppURL          types.String  `tfsdk:"custom_app_url"`
+	TimeoutSeconds        types.Int64   `tfsdk:"timeout_seconds"`
+	RetryMaxAttempts      types.Int32   `tfsdk:"retry_max_attempts"`
+	RetryWaitMinSeconds   types.Int64   `tfsdk:"retry_wait_min_seconds"`
+	RetryWaitMaxSeconds   types.Int64   `tfsdk:"retry_wait_max_seconds"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	Email                 types.String  `tfsdk:"email"`
+	Password              types.String  `tfsdk:"password"`
+	OrganizationID        types.String  `tfsdk:"organization_id"`
+	Profile               types.String  `tfsdk:"profile"`
+	FeaturePreview        types.Map     `tfsdk:"feature_preview"`
+	Tags                  types.List    `tfsdk:"tags"`
+	Teams                 types.List    `tfsdk:"teams"`
+	TrackingTags          types.List    `tfsdk:"tracking_tags"`
+	DefaultRule           types.List    `tfsdk:"default_rule"`
+}
+
+type OllyDefaultRuleModel struct {
+	Notifications               types.List   `tfsdk:"notifications"`
+	Notification                types.List   `tfsdk:"notification"`
+	RunbookURLPrefix            types.String `tfsdk:"runbook_url_prefix"`
+	Tip                         types.String `tfsdk:"tip"`
+	SkipClearNotificationStates types.Set    `tfsdk:"skip_clear_notification_states"`
+	ReminderNotification        types.List   `tfsdk:"reminder_notification"`
 }
 
+type OllyReminderNotificationModel struct {
+	IntervalMs types.Int64  `tfsdk:"interval_ms"`
+	TimeoutMs  types.Int64  `tfsdk:"timeout_ms"`
+	Type       types.String `tfsdk:"type"`
+}
+
+var (
+	reminderNotificationType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"interval_ms": types.Int64Type,
+		"timeout_ms":  types.Int64Type,
+		"type":        types.StringType,
+	}}
+	defaultRuleType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"notifications":                  types.ListType{ElemType: types.StringType},
+		"notification":                   newNotificationBlock("").Type(),
+		"runbook_url_prefix":             types.StringType,
+		"tip":                            types.StringType,
+		"skip_clear_notification_states": types.SetType{ElemType: types.StringType},
+		"reminder_notification":          types.ListType{ElemType: reminderNotificationType},
+	}}
+)
+
 func newDefaultOllyProviderModel() *OllyProviderModel {
 	return &OllyProviderModel{
-		AuthToken:           types.StringNull(),
-		APIURL:              types.StringNull(),
-		CustomAppURL:        types.StringNull(),
-		TimeoutSeconds:      types.Int64Value(60),
-		RetryMaxAttempts:    types.Int32Value(5),
-		RetryWaitMinSeconds: types.Int64Value(1),
-		RetryWaitMaxSeconds: types.Int64Value(10),
-		Email:               types.StringNull(),
-		Password:            types.StringNull(),
-		OrganizationID:      types.StringNull(),
-		FeaturePreview:      types.MapNull(types.BoolType),
-		Tags:                types.ListNull(types.StringType),
-		Teams:               types.ListNull(types.StringType),
+		AuthToken:             types.StringNull(),
+		CredentialProcess:     types.StringNull(),
+		APIURL:                types.StringNull(),
+		Realm:                 types.StringNull(),
+		CustomAppURL:          types.StringNull(),
+		TimeoutSeconds:        types.Int64Value(120),
+		RetryMaxAttempts:      types.Int32Value(4),
+		RetryWaitMinSeconds:   types.Int64Value(1),
+		RetryWaitMaxSeconds:   types.Int64Value(30),
+		RequestsPerSecond:     types.Float64Null(),
+		MaxConcurrentRequests: types.Int64Null(),
+		Email:                 types.StringNull(),
+		Password:              types.StringNull(),
+		OrganizationID:        types.StringNull(),
+		Profile:               types.StringNull(),
+		FeaturePreview:        types.MapNull(types.BoolType),
+		Tags:                  types.ListNull(types.StringType),
+		Teams:                 types.ListNull(types.StringType),
+		TrackingTags:          types.ListNull(types.StringType),
+		DefaultRule:           types.ListNull(defaultRuleType),
 	}
 }
 
@@ -49,19 +103,104 @@ func (model *OllyProviderModel) init() {
 	if data, ok := os.LookupEnv("SFX_AUTH_TOKEN"); ok && model.AuthToken.IsNull() {
 		model.AuthToken = types.StringValue(data)
 	}
-	if data, ok := os.LookupEnv("SFX_API_URL"); ok && model.APIURL.IsNull() {
+	if data, ok := os.LookupEnv("SFX_CREDENTIAL_PROCESS"); ok && data != "" && model.CredentialProcess.IsNull() {
+		model.CredentialProcess = types.StringValue(data)
+	}
+	if data, ok := os.LookupEnv("SFX_REALM"); ok && data != "" && model.Realm.IsNull() {
+		model.Realm = types.StringValue(data)
+	}
+	// The realm takes precedence over the API URL set within the environment.
+	if data, ok := os.LookupEnv("SFX_API_URL"); ok && model.APIURL.IsNull() && model.Realm.IsNull() {
 		model.APIURL = types.StringValue(data)
 	}
+	if data, ok := os.LookupEnv("SFX_PROFILE"); ok && data != "" && model.Profile.IsNull() {
+		model.Profile = types.StringValue(data)
+	}
 	if model.TimeoutSeconds.IsNull() {
-		model.TimeoutSeconds = types.Int64Value(60)
+		model.TimeoutSeconds = types.Int64Value(120)
 	}
 	if model.RetryMaxAttempts.IsNull() {
-		model.RetryMaxAttempts = types.Int32Value(5)
+		model.RetryMaxAttempts = types.Int32Value(4)
 	}
 	if model.RetryWaitMinSeconds.IsNull() {
 		model.RetryWaitMinSeconds = types.Int64Value(1)
 	}
 	if model.RetryWaitMaxSeconds.IsNull() {
-		model.RetryWaitMaxSeconds = types.Int64Value(10)
+		model.RetryWaitMaxSeconds = types.Int64Value(30)
+	}
+}
+
+// LoadDefaultRule converts the `default_rule` block,
+// nil is returned when the block has not been set.
+func (model *OllyProviderModel) LoadDefaultRule(ctx context.Context) (*pmeta.DefaultRule, diag.Diagnostics) {
+	if model.DefaultRule.IsNull() || model.DefaultRule.IsUnknown() || len(model.DefaultRule.Elements()) == 0 {
+		return nil, nil
+	}
+
+	var blocks []OllyDefaultRuleModel
+	if diags := model.DefaultRule.ElementsAs(ctx, &blocks, false); diags.HasError() {
+		return nil, diags
+	}
+
+	var (
+		block = blocks[0]
+		dr    = &pmeta.DefaultRule{
+			RunbookURLPrefix: block.RunbookURLPrefix.ValueString(),
+			Tip:              block.Tip.ValueString(),
+		}
+		diags diag.Diagnostics
+	)
+
+	var notifys []string
+	diags.Append(block.Notifications.ElementsAs(ctx, &notifys, false)...)
+	diags.Append(block.SkipClearNotificationStates.ElementsAs(ctx, &dr.SkipClearNotificationStates, false)...)
+
+	var reminders []OllyReminderNotificationModel
+	diags.Append(block.ReminderNotification.ElementsAs(ctx, &reminders, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	notifyBlocks, _ := notificationBlockValues(block.Notification).([]any)
+	switch {
+	case len(notifys) > 0 && len(notifyBlocks) > 0:
+		diags.AddAttributeError(
+			path.Root("default_rule").AtListIndex(0).AtName("notification"),
+			"Invalid notification",
+			"only one of \"notifications\" or \"notification\" can be set",
+		)
+		return nil, diags
+	case len(notifys) > 0:
+		list, err := common.NewNotificationList(convert.SliceAll(notifys, convert.ToAny[string]))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("default_rule").AtListIndex(0).AtName("notifications"),
+				"Invalid notification",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		dr.Notifications = list
+	case len(notifyBlocks) > 0:
+		list, err := common.NewNotificationListFromBlocks(notifyBlocks)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("default_rule").AtListIndex(0).AtName("notification"),
+				"Invalid notification",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		dr.Notifications = list
 	}
+
+	if len(reminders) > 0 {
+		dr.ReminderNotification = &detector.ReminderNotification{
+			IntervalMs: reminders[0].IntervalMs.ValueInt64(),
+			TimeoutMs:  reminders[0].TimeoutMs.ValueInt64(),
+			Type:       reminders[0].Type.ValueString(),
+		}
+	}
+
+	return dr, diags
 }
@@ -6,8 +6,14 @@ package internalframework
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 func TestModelEnsureDefaults(t *testing.T) {
@@ -25,10 +31,10 @@ func TestModelEnsureDefaults(t *testing.T) {
 			expected: &OllyProviderModel{
 				AuthToken:           types.StringNull(),
 				APIURL:              types.StringNull(),
-				TimeoutSeconds:      types.Int64Value(60),
-				RetryMaxAttempts:    types.Int32Value(5),
+				TimeoutSeconds:      types.Int64Value(120),
+				RetryMaxAttempts:    types.Int32Value(4),
 				RetryWaitMinSeconds: types.Int64Value(1),
-				RetryWaitMaxSeconds: types.Int64Value(10),
+				RetryWaitMaxSeconds: types.Int64Value(30),
 			},
 		},
 		{
@@ -42,10 +48,10 @@ func TestModelEnsureDefaults(t *testing.T) {
 			expected: &OllyProviderModel{
 				AuthToken:           types.StringValue("test-auth-token"),
 				APIURL:              types.StringValue("https://example.com"),
-				TimeoutSeconds:      types.Int64Value(60),
-				RetryMaxAttempts:    types.Int32Value(5),
+				TimeoutSeconds:      types.Int64Value(120),
+				RetryMaxAttempts:    types.Int32Value(4),
 				RetryWaitMinSeconds: types.Int64Value(1),
-				RetryWaitMaxSeconds: types.Int64Value(10),
+				RetryWaitMaxSeconds: types.Int64Value(30),
 			},
 		},
 		{
@@ -72,6 +78,54 @@ func TestModelEnsureDefaults(t *testing.T) {
 				RetryWaitMaxSeconds: types.Int64Value(20),
 			},
 		},
+		{
+			name:  "realm set within the environment",
+			model: &OllyProviderModel{},
+			env: map[string]string{
+				"SFX_REALM":   "eu0",
+				"SFX_API_URL": "https://example.com",
+			},
+			expected: &OllyProviderModel{
+				APIURL:              types.StringNull(),
+				Realm:               types.StringValue("eu0"),
+				TimeoutSeconds:      types.Int64Value(120),
+				RetryMaxAttempts:    types.Int32Value(4),
+				RetryWaitMinSeconds: types.Int64Value(1),
+				RetryWaitMaxSeconds: types.Int64Value(30),
+			},
+		},
+		{
+			name: "realm is defined",
+			model: &OllyProviderModel{
+				Realm: types.StringValue("us1"),
+			},
+			env: map[string]string{
+				"SFX_REALM":   "eu0",
+				"SFX_API_URL": "https://example.com",
+			},
+			expected: &OllyProviderModel{
+				APIURL:              types.StringNull(),
+				Realm:               types.StringValue("us1"),
+				TimeoutSeconds:      types.Int64Value(120),
+				RetryMaxAttempts:    types.Int32Value(4),
+				RetryWaitMinSeconds: types.Int64Value(1),
+				RetryWaitMaxSeconds: types.Int64Value(30),
+			},
+		},
+		{
+			name:  "profile set within the environment",
+			model: &OllyProviderModel{},
+			env: map[string]string{
+				"SFX_PROFILE": "staging",
+			},
+			expected: &OllyProviderModel{
+				Profile:             types.StringValue("staging"),
+				TimeoutSeconds:      types.Int64Value(120),
+				RetryMaxAttempts:    types.Int32Value(4),
+				RetryWaitMinSeconds: types.Int64Value(1),
+				RetryWaitMaxSeconds: types.Int64Value(30),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			for k, v := range tc.env {
@@ -83,3 +137,107 @@ func TestModelEnsureDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestModelLoadDefaultRule(t *testing.T) {
+	t.Parallel()
+
+	notificationType := newNotificationBlock("").Type().(types.ListType)
+	newTeamNotification := func(teamID string) types.List {
+		objectType := notificationType.ElemType.(types.ObjectType)
+		attrs := make(map[string]attr.Value)
+		for name, t := range objectType.AttrTypes {
+			attrs[name] = types.ListNull(t.(types.ListType).ElemType)
+		}
+		teamType := objectType.AttrTypes["team"].(types.ListType).ElemType.(types.ObjectType)
+		attrs["team"] = types.ListValueMust(teamType, []attr.Value{
+			types.ObjectValueMust(teamType.AttrTypes, map[string]attr.Value{
+				"team_id": types.StringValue(teamID),
+			}),
+		})
+		return types.ListValueMust(objectType, []attr.Value{
+			types.ObjectValueMust(objectType.AttrTypes, attrs),
+		})
+	}
+
+	newDefaultRule := func(blocks types.List, notifications ...attr.Value) types.List {
+		return types.ListValueMust(defaultRuleType, []attr.Value{
+			types.ObjectValueMust(defaultRuleType.AttrTypes, map[string]attr.Value{
+				"notifications":      types.ListValueMust(types.StringType, notifications),
+				"notification":       blocks,
+				"runbook_url_prefix": types.StringValue("https://runbooks.example/"),
+				"tip":                types.StringNull(),
+				"skip_clear_notification_states": types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("OK"),
+				}),
+				"reminder_notification": types.ListValueMust(reminderNotificationType, []attr.Value{
+					types.ObjectValueMust(reminderNotificationType.AttrTypes, map[string]attr.Value{
+						"interval_ms": types.Int64Value(60000),
+						"timeout_ms":  types.Int64Null(),
+						"type":        types.StringValue("TIMEOUT"),
+					}),
+				}),
+			}),
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		block  types.List
+		expect *pmeta.DefaultRule
+		errVal string
+	}{
+		{
+			name:   "not set",
+			block:  types.ListNull(defaultRuleType),
+			expect: nil,
+		},
+		{
+			name:  "values set",
+			block: newDefaultRule(types.ListNull(notificationType.ElemType), types.StringValue("Email,oncall@example.com")),
+			expect: &pmeta.DefaultRule{
+				Notifications: []*notification.Notification{
+					{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "oncall@example.com"}},
+				},
+				RunbookURLPrefix:            "https://runbooks.example/",
+				SkipClearNotificationStates: []string{"OK"},
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+			},
+		},
+		{
+			name:  "notification blocks set",
+			block: newDefaultRule(newTeamNotification("AAAAAAAAAAA")),
+			expect: &pmeta.DefaultRule{
+				Notifications: []*notification.Notification{
+					{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: "AAAAAAAAAAA"}},
+				},
+				RunbookURLPrefix:            "https://runbooks.example/",
+				SkipClearNotificationStates: []string{"OK"},
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+			},
+		},
+		{
+			name:   "invalid notification",
+			block:  newDefaultRule(types.ListNull(notificationType.ElemType), types.StringValue("Pager,team")),
+			errVal: "Invalid notification",
+		},
+		{
+			name:   "both notification forms set",
+			block:  newDefaultRule(newTeamNotification("AAAAAAAAAAA"), types.StringValue("Team,AAAAAAAAAAA")),
+			errVal: "Invalid notification",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := &OllyProviderModel{DefaultRule: tc.block}
+			dr, diags := model.LoadDefaultRule(t.Context())
+			if tc.errVal != "" {
+				require.True(t, diags.HasError(), "Must report an error")
+				assert.Equal(t, tc.errVal, diags[0].Summary(), "Must match the expected error")
+				return
+			}
+			require.False(t, diags.HasError(), "Must not report an error")
+			assert.Equal(t, tc.expect, dr, "Must match the expected default rule")
+		})
+	}
+}
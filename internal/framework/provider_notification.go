@@ -0,0 +1,160 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalframework
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// newNotificationBlock returns the notification block used within the provider schema,
+// it must match the block defined by the SDK provider so that both providers can be served together.
+func newNotificationBlock(description string) schema.ListNestedBlock {
+	credential := schema.StringAttribute{
+		Required:    true,
+		Description: "ID of the integration that sends the notification",
+	}
+	team := schema.StringAttribute{
+		Required:    true,
+		Description: "ID of the team",
+	}
+
+	blocks := map[string]schema.Block{
+		common.EmailNotificationBlock: newNotificationTypeBlock("Sends the notification to an email address", map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				Required:    true,
+				Description: "Email address to send the notification to",
+			},
+			"cc": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Email addresses to copy on the notification",
+			},
+			"bcc": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Email addresses to blind copy on the notification",
+			},
+		}),
+		common.OpsgenieNotificationBlock: newNotificationTypeBlock("Sends the notification to an Opsgenie responder", map[string]schema.Attribute{
+			"credential_id": credential,
+			"responder_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the Opsgenie responder",
+			},
+			"responder_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the Opsgenie responder",
+			},
+			"responder_type": schema.StringAttribute{
+				Required:    true,
+				Description: "Type of the Opsgenie responder",
+			},
+		}),
+		common.SlackNotificationBlock: newNotificationTypeBlock("Sends the notification to a Slack channel", map[string]schema.Attribute{
+			"credential_id": credential,
+			"channel": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the Slack channel, without the leading #",
+			},
+		}),
+		common.TeamNotificationBlock: newNotificationTypeBlock("Sends the notification to the notification policy of a team", map[string]schema.Attribute{
+			"team_id": team,
+		}),
+		common.TeamEmailNotificationBlock: newNotificationTypeBlock("Sends the notification to the members of a team by email", map[string]schema.Attribute{
+			"team_id": team,
+		}),
+		common.VictorOpsNotificationBlock: newNotificationTypeBlock("Sends the notification to Splunk On-Call (formerly VictorOps)", map[string]schema.Attribute{
+			"credential_id": credential,
+			"routing_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Routing key used to route the notification",
+			},
+		}),
+		common.WebhookNotificationBlock: newNotificationTypeBlock("Sends the notification to a webhook, set either credential_id or url", map[string]schema.Attribute{
+			"credential_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the webhook integration",
+			},
+			"secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret sent with the notification when url is set",
+			},
+			"url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the webhook",
+			},
+		}),
+	}
+
+	// The remaining types only need the integration credential.
+	for name, description := range map[string]string{
+		common.AmazonEventBridgeNotificationBlock: "Sends the notification to Amazon EventBridge",
+		common.BigPandaNotificationBlock:          "Sends the notification to BigPanda",
+		common.JiraNotificationBlock:              "Creates a Jira issue for the notification",
+		common.Office365NotificationBlock:         "Sends the notification to Microsoft Teams",
+		common.PagerDutyNotificationBlock:         "Sends the notification to PagerDuty",
+		common.ServiceNowNotificationBlock:        "Creates a ServiceNow incident for the notification",
+		common.SplunkPlatformNotificationBlock:    "Sends the notification to the Splunk platform",
+		common.XMattersNotificationBlock:          "Sends the notification to xMatters",
+	} {
+		blocks[name] = newNotificationTypeBlock(description, map[string]schema.Attribute{
+			"credential_id": credential,
+		})
+	}
+
+	return schema.ListNestedBlock{
+		Description: description,
+		NestedObject: schema.NestedBlockObject{
+			Blocks: blocks,
+		},
+	}
+}
+
+func newNotificationTypeBlock(description string, attributes map[string]schema.Attribute) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: description,
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
+	}
+}
+
+// notificationBlockValues converts the notification blocks into the
+// values read by the SDK, so they can be parsed by [common.NewNotificationListFromBlocks].
+func notificationBlockValues(v attr.Value) any {
+	switch v := v.(type) {
+	case basetypes.StringValue:
+		return v.ValueString()
+	case basetypes.ListValue:
+		if v.IsNull() || v.IsUnknown() {
+			return nil
+		}
+		items := make([]any, 0, len(v.Elements()))
+		for _, item := range v.Elements() {
+			items = append(items, notificationBlockValues(item))
+		}
+		return items
+	case basetypes.ObjectValue:
+		if v.IsNull() || v.IsUnknown() {
+			return nil
+		}
+		values := make(map[string]any, len(v.Attributes()))
+		for name, item := range v.Attributes() {
+			values[name] = notificationBlockValues(item)
+		}
+		return values
+	}
+	return nil
+}
@@ -3,7 +3,11 @@
 
 package internalframework
 
-import "github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+import (
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
 
 type ProviderOption func(*ollyProvider)
 
@@ -12,3 +16,11 @@ func WithProviderFeatureRegistry(reg *feature.Registry) ProviderOption {
 		p.features = reg
 	}
 }
+
+// WithProviderLegacyResources sets the resources defined by the SDKv2 provider
+// that are served alongside this provider, which allows them to be listed.
+func WithProviderLegacyResources(resources map[string]*sdkschema.Resource) ProviderOption {
+	return func(p *ollyProvider) {
+		p.legacyResources = resources
+	}
+}
@@ -9,37 +9,53 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/signalfx"
 )
 
+var defaultRuleTerraformType = tftypes.List{ElementType: defaultRuleType.TerraformType(context.Background())}
+
 func NewTestConfig(p provider.Provider, values map[string]tftypes.Value) tfsdk.Config {
 	schema := &provider.SchemaResponse{}
 	p.Schema(context.Background(), provider.SchemaRequest{}, schema)
 	data := map[string]tftypes.Value{
-		"auth_token":             tftypes.NewValue(tftypes.String, nil),
-		"api_url":                tftypes.NewValue(tftypes.String, nil),
-		"custom_app_url":         tftypes.NewValue(tftypes.String, nil),
-		"timeout_seconds":        tftypes.NewValue(tftypes.Number, nil),
-		"retry_max_attempts":     tftypes.NewValue(tftypes.Number, nil),
-		"retry_wait_min_seconds": tftypes.NewValue(tftypes.Number, nil),
-		"retry_wait_max_seconds": tftypes.NewValue(tftypes.Number, nil),
-		"email":                  tftypes.NewValue(tftypes.String, nil),
-		"password":               tftypes.NewValue(tftypes.String, nil),
-		"organization_id":        tftypes.NewValue(tftypes.String, nil),
-		"feature_preview":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, nil),
-		"tags":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
-		"teams":                  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"auth_token":              tftypes.NewValue(tftypes.String, nil),
+		"api_url":                 tftypes.NewValue(tftypes.String, nil),
+		"credential_process":      tftypes.NewValue(tftypes.String, nil),
+		"realm":                   tftypes.NewValue(tftypes.String, nil),
+		"custom_app_url":          tftypes.NewValue(tftypes.String, nil),
+		"timeout_seconds":         tftypes.NewValue(tftypes.Number, nil),
+		"retry_max_attempts":      tftypes.NewValue(tftypes.Number, nil),
+		"retry_wait_min_seconds":  tftypes.NewValue(tftypes.Number, nil),
+		"retry_wait_max_seconds":  tftypes.NewValue(tftypes.Number, nil),
+		"requests_per_second":     tftypes.NewValue(tftypes.Number, nil),
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, nil),
+		"email":                   tftypes.NewValue(tftypes.String, nil),
+		"password":                tftypes.NewValue(tftypes.String, nil),
+		"organization_id":         tftypes.NewValue(tftypes.String, nil),
+		"profile":                 tftypes.NewValue(tftypes.String, nil),
+		"feature_preview":         tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, nil),
+		"tags":                    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"teams":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"tracking_tags":           tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"default_rule":            tftypes.NewValue(defaultRuleTerraformType, nil),
 	}
 	maps.Copy(data, values)
 	return tfsdk.Config{
@@ -47,34 +63,48 @@ func NewTestConfig(p provider.Provider, values map[string]tftypes.Value) tfsdk.C
 		Raw: tftypes.NewValue(
 			tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
-					"auth_token":             tftypes.String,
-					"api_url":                tftypes.String,
-					"custom_app_url":         tftypes.String,
-					"timeout_seconds":        tftypes.Number,
-					"retry_max_attempts":     tftypes.Number,
-					"retry_wait_min_seconds": tftypes.Number,
-					"retry_wait_max_seconds": tftypes.Number,
-					"email":                  tftypes.String,
-					"password":               tftypes.String,
-					"organization_id":        tftypes.String,
-					"feature_preview":        tftypes.Map{ElementType: tftypes.Bool},
-					"tags":                   tftypes.List{ElementType: tftypes.String},
-					"teams":                  tftypes.List{ElementType: tftypes.String},
+					"auth_token":              tftypes.String,
+					"api_url":                 tftypes.String,
+					"credential_process":      tftypes.String,
+					"realm":                   tftypes.String,
+					"custom_app_url":          tftypes.String,
+					"timeout_seconds":         tftypes.Number,
+					"retry_max_attempts":      tftypes.Number,
+					"retry_wait_min_seconds":  tftypes.Number,
+					"retry_wait_max_seconds":  tftypes.Number,
+					"requests_per_second":     tftypes.Number,
+					"max_concurrent_requests": tftypes.Number,
+					"email":                   tftypes.String,
+					"password":                tftypes.String,
+					"organization_id":         tftypes.String,
+					"profile":                 tftypes.String,
+					"feature_preview":         tftypes.Map{ElementType: tftypes.Bool},
+					"tags":                    tftypes.List{ElementType: tftypes.String},
+					"teams":                   tftypes.List{ElementType: tftypes.String},
+					"tracking_tags":           tftypes.List{ElementType: tftypes.String},
+					"default_rule":            defaultRuleTerraformType,
 				},
 				OptionalAttributes: map[string]struct{}{
-					"auth_token":             {},
-					"api_url":                {},
-					"custom_app_url":         {},
-					"timeout_seconds":        {},
-					"retry_max_attempts":     {},
-					"retry_wait_min_seconds": {},
-					"retry_wait_max_seconds": {},
-					"email":                  {},
-					"password":               {},
-					"organization_id":        {},
-					"feature_preview":        {},
-					"tags":                   {},
-					"teams":                  {},
+					"auth_token":              {},
+					"api_url":                 {},
+					"credential_process":      {},
+					"realm":                   {},
+					"custom_app_url":          {},
+					"timeout_seconds":         {},
+					"retry_max_attempts":      {},
+					"retry_wait_min_seconds":  {},
+					"retry_wait_max_seconds":  {},
+					"requests_per_second":     {},
+					"max_concurrent_requests": {},
+					"email":                   {},
+					"password":                {},
+					"organization_id":         {},
+					"profile":                 {},
+					"feature_preview":         {},
+					"tags":                    {},
+					"teams":                   {},
+					"tracking_tags":           {},
+					"default_rule":            {},
 				},
 			},
 			data,
@@ -125,9 +155,10 @@ func TestProviderResource(t *testing.T) {
 	p := NewProvider("1.0.0")
 
 	expect := map[string]struct{}{
-		"signalfx_alert_muting_rule":     {},
-		"signalfx_big_panda_integration": {},
-		"signalfx_email_template":        {},
+		"signalfx_alert_muting_rule":         {},
+		"signalfx_big_panda_integration":     {},
+		"signalfx_email_template":            {},
+		"signalfx_integration_splunk_oncall": {},
 	}
 
 	actual := p.Resources(context.Background())
@@ -139,6 +170,98 @@ func TestProviderResource(t *testing.T) {
 	}
 }
 
+func TestProviderEphemeralResources(t *testing.T) {
+	t.Parallel()
+
+	p, ok := NewProvider("1.0.0").(provider.ProviderWithEphemeralResources)
+	require.True(t, ok, "Provider must implement ProviderWithEphemeralResources")
+
+	expect := map[string]struct{}{
+		"signalfx_session_token": {},
+	}
+
+	actual := p.EphemeralResources(context.Background())
+	assert.Len(t, actual, len(expect), "Must return expected number of ephemeral resources")
+	for _, res := range actual {
+		resp := &ephemeral.MetadataResponse{}
+		res().Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+		assert.Contains(t, expect, resp.TypeName, "Ephemeral resource %s must be expected", resp.TypeName)
+	}
+}
+
+func TestProviderListResources(t *testing.T) {
+	t.Parallel()
+
+	p, ok := NewProvider(
+		"1.0.0",
+		WithProviderLegacyResources(signalfx.Provider().ResourcesMap),
+	).(provider.ProviderWithListResources)
+	require.True(t, ok, "Provider must implement ProviderWithListResources")
+
+	expect := map[string]struct{}{
+		"signalfx_alert_muting_rule":  {},
+		"signalfx_dashboard":          {},
+		"signalfx_dashboard_group":    {},
+		"signalfx_detector":           {},
+		"signalfx_event_feed_chart":   {},
+		"signalfx_heatmap_chart":      {},
+		"signalfx_list_chart":         {},
+		"signalfx_single_value_chart": {},
+		"signalfx_table_chart":        {},
+		"signalfx_text_chart":         {},
+		"signalfx_time_chart":         {},
+	}
+
+	actual := p.ListResources(context.Background())
+	assert.Len(t, actual, len(expect), "Must return expected number of list resources")
+	for _, res := range actual {
+		resp := &resource.MetadataResponse{}
+		res().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+		assert.Contains(t, expect, resp.TypeName, "List resource %s must be expected", resp.TypeName)
+	}
+
+	server, err := providerserver.NewProtocol5WithError(p)()
+	require.NoError(t, err, "Must create provider server")
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err, "Must return the provider schema")
+	assert.Empty(t, schemas.Diagnostics, "Must not report any issues with the list resources")
+	for name := range expect {
+		assert.Contains(t, schemas.ListResourceSchemas, name, "Must have a list resource schema for %s", name)
+	}
+}
+
+func TestProviderActions(t *testing.T) {
+	t.Parallel()
+
+	p, ok := NewProvider("1.0.0").(provider.ProviderWithActions)
+	require.True(t, ok, "Provider must implement ProviderWithActions")
+
+	expect := map[string]struct{}{
+		"signalfx_mute_detector":     {},
+		"signalfx_rotate_org_token":  {},
+		"signalfx_validate_detector": {},
+	}
+
+	actual := p.Actions(context.Background())
+	assert.Len(t, actual, len(expect), "Must return expected number of actions")
+	for _, act := range actual {
+		resp := &action.MetadataResponse{}
+		act().Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+		assert.Contains(t, expect, resp.TypeName, "Action %s must be expected", resp.TypeName)
+	}
+
+	server, err := providerserver.NewProtocol5WithError(p)()
+	require.NoError(t, err, "Must create provider server")
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err, "Must return the provider schema")
+	assert.Empty(t, schemas.Diagnostics, "Must not report any issues with the actions")
+	for name := range expect {
+		assert.Contains(t, schemas.ActionSchemas, name, "Must have an action schema for %s", name)
+	}
+}
+
 func TestProviderFunctions(t *testing.T) {
 	t.Parallel()
 
@@ -291,6 +414,36 @@ func TestProviderConfigure(t *testing.T) {
 				Teams:        []string{"team1", "team2"},
 			},
 		},
+		{
+			name: "Credentials from credential process",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"credential_process": tftypes.NewValue(tftypes.String, `echo '{"auth_token":"process-token","api_url":"http://localhost"}'`),
+					"retry_max_attempts": tftypes.NewValue(tftypes.Number, 0),
+				}
+			},
+			issues: nil,
+			expect: &pmeta.Meta{
+				APIURL:    "http://localhost",
+				AuthToken: "process-token",
+			},
+		},
+		{
+			name: "Credential process failed",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"credential_process": tftypes.NewValue(tftypes.String, `echo "vault is sealed" >&2; exit 1`),
+				}
+			},
+			issues: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("credential_process"),
+					"Issue running credential process",
+					"credential process failed: exit status 1: vault is sealed",
+				),
+			},
+			expect: nil,
+		},
 		{
 			name: "Custom Domain is provided from user config",
 			data: func(_ *testing.T) map[string]tftypes.Value {
@@ -438,8 +591,9 @@ func TestProviderConfigure(t *testing.T) {
 			provider.ConfigureRequest{
 				TerraformVersion: "1.0.0",
 				Config: NewTestConfig(p, map[string]tftypes.Value{
-					"api_url":    tftypes.NewValue(tftypes.String, "http://localhost"),
-					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+					"api_url":            tftypes.NewValue(tftypes.String, "http://localhost"),
+					"auth_token":         tftypes.NewValue(tftypes.String, "my-secret-token"),
+					"retry_max_attempts": tftypes.NewValue(tftypes.Number, 0),
 				}),
 			},
 			resp,
@@ -484,7 +638,7 @@ func TestProviderValidateConfig(t *testing.T) {
 				diag.NewAttributeErrorDiagnostic(
 					path.Root("api_url"),
 					"Missing API Endpoint",
-					"Field must be set to a valid endpoint for the Splunk Observability Cloud provider.",
+					"Field must be set to a valid endpoint for the Splunk Observability Cloud provider, or 'realm' or 'credential_process' must be set.",
 				),
 				diag.NewWarningDiagnostic(
 					"Missing Authentication Method",
@@ -514,6 +668,42 @@ func TestProviderValidateConfig(t *testing.T) {
 			},
 			issues: nil,
 		},
+		{
+			name: "Realm set",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"realm":      tftypes.NewValue(tftypes.String, "eu0"),
+					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+				}
+			},
+			issues: nil,
+		},
+		{
+			name: "Credential process set",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"credential_process": tftypes.NewValue(tftypes.String, "vault-signalfx-token"),
+				}
+			},
+			issues: nil,
+		},
+		{
+			name: "Realm and API URL set",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"api_url":    tftypes.NewValue(tftypes.String, "http://localhost"),
+					"realm":      tftypes.NewValue(tftypes.String, "eu0"),
+					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+				}
+			},
+			issues: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("realm"),
+					"Conflicting API Endpoint",
+					"Only one of 'realm' or 'api_url' can be set, this includes the realm set by the SFX_REALM environment variable.",
+				),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -540,3 +730,190 @@ func TestProviderValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderConfigureRetries(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		settings map[string]int64
+		faults   []fwtest.Fault
+		requests int
+		minWait  time.Duration
+		maxWait  time.Duration
+		errVal   string
+	}{
+		{
+			name:     "no faults",
+			settings: map[string]int64{},
+			requests: 1,
+		},
+		{
+			name: "burst of 5xx within attempts",
+			settings: map[string]int64{
+				"retry_max_attempts":     4,
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusServiceUnavailable, Times: 2},
+				{Status: http.StatusBadGateway},
+				{Status: http.StatusInternalServerError},
+			},
+			requests: 5,
+		},
+		{
+			name: "burst of 5xx exceeds attempts",
+			settings: map[string]int64{
+				"retry_max_attempts":     2,
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusInternalServerError, Times: 5},
+			},
+			requests: 3,
+			errVal:   "giving up after 3 attempt(s)",
+		},
+		{
+			name: "client errors are not retried",
+			settings: map[string]int64{
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusBadRequest},
+			},
+			requests: 1,
+			errVal:   "route \"/v2/team/team-1\" had issues with status code 400",
+		},
+		{
+			name: "not implemented is not retried",
+			settings: map[string]int64{
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusNotImplemented},
+			},
+			requests: 1,
+			errVal:   "route \"/v2/team/team-1\" had issues with status code 501",
+		},
+		{
+			name: "rate limited waits for retry after",
+			settings: map[string]int64{
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 30,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusTooManyRequests, RetryAfter: time.Second},
+			},
+			requests: 2,
+			minWait:  time.Second,
+		},
+		{
+			name: "retry after is bounded by max wait",
+			settings: map[string]int64{
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 1,
+			},
+			faults: []fwtest.Fault{
+				{Status: http.StatusTooManyRequests, RetryAfter: time.Hour},
+			},
+			requests: 2,
+			minWait:  time.Second,
+			maxWait:  10 * time.Second,
+		},
+		{
+			name: "slow response is retried after timeout",
+			settings: map[string]int64{
+				"timeout_seconds":        1,
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Delay: time.Minute},
+			},
+			requests: 2,
+			minWait:  time.Second,
+			maxWait:  10 * time.Second,
+		},
+		{
+			name: "slow responses exceed attempts",
+			settings: map[string]int64{
+				"timeout_seconds":        1,
+				"retry_max_attempts":     0,
+				"retry_wait_min_seconds": 0,
+				"retry_wait_max_seconds": 0,
+			},
+			faults: []fwtest.Fault{
+				{Delay: time.Minute},
+			},
+			requests: 1,
+			maxWait:  10 * time.Second,
+			errVal:   "giving up after 1 attempt(s)",
+		},
+		{
+			name:     "truncated body is reported",
+			settings: map[string]int64{},
+			faults: []fwtest.Fault{
+				{Truncate: true},
+			},
+			requests: 1,
+			errVal:   "unexpected EOF",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			script := fwtest.NewFaultScript(tc.faults...)
+			s := httptest.NewServer(fwtest.NewMockServeMux(t, map[string]http.Handler{
+				"GET /v2/organization": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(`{"url": "https://app.signalfx.com"}`))
+				}),
+				"GET /v2/team/team-1": script.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"id": "team-1", "name": "my team"}`))
+				})),
+			}))
+			t.Cleanup(s.Close)
+
+			values := map[string]tftypes.Value{
+				"api_url":    tftypes.NewValue(tftypes.String, s.URL),
+				"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+			}
+			for name, v := range tc.settings {
+				values[name] = tftypes.NewValue(tftypes.Number, v)
+			}
+
+			p := NewProvider(t.Name())
+			resp := &provider.ConfigureResponse{}
+			p.Configure(
+				context.Background(),
+				provider.ConfigureRequest{
+					TerraformVersion: "1.11.0",
+					Config:           NewTestConfig(p, values),
+				},
+				resp,
+			)
+			require.False(t, resp.Diagnostics.HasError(), "Must not error configuring provider")
+
+			meta := resp.ResourceData.(*pmeta.Meta)
+
+			start := time.Now()
+			team, err := meta.Client.GetTeam(context.Background(), "team-1")
+			elapsed := time.Since(start)
+
+			if tc.errVal != "" {
+				assert.ErrorContains(t, err, tc.errVal, "Must match the expected error")
+			} else if assert.NoError(t, err, "Must not error reading team") {
+				assert.Equal(t, "my team", team.Name, "Must match the expected team")
+			}
+			assert.Equal(t, tc.requests, script.Requests(), "Must match the number of requests made")
+			assert.GreaterOrEqual(t, elapsed, tc.minWait, "Must wait at least the expected duration")
+			if tc.maxWait > 0 {
+				assert.Less(t, elapsed, tc.maxWait, "Must not wait longer than the expected duration")
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwquery
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// searchPageSize is the number of results requested per page.
+const searchPageSize = 100
+
+// searchResult contains the common fields of an object returned by the search API.
+type searchResult struct {
+	ID    string
+	Name  string
+	Tags  []string
+	Teams []string
+}
+
+// searchFunc pages through the search API passing each result to yield,
+// it stops once all results have been read or yield returns false.
+type searchFunc func(ctx context.Context, client *signalfx.Client, name string, yield func(*searchResult) bool) error
+
+// ListResource allows for `terraform query` to find existing objects
+// that are managed by a resource defined in the SDKv2 provider.
+type ListResource struct {
+	fwembed.ResourceData
+
+	name     string
+	tags     bool
+	teams    bool
+	search   searchFunc
+	resource *sdkschema.Resource
+}
+
+var (
+	_ list.ListResource                 = (*ListResource)(nil)
+	_ list.ListResourceWithConfigure    = (*ListResource)(nil)
+	_ list.ListResourceWithRawV5Schemas = (*ListResource)(nil)
+)
+
+func (lr *ListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + lr.name
+}
+
+func (lr *ListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the existing objects that can be imported as `signalfx_" + lr.name + "`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return objects with a name containing this value, the match is case insensitive.",
+			},
+		},
+	}
+	if lr.tags {
+		resp.Schema.Attributes["tags"] = schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Only return objects that have all of the provided tags.",
+		}
+	}
+	if lr.teams {
+		resp.Schema.Attributes["teams"] = schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Only return objects that belong to at least one of the provided team IDs.",
+		}
+	}
+}
+
+func (lr *ListResource) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	if lr.resource == nil {
+		return
+	}
+	resp.ProtoV5Schema = lr.resource.ProtoSchema(ctx)()
+	if identity := lr.resource.ProtoIdentitySchema(ctx); identity != nil {
+		resp.ProtoV5IdentitySchema = identity()
+	}
+}
+
+func (lr *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var (
+		name  types.String
+		tags  []string
+		teams []string
+		diags diag.Diagnostics
+	)
+
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if lr.tags {
+		diags.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	}
+	if lr.teams {
+		diags.Append(req.Config.GetAttribute(ctx, path.Root("teams"), &teams)...)
+	}
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	client, err := pmeta.LoadClient(ctx, lr.Details())
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic("Unable to load client", err.Error()),
+		})
+		return
+	}
+
+	matches := func(found *searchResult) bool {
+		if !strings.Contains(strings.ToLower(found.Name), strings.ToLower(name.ValueString())) {
+			return false
+		}
+		for _, tag := range tags {
+			if !slices.Contains(found.Tags, tag) {
+				return false
+			}
+		}
+		return len(teams) == 0 || slices.ContainsFunc(teams, func(team string) bool {
+			return slices.Contains(found.Teams, team)
+		})
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+
+		err := lr.search(ctx, client, name.ValueString(), func(found *searchResult) bool {
+			if !matches(found) {
+				return true
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = found.Name
+			result.Diagnostics.Append(lr.SetIdentity(ctx, result.Identity, found.ID)...)
+
+			// Reading the complete object requires the SDKv2 provider,
+			// so only the values known from the search are included.
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), found.ID)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), found.Name)...)
+			}
+
+			if !push(result) {
+				return false
+			}
+
+			count++
+			return req.Limit <= 0 || count < req.Limit
+		})
+		if err != nil {
+			push(list.ListResult{Diagnostics: diag.Diagnostics{
+				diag.NewErrorDiagnostic("Unable to search for signalfx_"+lr.name, err.Error()),
+			}})
+		}
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwquery
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+var identityType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":     tftypes.String,
+		"org_id": tftypes.String,
+		"realm":  tftypes.String,
+	},
+}
+
+// testResources contains a minimal SDKv2 resource for each of the supported list types.
+var testResources = func() map[string]*sdkschema.Resource {
+	resources := make(map[string]*sdkschema.Resource, len(listTypes))
+	for _, lt := range listTypes {
+		resources["signalfx_"+lt.name] = &sdkschema.Resource{
+			Schema: map[string]*sdkschema.Schema{
+				"name": {Type: sdkschema.TypeString, Required: true},
+			},
+			Identity: &sdkschema.ResourceIdentity{
+				SchemaFunc: func() map[string]*sdkschema.Schema {
+					return map[string]*sdkschema.Schema{
+						"id":     {Type: sdkschema.TypeString, RequiredForImport: true},
+						"org_id": {Type: sdkschema.TypeString, OptionalForImport: true},
+						"realm":  {Type: sdkschema.TypeString, OptionalForImport: true},
+					}
+				},
+			},
+		}
+	}
+	return resources
+}()
+
+func newListResource(tb testing.TB, name string) func() list.ListResource {
+	tb.Helper()
+
+	for _, lr := range NewListResources(testResources) {
+		resp := &resource.MetadataResponse{}
+		lr().Metadata(tb.Context(), resource.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+		if resp.TypeName == name {
+			return lr
+		}
+	}
+	require.FailNow(tb, "Must have a list resource for "+name)
+	return nil
+}
+
+func newListConfig(tb testing.TB, lr list.ListResource, values map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	tb.Helper()
+
+	resp := &list.ListResourceSchemaResponse{}
+	lr.ListResourceConfigSchema(tb.Context(), list.ListResourceSchemaRequest{}, resp)
+
+	typ := resp.Schema.Type().TerraformType(tb.Context())
+	attrs := make(map[string]tftypes.Value)
+	for name, attr := range typ.(tftypes.Object).AttributeTypes {
+		attrs[name] = tftypes.NewValue(attr, nil)
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		}
+	}
+
+	dv, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	require.NoError(tb, err, "Must be able to create list config")
+	return &dv
+}
+
+func newStringList(values ...string) tftypes.Value {
+	elems := make([]tftypes.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, tftypes.NewValue(tftypes.String, v))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+}
+
+func TestNewListResources(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		resources map[string]*sdkschema.Resource
+		expect    []string
+	}{
+		{
+			name:      "no resources",
+			resources: nil,
+			expect:    []string{},
+		},
+		{
+			name: "only provided resources",
+			resources: map[string]*sdkschema.Resource{
+				"signalfx_detector":   testResources["signalfx_detector"],
+				"signalfx_time_chart": testResources["signalfx_time_chart"],
+				"signalfx_webhook":    {},
+			},
+			expect: []string{"signalfx_detector", "signalfx_time_chart"},
+		},
+		{
+			name:      "all resources",
+			resources: testResources,
+			expect: []string{
+				"signalfx_detector",
+				"signalfx_dashboard",
+				"signalfx_dashboard_group",
+				"signalfx_event_feed_chart",
+				"signalfx_heatmap_chart",
+				"signalfx_list_chart",
+				"signalfx_single_value_chart",
+				"signalfx_table_chart",
+				"signalfx_text_chart",
+				"signalfx_time_chart",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := []string{}
+			for _, lr := range NewListResources(tc.resources) {
+				resp := &resource.MetadataResponse{}
+				lr().Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+				actual = append(actual, resp.TypeName)
+			}
+			assert.Equal(t, tc.expect, actual, "Must match the expected type names")
+		})
+	}
+}
+
+func TestListResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		lr     func() list.ListResource
+		expect []string
+	}{
+		{name: "detector", lr: newListResource(t, "signalfx_detector"), expect: []string{"name", "tags", "teams"}},
+		{name: "dashboard", lr: newListResource(t, "signalfx_dashboard"), expect: []string{"name", "tags"}},
+		{name: "dashboard group", lr: newListResource(t, "signalfx_dashboard_group"), expect: []string{"name", "teams"}},
+		{name: "chart", lr: newListResource(t, "signalfx_event_feed_chart"), expect: []string{"name", "tags"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &list.ListResourceSchemaResponse{}
+			lr := tc.lr()
+			lr.ListResourceConfigSchema(t.Context(), list.ListResourceSchemaRequest{}, resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), "Must not report any issues")
+			assert.NotEmpty(t, resp.Schema.Description, "Must have a description set")
+			assert.Len(t, resp.Schema.Attributes, len(tc.expect), "Must only have the supported filters")
+			for _, name := range tc.expect {
+				assert.Contains(t, resp.Schema.Attributes, name, "Must support the %q filter", name)
+			}
+
+			raw := &list.RawV5SchemaResponse{}
+			lr.(list.ListResourceWithRawV5Schemas).RawV5Schemas(t.Context(), list.RawV5SchemaRequest{}, raw)
+			assert.NotNil(t, raw.ProtoV5Schema, "Must return the resource schema")
+			assert.NotNil(t, raw.ProtoV5IdentitySchema, "Must return the resource identity schema")
+		})
+	}
+}
+
+func TestListResourceMockIntegration(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		lr        func() list.ListResource
+		endpoints map[string]http.Handler
+		config    map[string]tftypes.Value
+		limit     int64
+		include   bool
+		expect    map[string]string
+		errVal    string
+	}{
+		{
+			name: "detectors filtered by tags and teams",
+			lr:   newListResource(t, "signalfx_detector"),
+			endpoints: map[string]http.Handler{
+				"GET /v2/detector": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(&detector.SearchResults{
+						Count: 4,
+						Results: []detector.Detector{
+							{Id: "detector-1", Name: "CPU Utilization", Tags: []string{"prod", "cpu"}, Teams: []string{"team-a"}},
+							{Id: "detector-2", Name: "Memory Utilization", Tags: []string{"prod"}, Teams: []string{"team-a"}},
+							{Id: "detector-3", Name: "Disk Utilization", Tags: []string{"prod", "cpu"}, Teams: []string{"team-b"}},
+							{Id: "detector-4", Name: "CPU Auto Detector", Tags: []string{"prod", "cpu"}, Teams: []string{"team-a"}, DetectorOrigin: "AutoDetect"},
+						},
+					})
+				}),
+			},
+			config: map[string]tftypes.Value{
+				"tags":  newStringList("prod", "cpu"),
+				"teams": newStringList("team-a"),
+			},
+			expect: map[string]string{
+				"detector-1": "CPU Utilization",
+			},
+		},
+		{
+			name: "detectors filtered by name across pages",
+			lr:   newListResource(t, "signalfx_detector"),
+			endpoints: map[string]http.Handler{
+				"GET /v2/detector": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					assert.Equal(t, "cpu", r.URL.Query().Get("name"), "Must search using the provided name")
+
+					results := &detector.SearchResults{}
+					switch r.URL.Query().Get("offset") {
+					case "0":
+						for range searchPageSize {
+							results.Results = append(results.Results, detector.Detector{Id: "standard", Name: "Memory"})
+						}
+						results.Results[0] = detector.Detector{Id: "detector-1", Name: "CPU Utilization"}
+					case "100":
+						results.Results = append(results.Results, detector.Detector{Id: "detector-101", Name: "Container cpu"})
+					default:
+						t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+					}
+					_ = json.NewEncoder(w).Encode(results)
+				}),
+			},
+			config: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "cpu"),
+			},
+			expect: map[string]string{
+				"detector-1":   "CPU Utilization",
+				"detector-101": "Container cpu",
+			},
+		},
+		{
+			name: "limit stops reading results",
+			lr:   newListResource(t, "signalfx_dashboard_group"),
+			endpoints: map[string]http.Handler{
+				"GET /v2/dashboardgroup": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(&dashboard_group.SearchResult{
+						Count: 2,
+						Results: []*dashboard_group.DashboardGroup{
+							{Id: "group-1", Name: "First"},
+							{Id: "group-2", Name: "Second"},
+						},
+					})
+				}),
+			},
+			limit:   1,
+			include: true,
+			expect: map[string]string{
+				"group-1": "First",
+			},
+		},
+		{
+			name: "charts filtered by type",
+			lr:   newListResource(t, "signalfx_time_chart"),
+			endpoints: map[string]http.Handler{
+				"GET /v2/chart": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(&chart.SearchResult{
+						Count: 3,
+						Results: []*chart.Chart{
+							{Id: "chart-1", Name: "Requests", Options: &chart.Options{Type: "TimeSeriesChart"}},
+							{Id: "chart-2", Name: "Notes", Options: &chart.Options{Type: "Text"}},
+							{Id: "chart-3", Name: "Unknown"},
+						},
+					})
+				}),
+			},
+			expect: map[string]string{
+				"chart-1": "Requests",
+			},
+		},
+		{
+			name: "search returns error",
+			lr:   newListResource(t, "signalfx_dashboard"),
+			endpoints: map[string]http.Handler{
+				"GET /v2/dashboard": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					http.Error(w, "Not Serving Requests", http.StatusBadGateway)
+				}),
+			},
+			expect: map[string]string{},
+			errVal: `route "/v2/dashboard" had issues with status code 502`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := providerserver.NewProtocol5WithError(fwtest.NewMock(
+				t,
+				tc.endpoints,
+				fwtest.WithMockListResources(tc.lr),
+			))()
+			require.NoError(t, err, "Must create provider server")
+
+			providerConfig, err := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+			require.NoError(t, err, "Must create provider config")
+
+			configured, err := server.ConfigureProvider(t.Context(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+			require.NoError(t, err, "Must configure provider")
+			require.Empty(t, configured.Diagnostics, "Must not report issues configuring provider")
+
+			lr := tc.lr()
+			meta := &resource.MetadataResponse{}
+			lr.Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "signalfx"}, meta)
+
+			lister, ok := server.(tfprotov5.ProviderServerWithListResource)
+			require.True(t, ok, "Must support list resources")
+
+			stream, err := lister.ListResource(t.Context(), &tfprotov5.ListResourceRequest{
+				TypeName:        meta.TypeName,
+				Config:          newListConfig(t, lr, tc.config),
+				IncludeResource: tc.include,
+				Limit:           tc.limit,
+			})
+			require.NoError(t, err, "Must not error listing resources")
+
+			var (
+				actual = make(map[string]string)
+				errs   []string
+			)
+			for result := range stream.Results {
+				for _, d := range result.Diagnostics {
+					if d.Severity == tfprotov5.DiagnosticSeverityError {
+						errs = append(errs, d.Detail)
+					}
+				}
+				if result.Identity == nil {
+					continue
+				}
+
+				identity, err := result.Identity.IdentityData.Unmarshal(identityType)
+				require.NoError(t, err, "Must be able to read identity")
+
+				var values map[string]tftypes.Value
+				require.NoError(t, identity.As(&values))
+
+				var id string
+				require.NoError(t, values["id"].As(&id))
+				actual[id] = result.DisplayName
+
+				if tc.include {
+					assert.NotNil(t, result.Resource, "Must include the resource when requested")
+				}
+			}
+
+			assert.Equal(t, tc.expect, actual, "Must match the expected results")
+			if tc.errVal != "" {
+				assert.Equal(t, []string{tc.errVal}, errs, "Must report the expected error")
+			} else {
+				assert.Empty(t, errs, "Must not report any errors")
+			}
+		})
+	}
+}
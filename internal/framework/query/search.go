@@ -0,0 +1,156 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwquery
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go"
+)
+
+// chartTypes maps the chart resources to the chart type reported by the API.
+var chartTypes = []struct {
+	name      string
+	chartType string
+}{
+	{name: "event_feed_chart", chartType: "Event"},
+	{name: "heatmap_chart", chartType: "Heatmap"},
+	{name: "list_chart", chartType: "List"},
+	{name: "single_value_chart", chartType: "SingleValue"},
+	{name: "table_chart", chartType: "TableChart"},
+	{name: "text_chart", chartType: "Text"},
+	{name: "time_chart", chartType: "TimeSeriesChart"},
+}
+
+// listType describes the filters and search method of an SDKv2 resource that can be listed.
+type listType struct {
+	name   string
+	tags   bool
+	teams  bool
+	search searchFunc
+}
+
+// listTypes contains each of the SDKv2 resources that can be listed.
+var listTypes = func() []listType {
+	types := []listType{
+		{name: "detector", tags: true, teams: true, search: searchDetectors},
+		{name: "dashboard", tags: true, search: searchDashboards},
+		{name: "dashboard_group", teams: true, search: searchDashboardGroups},
+	}
+	for _, ct := range chartTypes {
+		types = append(types, listType{name: ct.name, tags: true, search: searchCharts(ct.chartType)})
+	}
+	return types
+}()
+
+// NewListResources returns a list resource for each of the supported SDKv2 resources
+// contained in resources, which is keyed by the full resource type name.
+// The resource is used to report the resource and identity schema of the list results.
+func NewListResources(resources map[string]*sdkschema.Resource) []func() list.ListResource {
+	lrs := make([]func() list.ListResource, 0, len(listTypes))
+	for _, lt := range listTypes {
+		res, ok := resources["signalfx_"+lt.name]
+		if !ok {
+			continue
+		}
+		lrs = append(lrs, func() list.ListResource {
+			return &ListResource{
+				name:     lt.name,
+				tags:     lt.tags,
+				teams:    lt.teams,
+				search:   lt.search,
+				resource: res,
+			}
+		})
+	}
+	return lrs
+}
+
+func searchDetectors(ctx context.Context, client *signalfx.Client, name string, yield func(*searchResult) bool) error {
+	for offset := 0; ; offset += searchPageSize {
+		results, err := client.SearchDetectors(ctx, searchPageSize, name, offset, "")
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results.Results {
+			// Auto detectors are managed by Splunk Observability Cloud
+			// and can not be imported as a detector resource.
+			if r.DetectorOrigin == "AutoDetect" {
+				continue
+			}
+			if !yield(&searchResult{ID: r.Id, Name: r.Name, Tags: r.Tags, Teams: r.Teams}) {
+				return nil
+			}
+		}
+
+		if len(results.Results) < searchPageSize {
+			return nil
+		}
+	}
+}
+
+func searchDashboards(ctx context.Context, client *signalfx.Client, name string, yield func(*searchResult) bool) error {
+	for offset := 0; ; offset += searchPageSize {
+		results, err := client.SearchDashboard(ctx, searchPageSize, name, offset, "")
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results.Results {
+			if !yield(&searchResult{ID: r.Id, Name: r.Name, Tags: r.Tags}) {
+				return nil
+			}
+		}
+
+		if len(results.Results) < searchPageSize {
+			return nil
+		}
+	}
+}
+
+func searchDashboardGroups(ctx context.Context, client *signalfx.Client, name string, yield func(*searchResult) bool) error {
+	for offset := 0; ; offset += searchPageSize {
+		results, err := client.SearchDashboardGroups(ctx, searchPageSize, name, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results.Results {
+			if !yield(&searchResult{ID: r.Id, Name: r.Name, Teams: r.Teams}) {
+				return nil
+			}
+		}
+
+		if len(results.Results) < searchPageSize {
+			return nil
+		}
+	}
+}
+
+func searchCharts(chartType string) searchFunc {
+	return func(ctx context.Context, client *signalfx.Client, name string, yield func(*searchResult) bool) error {
+		for offset := 0; ; offset += searchPageSize {
+			results, err := client.SearchCharts(ctx, searchPageSize, name, offset, "")
+			if err != nil {
+				return err
+			}
+
+			for _, r := range results.Results {
+				if r.Options == nil || r.Options.Type != chartType {
+					continue
+				}
+				if !yield(&searchResult{ID: r.Id, Name: r.Name, Tags: r.Tags}) {
+					return nil
+				}
+			}
+
+			if len(results.Results) < searchPageSize {
+				return nil
+			}
+		}
+	}
+}
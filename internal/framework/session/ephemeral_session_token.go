@@ -0,0 +1,137 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwsession
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// sessionTokenPrivateKey is the private data key used to pass
+// the created token from Open to Close so that it can be revoked.
+const sessionTokenPrivateKey = "access_token"
+
+type EphemeralSessionToken struct {
+	fwembed.EphemeralResourceData
+}
+
+type ephemeralSessionTokenModel struct {
+	Email          types.String `tfsdk:"email"`
+	Password       types.String `tfsdk:"password"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	AccessToken    types.String `tfsdk:"access_token"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
+
+var (
+	_ ephemeral.EphemeralResource              = (*EphemeralSessionToken)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*EphemeralSessionToken)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*EphemeralSessionToken)(nil)
+)
+
+func NewEphemeralSessionToken() ephemeral.EphemeralResource {
+	return &EphemeralSessionToken{}
+}
+
+func (st *EphemeralSessionToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_token"
+}
+
+func (st *EphemeralSessionToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short lived session token using an email and password without storing it within state. " +
+			"The session token is revoked once Terraform no longer requires it.",
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				Required:    true,
+				Description: "Email of the user, it requires the account to be configured to login with Email and Password.",
+			},
+			"password": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the user, it requires the account to be configured to login with Email and Password.",
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Required if the user is configured to be part of multiple organizations.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The session token that can be used to authenticate with the Splunk Observability Cloud API.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the session token expires at, formatted as RFC3339.",
+			},
+		},
+	}
+}
+
+func (st *EphemeralSessionToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ephemeralSessionTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	meta := &pmeta.Meta{
+		APIURL:         st.Details().APIURL,
+		Email:          model.Email.ValueString(),
+		Password:       model.Password.ValueString(),
+		OrganizationID: model.OrganizationID.ValueString(),
+	}
+
+	token, err := meta.CreateSessionToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Issue creating session token", err.Error())
+		return
+	}
+
+	model.AccessToken = types.StringValue(token.AccessToken)
+	model.ExpiresAt = types.StringNull()
+	if token.ExpiryMs > 0 {
+		model.ExpiresAt = types.StringValue(time.UnixMilli(token.ExpiryMs).UTC().Format(time.RFC3339))
+	}
+
+	data, err := json.Marshal(token.AccessToken)
+	if err != nil {
+		resp.Diagnostics.AddError("Issue storing session token", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionTokenPrivateKey, data)...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (st *EphemeralSessionToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	data, diags := req.Private.GetKey(ctx, sessionTokenPrivateKey)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() || len(data) == 0 {
+		return
+	}
+
+	var token string
+	if err := json.Unmarshal(data, &token); err != nil {
+		resp.Diagnostics.AddError("Issue reading session token", err.Error())
+		return
+	}
+
+	meta := &pmeta.Meta{
+		APIURL: st.Details().APIURL,
+	}
+
+	// The token expires on its own, so failing to revoke it
+	// should not prevent the rest of the operation from completing.
+	if err := meta.DeleteSessionToken(ctx, token); err != nil {
+		resp.Diagnostics.AddWarning("Unable to revoke session token", err.Error())
+	}
+}
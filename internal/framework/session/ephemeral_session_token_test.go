@@ -0,0 +1,211 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwsession
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+var sessionTokenType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"email":           tftypes.String,
+		"password":        tftypes.String,
+		"organization_id": tftypes.String,
+		"access_token":    tftypes.String,
+		"expires_at":      tftypes.String,
+	},
+}
+
+func newSessionTokenConfig(tb testing.TB, email, password string) *tfprotov5.DynamicValue {
+	tb.Helper()
+
+	dv, err := tfprotov5.NewDynamicValue(sessionTokenType, tftypes.NewValue(sessionTokenType, map[string]tftypes.Value{
+		"email":           tftypes.NewValue(tftypes.String, email),
+		"password":        tftypes.NewValue(tftypes.String, password),
+		"organization_id": tftypes.NewValue(tftypes.String, nil),
+		"access_token":    tftypes.NewValue(tftypes.String, nil),
+		"expires_at":      tftypes.NewValue(tftypes.String, nil),
+	}))
+	require.NoError(tb, err, "Must be able to create config value")
+	return &dv
+}
+
+func TestEphemeralSessionTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.MetadataResponse{}
+	NewEphemeralSessionToken().Metadata(t.Context(), ephemeral.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_session_token", resp.TypeName, "Must match the expected type name")
+}
+
+func TestEphemeralSessionTokenSchema(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.SchemaResponse{}
+	NewEphemeralSessionToken().Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "Must not report any issues")
+	assert.NotEmpty(t, resp.Schema.Description, "Must have a description set")
+	for name, attr := range resp.Schema.Attributes {
+		assert.NotEmpty(t, attr.GetDescription(), "Attribute %q must have a description", name)
+	}
+	assert.True(t, resp.Schema.Attributes["password"].IsSensitive(), "Password must be sensitive")
+	assert.True(t, resp.Schema.Attributes["access_token"].IsSensitive(), "Access token must be sensitive")
+}
+
+func TestEphemeralSessionTokenLifecycle(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		endpoints map[string]http.Handler
+		email     string
+		password  string
+		token     string
+		expires   string
+		openErr   string
+		closeWarn string
+	}{
+		{
+			name: "token created and revoked",
+			endpoints: map[string]http.Handler{
+				"POST /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var req sessiontoken.CreateTokenRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					if req.Email != "user@example.com" || req.Password != "hunter2" {
+						http.Error(w, "invalid credentials", http.StatusUnauthorized)
+						return
+					}
+					//nolint:gosec // G117: The access token is synthetic data returned by this test-only HTTP server.
+					_ = json.NewEncoder(w).Encode(&sessiontoken.Token{AccessToken: "session-token", ExpiryMs: 1700000000000})
+				}),
+				"DELETE /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					if r.Header.Get("X-SF-Token") != "session-token" {
+						http.Error(w, "unknown token", http.StatusUnauthorized)
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			},
+			email:    "user@example.com",
+			password: "hunter2",
+			token:    "session-token",
+			expires:  "2023-11-14T22:13:20Z",
+		},
+		{
+			name: "invalid credentials",
+			endpoints: map[string]http.Handler{
+				"POST /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					http.Error(w, "invalid credentials", http.StatusUnauthorized)
+				}),
+			},
+			email:    "user@example.com",
+			password: "wrong",
+			openErr:  "route \"/v2/session\" had issues with status code 401",
+		},
+		{
+			name: "token already revoked",
+			endpoints: map[string]http.Handler{
+				"POST /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					//nolint:gosec // G117: The access token is synthetic data returned by this test-only HTTP server.
+					_ = json.NewEncoder(w).Encode(&sessiontoken.Token{AccessToken: "session-token"})
+				}),
+				"DELETE /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					http.Error(w, "unknown token", http.StatusUnauthorized)
+				}),
+			},
+			email:     "user@example.com",
+			password:  "hunter2",
+			token:     "session-token",
+			closeWarn: "Unable to revoke session token",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := providerserver.NewProtocol5WithError(fwtest.NewMock(
+				t,
+				tc.endpoints,
+				fwtest.WithMockEphemeralResources(NewEphemeralSessionToken),
+			))()
+			require.NoError(t, err, "Must create provider server")
+
+			providerConfig, err := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+			require.NoError(t, err, "Must create provider config")
+
+			configured, err := server.ConfigureProvider(t.Context(), &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+			require.NoError(t, err, "Must configure provider")
+			require.Empty(t, configured.Diagnostics, "Must not report issues configuring provider")
+
+			opened, err := server.OpenEphemeralResource(t.Context(), &tfprotov5.OpenEphemeralResourceRequest{
+				TypeName: "signalfx_session_token",
+				Config:   newSessionTokenConfig(t, tc.email, tc.password),
+			})
+			require.NoError(t, err, "Must not error opening the ephemeral resource")
+
+			if tc.openErr != "" {
+				require.Len(t, opened.Diagnostics, 1, "Must report a single issue")
+				assert.Equal(t, tfprotov5.DiagnosticSeverityError, opened.Diagnostics[0].Severity)
+				assert.Equal(t, tc.openErr, opened.Diagnostics[0].Detail)
+				return
+			}
+			require.Empty(t, opened.Diagnostics, "Must not report any issues")
+
+			result, err := opened.Result.Unmarshal(sessionTokenType)
+			require.NoError(t, err, "Must be able to read result")
+
+			var values map[string]tftypes.Value
+			require.NoError(t, result.As(&values))
+
+			var token, expires *string
+			require.NoError(t, values["access_token"].As(&token))
+			require.NoError(t, values["expires_at"].As(&expires))
+			assert.Equal(t, tc.token, *token, "Must match the expected token")
+			if tc.expires == "" {
+				assert.Nil(t, expires, "Must not set the expiry when not returned")
+			} else {
+				assert.Equal(t, tc.expires, *expires, "Must match the expected expiry")
+			}
+
+			closed, err := server.CloseEphemeralResource(t.Context(), &tfprotov5.CloseEphemeralResourceRequest{
+				TypeName: "signalfx_session_token",
+				Private:  opened.Private,
+			})
+			require.NoError(t, err, "Must not error closing the ephemeral resource")
+
+			if tc.closeWarn != "" {
+				require.Len(t, closed.Diagnostics, 1, "Must report a single issue")
+				assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, closed.Diagnostics[0].Severity)
+				assert.Equal(t, tc.closeWarn, closed.Diagnostics[0].Summary)
+			} else {
+				assert.Empty(t, closed.Diagnostics, "Must not report any issues")
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/signalfx/signalfx-go/orgtoken"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	fwtypes "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/types"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+type ActionRotateOrgToken struct {
+	fwembed.ActionData
+}
+
+type actionRotateOrgTokenModel struct {
+	Name        types.String      `tfsdk:"name"`
+	GracePeriod fwtypes.TimeRange `tfsdk:"grace_period"`
+}
+
+var (
+	_ action.Action              = (*ActionRotateOrgToken)(nil)
+	_ action.ActionWithConfigure = (*ActionRotateOrgToken)(nil)
+)
+
+func NewActionRotateOrgToken() action.Action {
+	return &ActionRotateOrgToken{}
+}
+
+func (rt *ActionRotateOrgToken) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotate_org_token"
+}
+
+func (rt *ActionRotateOrgToken) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Rotates the secret of an org token, the new secret is read by `signalfx_org_token` on the next refresh. " +
+			"Rotating a token requires the provider to be configured with a session token that has admin permissions.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the org token to rotate.",
+			},
+			"grace_period": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  fwtypes.TimeRangeType{},
+				Description: "How long the previous secret remains valid after rotating, for example `30m`. When not set, the previous secret is revoked immediately.",
+			},
+		},
+	}
+}
+
+func (rt *ActionRotateOrgToken) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model actionRotateOrgTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var grace time.Duration
+	if !model.GracePeriod.IsNull() {
+		var err error
+		if grace, err = model.GracePeriod.ParseDuration(); err != nil || grace < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("grace_period"), "Invalid grace period", fmt.Sprintf("grace period %q must be a positive duration", model.GracePeriod.ValueString()))
+			return
+		}
+	}
+
+	token, err := rotateOrgToken(ctx, rt.Details(), model.Name.ValueString(), grace)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to rotate org token", err.Error())
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Rotated the secret of org token %q", token.Name),
+		})
+	}
+}
+
+// rotateOrgToken requests a new secret for the named org token.
+//
+// Note: The go-sdk does not currently support rotating org tokens,
+// once it is added this should adopt the sfx client directly.
+func rotateOrgToken(ctx context.Context, meta *pmeta.Meta, name string, grace time.Duration) (*orgtoken.Token, error) {
+	token, err := meta.LoadSessionToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.ParseRequestURI(meta.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/v2/token/" + name + "/rotate"
+	u.RawPath = "/v2/token/" + url.PathEscape(name) + "/rotate"
+	if grace > 0 {
+		u.RawQuery = url.Values{"graceful": {strconv.FormatInt(int64(grace/time.Second), 10)}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-SF-Token", token)
+	req.Header.Set("Accept", "application/json")
+
+	// The provider client is used so the request is sent with the configured
+	// authentication, retries, rate limits and tracing.
+	resp, err := cmp.Or(meta.HTTPClient, http.DefaultClient).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("route %q had issues with status code %d: %s", u.Path, resp.StatusCode, body)
+	}
+
+	rotated := &orgtoken.Token{}
+	if err := json.NewDecoder(resp.Body).Decode(rotated); err != nil {
+		return nil, err
+	}
+	return rotated, nil
+}
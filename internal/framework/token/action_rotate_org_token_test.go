@@ -0,0 +1,137 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestActionRotateOrgTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &action.MetadataResponse{}
+	NewActionRotateOrgToken().Metadata(t.Context(), action.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_rotate_org_token", resp.TypeName, "Must match the expected type name")
+}
+
+func TestActionRotateOrgTokenInvoke(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		values   map[string]tftypes.Value
+		token    string
+		graceful string
+		status   int
+		progress []string
+		errVal   string
+	}{
+		{
+			name: "rotate immediately",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "ingest token"),
+			},
+			status:   http.StatusOK,
+			progress: []string{`Rotated the secret of org token "ingest token"`},
+		},
+		{
+			name: "token name is escaped",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "team/ingest?token"),
+			},
+			token:    "team/ingest?token",
+			status:   http.StatusOK,
+			progress: []string{`Rotated the secret of org token "team/ingest?token"`},
+		},
+		{
+			name: "rotate with grace period",
+			values: map[string]tftypes.Value{
+				"name":         tftypes.NewValue(tftypes.String, "ingest token"),
+				"grace_period": tftypes.NewValue(tftypes.String, "30m"),
+			},
+			graceful: "1800",
+			status:   http.StatusOK,
+			progress: []string{`Rotated the secret of org token "ingest token"`},
+		},
+		{
+			name: "invalid grace period",
+			values: map[string]tftypes.Value{
+				"name":         tftypes.NewValue(tftypes.String, "ingest token"),
+				"grace_period": tftypes.NewValue(tftypes.String, "-30m"),
+			},
+			errVal: `grace period "-30m" must be a positive duration`,
+		},
+		{
+			name: "not authorized",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "ingest token"),
+			},
+			status: http.StatusUnauthorized,
+			errVal: `route "/v2/token/ingest token/rotate" had issues with status code 401`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoints := map[string]http.Handler{
+				"POST /v2/token/{name}/rotate": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					assert.Equal(t, cmp.Or(tc.token, "ingest token"), r.PathValue("name"), "Must rotate the named token")
+					assert.Equal(t, tc.graceful, r.URL.Query().Get("graceful"), "Must match the expected grace period")
+					assert.NotEmpty(t, r.Header.Get("X-SF-Token"), "Must authenticate the request")
+
+					if tc.status != http.StatusOK {
+						http.Error(w, "Unauthorized", tc.status)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&orgtoken.Token{Name: r.PathValue("name"), Secret: "rotated"})
+				}),
+			}
+
+			progress, diags := fwtest.InvokeMockAction(t, endpoints, NewActionRotateOrgToken, "signalfx_rotate_org_token", tc.values)
+			if tc.errVal != "" {
+				if assert.Len(t, diags, 1, "Must report an error") {
+					assert.Contains(t, diags[0].Detail, tc.errVal, "Must match the expected error")
+				}
+				return
+			}
+
+			assert.Empty(t, diags, "Must not report any issues")
+			assert.Equal(t, tc.progress, progress, "Must match the expected progress")
+		})
+	}
+}
+
+func TestActionRotateOrgTokenFakeAPI(t *testing.T) {
+	t.Parallel()
+
+	api := fakeapi.New()
+	api.Put(fakeapi.Tokens, map[string]any{"name": "ingest token", "secret": "original"})
+
+	progress, diags := fwtest.InvokeMockAction(t, api.Endpoints(), NewActionRotateOrgToken, "signalfx_rotate_org_token", map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "ingest token"),
+	})
+	assert.Empty(t, diags, "Must not report any issues")
+	assert.Equal(t, []string{`Rotated the secret of org token "ingest token"`}, progress, "Must report the token was rotated")
+
+	token, ok := api.Get(fakeapi.Tokens, "ingest token")
+	if assert.True(t, ok, "Must still have the token") {
+		assert.NotEqual(t, "original", token["secret"], "Must have rotated the secret")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// relatedCollections lists the objects that the API modifies as a side effect
+// of writing to another, such as a dashboard being added to its dashboard group.
+var relatedCollections = map[string][]string{
+	"dashboard":      {"dashboardgroup"},
+	"dashboardgroup": {"dashboard"},
+}
+
+var (
+	cacheOnce sync.Once
+	cache     *ResponseCache
+)
+
+// GetResponseCache returns the cache shared by every provider served
+// within the process, so a write made by one invalidates the reads of the other.
+func GetResponseCache() *ResponseCache {
+	cacheOnce.Do(func() {
+		cache = NewResponseCache()
+	})
+	return cache
+}
+
+// ResponseCache stores successful GET responses in memory by route so
+// that repeated reads of the same object during a plan only reach the API once.
+//
+// Any other request invalidates the cached responses of the objects named within its path,
+// their collection, and any collection that the API updates as a side effect.
+// The cache is disabled by default and is enabled by the `provider.cache` feature preview.
+type ResponseCache struct {
+	enabled atomic.Bool
+
+	mu         sync.Mutex
+	generation uint64
+	entries    map[cacheKey]*cachedResponse
+}
+
+// cacheKey separates the responses of providers that are configured
+// with a different API or token within the same process.
+type cacheKey struct {
+	scope [sha256.Size]byte
+	path  string
+	query string
+}
+
+func newCacheKey(req *http.Request) cacheKey {
+	return cacheKey{
+		scope: sha256.Sum256([]byte(req.URL.Host + "\n" + req.Header.Get(signalfx.AuthHeaderKey))),
+		path:  req.URL.Path,
+		query: req.URL.RawQuery,
+	}
+}
+
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewResponseCache returns an empty, disabled, cache.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries: make(map[cacheKey]*cachedResponse),
+	}
+}
+
+// SetEnabled turns caching on or off, disabling the cache also clears it.
+func (rc *ResponseCache) SetEnabled(enabled bool) {
+	rc.enabled.Store(enabled)
+	if !enabled {
+		rc.Clear()
+	}
+}
+
+// Enabled reports if responses are being cached.
+func (rc *ResponseCache) Enabled() bool {
+	return rc != nil && rc.enabled.Load()
+}
+
+// Len returns the number of cached responses.
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return len(rc.entries)
+}
+
+// Clear removes all cached responses.
+func (rc *ResponseCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	clear(rc.entries)
+}
+
+// Transport returns a [http.RoundTripper] that serves GET requests from the cache
+// and forwards everything else to base.
+func (rc *ResponseCache) Transport(base http.RoundTripper) http.RoundTripper {
+	return &cacheTransport{cache: rc, base: base}
+}
+
+type cacheTransport struct {
+	cache *ResponseCache
+	base  http.RoundTripper
+}
+
+func (ct *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rc := ct.cache
+	if !rc.Enabled() {
+		return ct.base.RoundTrip(req)
+	}
+
+	if req.Method != http.MethodGet {
+		resp, err := ct.base.RoundTrip(req)
+		// The write may have been applied even if the response was not received,
+		// so the cached values are always removed.
+		rc.invalidate(req)
+		return resp, err
+	}
+
+	key := newCacheKey(req)
+
+	rc.mu.Lock()
+	entry, ok := rc.entries[key]
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	if ok {
+		tflog.Trace(req.Context(), "Using cached response", tfext.NewLogFields().Field("route", req.URL.RequestURI()))
+		return entry.response(req), nil
+	}
+
+	resp, err := ct.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry = &cachedResponse{status: resp.StatusCode, header: resp.Header.Clone(), body: body}
+
+	rc.mu.Lock()
+	// A write made while the request was in flight could
+	// mean the response is already out of date.
+	if generation == rc.generation {
+		rc.entries[key] = entry
+	}
+	rc.mu.Unlock()
+
+	return entry.response(req), nil
+}
+
+func (rc *ResponseCache) invalidate(req *http.Request) {
+	// Paths are of the form `/v2/<collection>/<id>/<action>/<id>`,
+	// each collection and id pair refers to an object that could have been modified.
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) < 2 {
+		rc.Clear()
+		return
+	}
+	prefix, segments := "/"+segments[0]+"/", segments[1:]
+
+	var paths, prefixes []string
+	for i := 0; i < len(segments); i += 2 {
+		paths = append(paths, prefix+segments[i])
+		if i+1 < len(segments) {
+			obj := prefix + segments[i] + "/" + segments[i+1]
+			paths, prefixes = append(paths, obj), append(prefixes, obj+"/")
+		}
+	}
+	for _, related := range relatedCollections[segments[0]] {
+		paths, prefixes = append(paths, prefix+related), append(prefixes, prefix+related+"/")
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	for key := range rc.entries {
+		if slices.Contains(paths, key.path) || slices.ContainsFunc(prefixes, func(p string) bool {
+			return strings.HasPrefix(key.path, p)
+		}) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+func (cr *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cr.status, http.StatusText(cr.status)),
+		StatusCode:    cr.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cr.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cr.body)),
+		ContentLength: int64(len(cr.body)),
+		Request:       req,
+	}
+}
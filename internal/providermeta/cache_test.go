@@ -0,0 +1,221 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheTestServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newCacheTestServer(t *testing.T, handler http.HandlerFunc) *cacheTestServer {
+	s := &cacheTestServer{requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.RequestURI()]++
+		s.mu.Unlock()
+
+		if handler != nil {
+			handler(w, r)
+			return
+		}
+		switch {
+		case r.Method != http.MethodGet:
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			http.Error(w, "not found", http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(r.URL.RequestURI()))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *cacheTestServer) Requests(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[route]
+}
+
+func doRequest(t *testing.T, client *http.Client, method, url, token string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, http.NoBody)
+	require.NoError(t, err, "Must not error creating request")
+	req.Header.Set("X-SF-Token", token)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err, "Must not error making request")
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Must not error reading body")
+	return resp.StatusCode, string(body)
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	s := newCacheTestServer(t, nil)
+	rc := NewResponseCache()
+	client := &http.Client{Transport: rc.Transport(s.Client().Transport)}
+
+	for range 3 {
+		_, body := doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/AAAA", "token")
+		assert.Equal(t, "/v2/chart/AAAA", body, "Must return the response")
+	}
+	assert.Equal(t, 3, s.Requests("GET /v2/chart/AAAA"), "Must not cache responses when disabled")
+	assert.Equal(t, 0, rc.Len(), "Must not store responses when disabled")
+}
+
+func TestResponseCacheReads(t *testing.T) {
+	t.Parallel()
+
+	s := newCacheTestServer(t, nil)
+	rc := NewResponseCache()
+	rc.SetEnabled(true)
+	client := &http.Client{Transport: rc.Transport(s.Client().Transport)}
+
+	for range 3 {
+		status, body := doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/AAAA", "token")
+		assert.Equal(t, http.StatusOK, status, "Must return the cached status")
+		assert.Equal(t, "/v2/chart/AAAA", body, "Must return the cached body")
+
+		_, body = doRequest(t, client, http.MethodGet, s.URL+"/v2/chart?name=cpu", "token")
+		assert.Equal(t, "/v2/chart?name=cpu", body, "Must cache searches by query")
+
+		status, _ = doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/missing", "token")
+		assert.Equal(t, http.StatusNotFound, status, "Must return the error status")
+	}
+	_, _ = doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/AAAA", "other-token")
+
+	assert.Equal(t, 2, s.Requests("GET /v2/chart/AAAA"), "Must only read the object once per token")
+	assert.Equal(t, 1, s.Requests("GET /v2/chart?name=cpu"), "Must only search once")
+	assert.Equal(t, 3, s.Requests("GET /v2/chart/missing"), "Must not cache unsuccessful responses")
+	assert.Equal(t, 3, rc.Len(), "Must cache responses per token")
+
+	rc.SetEnabled(false)
+	assert.Equal(t, 0, rc.Len(), "Must clear the cache once disabled")
+}
+
+func TestResponseCacheInvalidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		path    string
+		removed []string
+		kept    []string
+	}{
+		{
+			name:    "update object",
+			method:  http.MethodPut,
+			path:    "/v2/detector/AAAA",
+			removed: []string{"/v2/detector/AAAA", "/v2/detector/AAAA/incidents", "/v2/detector?name=cpu"},
+			kept:    []string{"/v2/detector/BBBB", "/v2/team/CCCC", "/v2/dashboard/DDDD"},
+		},
+		{
+			name:    "create object",
+			method:  http.MethodPost,
+			path:    "/v2/detector",
+			removed: []string{"/v2/detector?name=cpu"},
+			kept:    []string{"/v2/detector/AAAA", "/v2/detector/BBBB", "/v2/team/CCCC"},
+		},
+		{
+			name:    "delete object",
+			method:  http.MethodDelete,
+			path:    "/v2/detector/BBBB",
+			removed: []string{"/v2/detector/BBBB", "/v2/detector?name=cpu"},
+			kept:    []string{"/v2/detector/AAAA", "/v2/team/CCCC"},
+		},
+		{
+			name:    "link objects",
+			method:  http.MethodPost,
+			path:    "/v2/team/CCCC/detector/AAAA",
+			removed: []string{"/v2/team/CCCC", "/v2/detector/AAAA", "/v2/detector?name=cpu"},
+			kept:    []string{"/v2/detector/BBBB", "/v2/dashboard/DDDD"},
+		},
+		{
+			name:    "related collection",
+			method:  http.MethodPost,
+			path:    "/v2/dashboard",
+			removed: []string{"/v2/dashboardgroup/EEEE", "/v2/dashboardgroup?name=cpu"},
+			kept:    []string{"/v2/dashboard/DDDD", "/v2/detector/AAAA"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newCacheTestServer(t, nil)
+			rc := NewResponseCache()
+			rc.SetEnabled(true)
+			client := &http.Client{Transport: rc.Transport(s.Client().Transport)}
+
+			routes := append(append([]string{}, tc.removed...), tc.kept...)
+			for range 2 {
+				for _, route := range routes {
+					_, _ = doRequest(t, client, http.MethodGet, s.URL+route, "token")
+				}
+			}
+			_, _ = doRequest(t, client, tc.method, s.URL+tc.path, "token")
+			for _, route := range routes {
+				_, _ = doRequest(t, client, http.MethodGet, s.URL+route, "token")
+			}
+
+			assert.Equal(t, 1, s.Requests(tc.method+" "+tc.path), "Must not cache writes")
+			for _, route := range tc.removed {
+				assert.Equal(t, 2, s.Requests("GET "+route), "Must read %s again after the write", route)
+			}
+			for _, route := range tc.kept {
+				assert.Equal(t, 1, s.Requests("GET "+route), "Must keep %s cached", route)
+			}
+		})
+	}
+}
+
+func TestResponseCacheWriteDuringRead(t *testing.T) {
+	t.Parallel()
+
+	rc := NewResponseCache()
+	rc.SetEnabled(true)
+
+	var client *http.Client
+	s := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Query().Get("write") == "true" {
+			// Simulates another resource updating the object
+			// while the read is being served.
+			_, _ = doRequest(t, client, http.MethodPut, "http://"+r.Host+r.URL.Path, "token")
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	client = &http.Client{Transport: rc.Transport(s.Client().Transport)}
+
+	_, _ = doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/AAAA?write=true", "token")
+	assert.Equal(t, 0, rc.Len(), "Must not cache a response that could be out of date")
+
+	_, _ = doRequest(t, client, http.MethodGet, s.URL+"/v2/chart/AAAA", "token")
+	assert.Equal(t, 1, rc.Len(), "Must cache once no write is in flight")
+}
+
+func TestGetResponseCache(t *testing.T) {
+	t.Parallel()
+
+	assert.Same(t, GetResponseCache(), GetResponseCache(), "Must return the same cache")
+}
@@ -0,0 +1,158 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// credentialExpiryWindow is how long before the credentials expire that
+// the process is run again, so requests in flight do not use an expired token.
+const credentialExpiryWindow = time.Minute
+
+// ProcessCredentials is the JSON document written to stdout by a credential process.
+type ProcessCredentials struct {
+	AuthToken string `json:"auth_token"`
+	// APIURL is optional, and is used as the API URL of the provider when set.
+	APIURL string `json:"api_url"`
+	// ExpiresAt is optional, the credentials are used for the lifetime of the provider when not set.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Expired reports if the credentials have expired at the time t.
+func (pc *ProcessCredentials) Expired(t time.Time) bool {
+	return pc.ExpiresAt != nil && !t.Before(*pc.ExpiresAt)
+}
+
+var (
+	credentialProcessesMu sync.Mutex
+	credentialProcesses   = make(map[string]*CredentialProcess)
+)
+
+// CredentialProcess runs an external command, such as a wrapper around a secrets manager,
+// to fetch the credentials used by the provider so they do not need to be stored
+// within the configuration, environment, or provider configuration files.
+//
+// The credentials are kept until they expire, and the command is run again
+// the next time they are used so that long running applies can continue.
+type CredentialProcess struct {
+	command string
+	run     func(ctx context.Context, command string) ([]byte, error)
+
+	mu    sync.Mutex
+	creds *ProcessCredentials
+}
+
+// NewCredentialProcess returns a credential process that runs
+// the command using the shell of the operating system.
+func NewCredentialProcess(command string) *CredentialProcess {
+	return &CredentialProcess{
+		command: command,
+		run:     runCredentialProcess,
+	}
+}
+
+// GetCredentialProcess returns the credential process for the command that is shared within the process.
+// The muxed providers are each configured separately, sharing the process means an interactive
+// command, such as a password manager prompt, is only run once until the credentials expire.
+func GetCredentialProcess(command string) *CredentialProcess {
+	credentialProcessesMu.Lock()
+	defer credentialProcessesMu.Unlock()
+
+	cp, ok := credentialProcesses[command]
+	if !ok {
+		cp = NewCredentialProcess(command)
+		credentialProcesses[command] = cp
+	}
+	return cp
+}
+
+// Credentials returns the current credentials, running the command when there are none or they have expired.
+func (cp *CredentialProcess) Credentials(ctx context.Context) (*ProcessCredentials, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.creds != nil && !cp.creds.Expired(time.Now().Add(credentialExpiryWindow)) {
+		return cp.creds, nil
+	}
+
+	tflog.Debug(ctx, "Running credential process")
+
+	out, err := cp.run(ctx, cp.command)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &ProcessCredentials{}
+	if err := json.Unmarshal(out, creds); err != nil {
+		return nil, fmt.Errorf("credential process returned invalid output: %w", err)
+	}
+	if creds.AuthToken == "" {
+		return nil, errors.New("credential process did not return an auth_token")
+	}
+	if creds.Expired(time.Now()) {
+		return nil, errors.New("credential process returned expired credentials")
+	}
+
+	fields := tfext.NewLogFields()
+	if creds.ExpiresAt != nil {
+		fields = fields.Field("expires_at", creds.ExpiresAt.Format(time.RFC3339))
+	}
+	tflog.Info(ctx, "Loaded credentials from credential process", fields)
+
+	cp.creds = creds
+	return creds, nil
+}
+
+// Transport returns a [http.RoundTripper] that sets the auth token of each request
+// from the credential process, base is returned when cp is nil.
+//
+// It must wrap any transport that depends on the auth token, such as the [ResponseCache].
+func (cp *CredentialProcess) Transport(base http.RoundTripper) http.RoundTripper {
+	if cp == nil {
+		return base
+	}
+	return &credentialTransport{process: cp, base: base}
+}
+
+type credentialTransport struct {
+	process *CredentialProcess
+	base    http.RoundTripper
+}
+
+func (ct *credentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	creds, err := ct.process.Credentials(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	return ct.base.RoundTrip(withAuthToken(req, creds.AuthToken))
+}
+
+func runCredentialProcess(ctx context.Context, command string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := shellCommand(ctx, command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("credential process failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("credential process failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
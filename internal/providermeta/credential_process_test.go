@@ -0,0 +1,215 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCredentialProcess(outputs ...string) (*CredentialProcess, *int) {
+	runs := new(int)
+	return &CredentialProcess{
+		command: "credential-helper",
+		run: func(_ context.Context, command string) ([]byte, error) {
+			if *runs >= len(outputs) {
+				return nil, errors.New("credential process failed: exit status 1")
+			}
+			out := outputs[*runs]
+			*runs++
+			return []byte(out), nil
+		},
+	}, runs
+}
+
+func TestCredentialProcessCredentials(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		output string
+		expect *ProcessCredentials
+		errVal string
+	}{
+		{
+			name:   "auth token only",
+			output: `{"auth_token":"XXX"}`,
+			expect: &ProcessCredentials{AuthToken: "XXX"},
+		},
+		{
+			name:   "auth token and api url",
+			output: `{"auth_token":"XXX","api_url":"https://api.us1.signalfx.com"}`,
+			expect: &ProcessCredentials{AuthToken: "XXX", APIURL: "https://api.us1.signalfx.com"},
+		},
+		{
+			name:   "invalid output",
+			output: `auth_token=XXX`,
+			errVal: "credential process returned invalid output: invalid character 'a' looking for beginning of value",
+		},
+		{
+			name:   "missing auth token",
+			output: `{"api_url":"https://api.us1.signalfx.com"}`,
+			errVal: "credential process did not return an auth_token",
+		},
+		{
+			name:   "expired credentials",
+			output: `{"auth_token":"XXX","expires_at":"2000-01-01T00:00:00Z"}`,
+			errVal: "credential process returned expired credentials",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cp, _ := newTestCredentialProcess(tc.output)
+			creds, err := cp.Credentials(context.Background())
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error loading credentials")
+			assert.Equal(t, tc.expect, creds, "Must match the expected credentials")
+		})
+	}
+}
+
+func TestCredentialProcessRefresh(t *testing.T) {
+	t.Parallel()
+
+	cp, runs := newTestCredentialProcess(
+		// Expires within the expiry window so it is replaced on the next use.
+		fmt.Sprintf(`{"auth_token":"XXX","expires_at":%q}`, time.Now().Add(30*time.Second).Format(time.RFC3339)),
+		fmt.Sprintf(`{"auth_token":"YYY","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339)),
+	)
+
+	var tokens []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get(signalfx.AuthHeaderKey))
+	}))
+	t.Cleanup(s.Close)
+
+	client := &http.Client{Transport: cp.Transport(http.DefaultTransport)}
+	for range 3 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL, http.NoBody)
+		require.NoError(t, err, "Must not error creating request")
+		req.Header.Set(signalfx.AuthHeaderKey, "configured-token")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err, "Must not error sending request")
+		_ = resp.Body.Close()
+		assert.Equal(t, "configured-token", req.Header.Get(signalfx.AuthHeaderKey), "Must not modify the original request")
+	}
+
+	assert.Equal(t, []string{"XXX", "YYY", "YYY"}, tokens, "Must replace the token once expired")
+	assert.Equal(t, 2, *runs, "Must only run the process when the credentials expire")
+
+	cp, _ = newTestCredentialProcess()
+	_, err := (&http.Client{Transport: cp.Transport(http.DefaultTransport)}).Get(s.URL)
+	assert.ErrorContains(t, err, "credential process failed: exit status 1", "Must return the process error")
+}
+
+func TestCredentialProcessNilTransport(t *testing.T) {
+	t.Parallel()
+
+	var cp *CredentialProcess
+	assert.Equal(t, http.DefaultTransport, cp.Transport(http.DefaultTransport), "Must return the base transport")
+}
+
+func TestCredentialProcessMetaLookup(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Test commands are written for a posix shell")
+	}
+
+	for _, tc := range []struct {
+		name    string
+		command string
+		expect  *ProcessCredentials
+		errVal  string
+	}{
+		{
+			name:    "not configured",
+			command: "",
+		},
+		{
+			name:    "credentials returned",
+			command: `echo '{"auth_token":"XXX","api_url":"https://api.us1.signalfx.com"}'`,
+			expect:  &ProcessCredentials{AuthToken: "XXX", APIURL: "https://api.us1.signalfx.com"},
+		},
+		{
+			name:    "process failed",
+			command: `echo "vault is sealed" >&2; exit 2`,
+			errVal:  "credential process failed: exit status 2: vault is sealed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := &Meta{
+				AuthToken:         "file-token",
+				APIURL:            "https://api.signalfx.com",
+				CredentialProcess: tc.command,
+			}
+			err := CredentialProcessMetaLookupFunc().Do(context.Background(), meta)
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+				assert.Nil(t, meta.Credentials, "Must not set the credential process")
+				return
+			}
+			require.NoError(t, err, "Must not error running the credential process")
+
+			if tc.expect == nil {
+				assert.Nil(t, meta.Credentials, "Must not set the credential process")
+				assert.Equal(t, "file-token", meta.AuthToken, "Must keep the existing auth token")
+				return
+			}
+			require.NotNil(t, meta.Credentials, "Must set the credential process")
+			assert.Equal(t, tc.expect.AuthToken, meta.AuthToken, "Must set the auth token")
+			assert.Equal(t, tc.expect.APIURL, meta.APIURL, "Must set the api url")
+		})
+	}
+}
+
+func TestGetCredentialProcess(t *testing.T) {
+	t.Parallel()
+
+	cp := GetCredentialProcess("get-credential-process")
+	assert.Same(t, cp, GetCredentialProcess("get-credential-process"), "Must share the process for the same command")
+	assert.NotSame(t, cp, GetCredentialProcess("get-other-credential-process"), "Must not share the process across commands")
+}
+
+func TestCredentialProcessMetaLookupShared(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Test commands are written for a posix shell")
+	}
+
+	runs := filepath.Join(t.TempDir(), "runs")
+	command := fmt.Sprintf(`echo run >> %q; echo '{"auth_token":"XXX"}'`, runs)
+
+	// Each muxed provider runs the lookup while being configured.
+	metas := []*Meta{{CredentialProcess: command}, {CredentialProcess: command}}
+	for _, meta := range metas {
+		require.NoError(t, CredentialProcessMetaLookupFunc().Do(context.Background(), meta), "Must not error running the credential process")
+		assert.Equal(t, "XXX", meta.AuthToken, "Must set the auth token")
+	}
+	assert.Same(t, metas[0].Credentials, metas[1].Credentials, "Must share the credential process")
+
+	out, err := os.ReadFile(runs)
+	require.NoError(t, err, "Must have run the credential process")
+	assert.Equal(t, "run\n", string(out), "Must only run the credential process once")
+}
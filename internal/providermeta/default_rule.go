@@ -0,0 +1,83 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+// DefaultRule holds the values set by the provider `default_rule` block
+// that are used for any detector rule that leaves the field empty.
+type DefaultRule struct {
+	Notifications []*notification.Notification
+	// RunbookURLPrefix is joined with the detect label of the rule to create its runbook URL.
+	RunbookURLPrefix            string
+	Tip                         string
+	ReminderNotification        *detector.ReminderNotification
+	SkipClearNotificationStates []string
+}
+
+// Apply sets the default values for each field of the rule that has not been set.
+// It does nothing when dr is nil.
+func (dr *DefaultRule) Apply(r *detector.Rule) {
+	if dr == nil || r == nil {
+		return
+	}
+	if len(r.Notifications) == 0 {
+		r.Notifications = slices.Clone(dr.Notifications)
+	}
+	if r.RunbookUrl == "" && dr.RunbookURLPrefix != "" {
+		r.RunbookUrl = dr.RunbookURLPrefix + url.PathEscape(r.DetectLabel)
+	}
+	if r.Tip == "" {
+		r.Tip = dr.Tip
+	}
+	if r.ReminderNotification == nil && dr.ReminderNotification != nil {
+		reminder := *dr.ReminderNotification
+		r.ReminderNotification = &reminder
+	}
+	if len(r.SkipClearNotificationStates) == 0 {
+		r.SkipClearNotificationStates = slices.Clone(dr.SkipClearNotificationStates)
+	}
+}
+
+// LoadProviderDefaultRule returns the default rule set by the provider,
+// nil is returned when it has not been set.
+//
+// Requires preview to be enabled in order to return values.
+func LoadProviderDefaultRule(ctx context.Context, meta any) *DefaultRule {
+	if g, ok := LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderDefaultRule); !ok || !g.Enabled() {
+		tflog.Debug(
+			ctx,
+			"Feature Preview is not enabled, using default value",
+			feature.NewPreviewLogFields(feature.PreviewProviderDefaultRule, g),
+		)
+		return nil
+	}
+
+	if m, ok := meta.(*Meta); ok {
+		return m.DefaultRule
+	}
+
+	return nil
+}
+
+// MergeProviderDefaultRule applies the provider default rule to each of the rules.
+//
+// Note: This currently requires the feature preview `feature.PreviewProviderDefaultRule` to be enabled.
+func MergeProviderDefaultRule(ctx context.Context, meta any, rules []*detector.Rule) []*detector.Rule {
+	dr := LoadProviderDefaultRule(ctx, meta)
+	for _, r := range rules {
+		dr.Apply(r)
+	}
+	return rules
+}
@@ -0,0 +1,135 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"testing"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+func newTestDefaultRule() *DefaultRule {
+	return &DefaultRule{
+		Notifications: []*notification.Notification{
+			{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "oncall@example.com"}},
+		},
+		RunbookURLPrefix:            "https://runbooks.example/",
+		Tip:                         "Check the service dashboard",
+		ReminderNotification:        &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+		SkipClearNotificationStates: []string{"OK"},
+	}
+}
+
+func TestDefaultRuleApply(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		dr     *DefaultRule
+		rule   *detector.Rule
+		expect *detector.Rule
+	}{
+		{
+			name:   "no default rule",
+			dr:     nil,
+			rule:   &detector.Rule{DetectLabel: "High CPU"},
+			expect: &detector.Rule{DetectLabel: "High CPU"},
+		},
+		{
+			name: "empty rule",
+			dr:   newTestDefaultRule(),
+			rule: &detector.Rule{DetectLabel: "High CPU"},
+			expect: &detector.Rule{
+				DetectLabel:                 "High CPU",
+				Notifications:               newTestDefaultRule().Notifications,
+				RunbookUrl:                  "https://runbooks.example/High%20CPU",
+				Tip:                         "Check the service dashboard",
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 60000, Type: "TIMEOUT"},
+				SkipClearNotificationStates: []string{"OK"},
+			},
+		},
+		{
+			name: "rule values set",
+			dr:   newTestDefaultRule(),
+			rule: &detector.Rule{
+				DetectLabel: "High CPU",
+				Notifications: []*notification.Notification{
+					{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: "AAAAAAAAAAA"}},
+				},
+				RunbookUrl:                  "https://wiki.example/cpu",
+				Tip:                         "Restart the service",
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 1000, Type: "TIMEOUT"},
+				SkipClearNotificationStates: []string{"STOPPED"},
+			},
+			expect: &detector.Rule{
+				DetectLabel: "High CPU",
+				Notifications: []*notification.Notification{
+					{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: "AAAAAAAAAAA"}},
+				},
+				RunbookUrl:                  "https://wiki.example/cpu",
+				Tip:                         "Restart the service",
+				ReminderNotification:        &detector.ReminderNotification{IntervalMs: 1000, Type: "TIMEOUT"},
+				SkipClearNotificationStates: []string{"STOPPED"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.dr.Apply(tc.rule)
+			assert.Equal(t, tc.expect, tc.rule, "Must match the expected rule")
+		})
+	}
+}
+
+func TestMergeProviderDefaultRule(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   any
+		expect string
+	}{
+		{
+			name:   "no provider set",
+			meta:   nil,
+			expect: "",
+		},
+		{
+			name: "preview not enabled",
+			meta: &Meta{
+				Registry: func() *feature.Registry {
+					r := feature.NewRegistry()
+					_ = r.MustRegister(feature.PreviewProviderDefaultRule)
+					return r
+				}(),
+				DefaultRule: newTestDefaultRule(),
+			},
+			expect: "",
+		},
+		{
+			name: "preview enabled",
+			meta: &Meta{
+				Registry: func() *feature.Registry {
+					r := feature.NewRegistry()
+					_ = r.MustRegister(feature.PreviewProviderDefaultRule, feature.WithPreviewGlobalAvailable())
+					return r
+				}(),
+				DefaultRule: newTestDefaultRule(),
+			},
+			expect: "Check the service dashboard",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules := MergeProviderDefaultRule(t.Context(), tc.meta, []*detector.Rule{{DetectLabel: "High CPU"}})
+			assert.Equal(t, tc.expect, rules[0].Tip, "Must match the expected tip")
+		})
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/signalfx/signalfx-go"
@@ -26,8 +28,18 @@ import (
 
 var (
 	ErrMetaNotProvided = errors.New("expected to implement type Meta")
+
+	// ErrConflictingAPIEndpoint is returned when the api url is configured alongside a realm,
+	// including the realm set by the SFX_REALM environment variable.
+	ErrConflictingAPIEndpoint = errors.New("only one of 'realm' or 'api_url' can be set, this includes the realm set by the SFX_REALM environment variable")
+
+	// RealmPattern matches the realm names that can be used within a hostname.
+	RealmPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
 )
 
+// legacyAPIHost is the API domain that does not include the realm and only serves us0.
+const legacyAPIHost = "api.signalfx.com"
+
 // Meta is the result of `resource.Provider` being correctly configured
 // and is returned as part of `provider.Meta()`.
 //
@@ -36,20 +48,46 @@ var (
 type Meta struct {
 	Registry *feature.Registry `json:"-"`
 	Client   *signalfx.Client  `json:"-"`
+	Cache    *ResponseCache    `json:"-"`
+	// HTTPClient is the client used by Client, it is used for requests not yet supported by the go-sdk.
+	HTTPClient *http.Client `json:"-"`
 
 	AuthToken      string   `json:"auth_token"`
 	APIURL         string   `json:"api_url"`
+	Realm          string   `json:"realm"`
 	CustomAppURL   string   `json:"custom_app_url"`
 	Email          string   `json:"email"`
 	Password       string   `json:"password"`
 	OrganizationID string   `json:"org_id"`
 	Tags           []string `json:"tags"`
 	Teams          []string `json:"teams"`
+
+	// CredentialProcess is the command run to fetch the auth token, and optionally the API URL.
+	CredentialProcess string `json:"credential_process"`
+	// Credentials is set once the credential process has been run.
+	Credentials *CredentialProcess `json:"-"`
+	// Session is set once a session token has been created from the email and password.
+	Session *SessionTokenSource `json:"-"`
+
+	// DefaultRule is set by the provider `default_rule` block.
+	DefaultRule *DefaultRule `json:"-"`
+	// NotificationTargets keeps the lookups made when checking the targets of notifications.
+	NotificationTargets *NotificationTargets `json:"-"`
+
+	// Profile selects the named profile to read from the provider configuration files.
+	Profile       string `json:"-"`
+	profileLoaded bool
+}
+
+// ProfileLoaded reports if the selected profile has been read from a provider configuration file.
+func (m *Meta) ProfileLoaded() bool {
+	return m.profileLoaded
 }
 
 // LoadClient returns the configured [signalfx.Client] ready to use.
 //
-// Note that it is a shared instance so high amounts of parallelism could cause issues.
+// Note that it is a shared instance, the number of requests in flight is bounded
+// by the provider `max_concurrent_requests` setting across all its users.
 func LoadClient(ctx context.Context, meta any) (*signalfx.Client, error) {
 	if m, ok := meta.(*Meta); ok {
 		return m.Client, nil
@@ -112,31 +150,97 @@ func LoadProviderTags(ctx context.Context, meta any) []string {
 	return nil
 }
 
+// LoadProviderTeams fetches all the configured teams set by the provider.
+//
+// Requires preview to be enabled in order to return values.
+func LoadProviderTeams(ctx context.Context, meta any) []string {
+	if g, ok := LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTeams); !ok || !g.Enabled() {
+		tflog.Debug(
+			ctx,
+			"Feature Preview is not enabled, using default value",
+			feature.NewPreviewLogFields(feature.PreviewProviderTeams, g),
+		)
+		return nil
+	}
+
+	if m, ok := meta.(*Meta); ok {
+		return m.Teams
+	}
+
+	return nil
+}
+
 // LoadSessionToken will use the provider username and password
 // so that it can be used as the token through the interaction.
+//
+// The session token is provided by [Meta.Session], which replaces it once it expires.
 func (m *Meta) LoadSessionToken(ctx context.Context) (string, error) {
 	if m.AuthToken != "" {
 		return m.AuthToken, nil
 	}
 
-	client, err := signalfx.NewClient("", signalfx.APIUrl(m.APIURL))
+	return m.LoadSessionSource().Token(ctx)
+}
+
+// LoadSessionSource returns the source of session tokens when the provider
+// is configured with an email and password instead of an auth token, otherwise it is nil.
+//
+// It is loaded before the HTTP client is configured so the session transport can be included.
+func (m *Meta) LoadSessionSource() *SessionTokenSource {
+	if m.AuthToken == "" && m.Session == nil {
+		m.Session = NewSessionTokenSource(m)
+	}
+	return m.Session
+}
+
+// CreateSessionToken exchanges the configured email and password for a new session token,
+// regardless of if an auth token has already been provided.
+func (m *Meta) CreateSessionToken(ctx context.Context) (*sessiontoken.Token, error) {
+	client, err := m.newSessionClient()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	resp, err := client.CreateSessionToken(ctx, &sessiontoken.CreateTokenRequest{
+	resp, err := client.CreateSessionToken(withoutSessionToken(ctx), &sessiontoken.CreateTokenRequest{
 		Email:          m.Email,
 		Password:       m.Password,
 		OrganizationId: m.OrganizationID,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// TODO: determine if any additional fields would be useful for debugging.
 	tflog.Info(ctx, "Created new session token")
 
-	return resp.AccessToken, nil
+	return resp, nil
+}
+
+// DeleteSessionToken revokes the provided session token so it can no longer be used.
+func (m *Meta) DeleteSessionToken(ctx context.Context, token string) error {
+	client, err := m.newSessionClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteSessionToken(withoutSessionToken(ctx), token); err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, "Deleted session token")
+
+	return nil
+}
+
+// newSessionClient returns the client used to create and delete session tokens.
+// The configured HTTP client is used once available so the requests share the same
+// transport, retries, and request limits as the provider.
+func (m *Meta) newSessionClient() (*signalfx.Client, error) {
+	opts := []signalfx.ClientParam{signalfx.APIUrl(m.APIURL)}
+	if m.HTTPClient != nil {
+		opts = append(opts, signalfx.HTTPClient(m.HTTPClient))
+	}
+	return signalfx.NewClient("", opts...)
 }
 
 // MergeProviderTeams will prepend the provider set teams to the resource level teams.
@@ -161,6 +265,54 @@ func MergeProviderTeams(ctx context.Context, meta any, teams []string) []string
 	return slices.Collect(os.All())
 }
 
+// RealmAPIURL returns the API URL of the realm.
+func RealmAPIURL(realm string) string {
+	return "https://api." + realm + ".signalfx.com"
+}
+
+// RealmAppURL returns the default application URL of the realm.
+func RealmAppURL(realm string) string {
+	return "https://app." + realm + ".signalfx.com"
+}
+
+// LoadRealm returns the configured realm, or the realm used by the configured API URL.
+// An empty string is returned when the realm can not be determined.
+func (m *Meta) LoadRealm() string {
+	if m.Realm != "" {
+		return m.Realm
+	}
+	u, err := url.ParseRequestURI(m.APIURL)
+	if err != nil {
+		return ""
+	}
+	if u.Hostname() == legacyAPIHost {
+		return "us0"
+	}
+	// Realm specific domains are in the form of `api.<realm>.<domain>`
+	parts := strings.SplitN(u.Hostname(), ".", 3)
+	if len(parts) != 3 || parts[0] != "api" {
+		return ""
+	}
+	switch parts[2] {
+	case "signalfx.com", "observability.splunkcloud.com":
+		return parts[1]
+	}
+	return ""
+}
+
+// NetrcMachines returns the machine names, in order of preference,
+// that the auth token can be stored under within a netrc file.
+func (m *Meta) NetrcMachines() []string {
+	switch realm := m.LoadRealm(); realm {
+	case "":
+		return []string{legacyAPIHost}
+	case "us0":
+		return []string{"api.us0.signalfx.com", legacyAPIHost}
+	default:
+		return []string{"api." + realm + ".signalfx.com"}
+	}
+}
+
 func (m *Meta) Validate() (errs error) {
 	if m.AuthToken == "" && (m.Email == "" || m.Password == "") {
 		errs = multierr.Append(errs, errors.New("missing auth token or email and password"))
@@ -171,7 +323,79 @@ func (m *Meta) Validate() (errs error) {
 	return errs
 }
 
+// LookupOrganizationID returns the ID of the organization that owns the auth token
+// when it has not been configured, so that resource identities include the organization.
+// The organization details are fetched the first time they are needed, rather than during configuration.
+func (m *Meta) LookupOrganizationID(ctx context.Context) (string, error) {
+	if m.OrganizationID != "" {
+		return m.OrganizationID, nil
+	}
+	content, err := m.loadOrganization(ctx)
+	if err != nil {
+		return "", err
+	}
+	id, _ := content["id"].(string)
+	return id, nil
+}
+
+// DetectCustomAPPURL fetches the organization details to find the application URL.
 func (m *Meta) DetectCustomAPPURL(ctx context.Context) (string, error) {
+	content, err := m.loadOrganization(ctx)
+	if err != nil {
+		return "", err
+	}
+	if site, ok := content["url"].(string); ok {
+		return site, nil
+	}
+	// Failover to the provided value
+	return m.CustomAppURL, nil
+}
+
+// organizationDetails holds the result of fetching the organization details.
+type organizationDetails struct {
+	sync.Mutex
+	loaded  bool
+	content map[string]any
+	err     error
+}
+
+// organizations keeps the organization details fetched for each API URL and set of credentials,
+// so that the muxed providers share a single request.
+var organizations = struct {
+	sync.Mutex
+	details map[string]*organizationDetails
+}{details: make(map[string]*organizationDetails)}
+
+// loadOrganization fetches the organization details once, so the
+// organization ID and application URL are looked up with a single request.
+// A failed request is not repeated unless it was caused by the context ending.
+func (m *Meta) loadOrganization(ctx context.Context) (map[string]any, error) {
+	key := strings.Join([]string{m.APIURL, m.AuthToken, m.CredentialProcess, m.Email}, "\x00")
+
+	organizations.Lock()
+	od, ok := organizations.details[key]
+	if !ok {
+		od = &organizationDetails{}
+		organizations.details[key] = od
+	}
+	organizations.Unlock()
+
+	od.Lock()
+	defer od.Unlock()
+
+	if od.loaded {
+		return od.content, od.err
+	}
+
+	content, err := m.fetchOrganization(ctx)
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	od.loaded, od.content, od.err = true, content, err
+	return content, err
+}
+
+func (m *Meta) fetchOrganization(ctx context.Context) (map[string]any, error) {
 	// Note(MovieStoreGuy):
 	//
 	// This is a temporary solution to auto-detect the custom app URL.
@@ -179,40 +403,47 @@ func (m *Meta) DetectCustomAPPURL(ctx context.Context) (string, error) {
 
 	u, err := url.ParseRequestURI(m.APIURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	u.Path = "/v2/organization"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	token := m.AuthToken
+	if token == "" && m.Session != nil {
+		if token, err = m.Session.Token(ctx); err != nil {
+			return nil, err
+		}
 	}
-	req.Header.Set("X-SF-Token", m.AuthToken)
+	req.Header.Set("X-SF-Token", token)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	// The configured client is used once available so the request
+	// shares the same transport, retries, and request limits as the provider.
+	client := m.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		tflog.Error(ctx, "Failed to auto-detect custom app URL", map[string]any{
+		tflog.Error(ctx, "Failed to fetch organization details", map[string]any{
 			"status_code": resp.StatusCode,
 			"body":        string(body),
 		})
-		return "", errors.New("failed fetching organization details")
+		return nil, errors.New("failed fetching organization details")
 	}
 
 	var content map[string]any
 	if err := json.NewDecoder(resp.Body).Decode(&content); err != nil {
-		return "", err
+		return nil, err
 	}
-
-	if site, ok := content["url"].(string); ok {
-		return site, nil
-	}
-	// Failover to the provided value
-	return m.CustomAppURL, nil
+	return content, nil
 }
@@ -4,10 +4,12 @@
 package pmeta
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
-	"io"
+	"fmt"
 	"os"
 	"os/user"
 	"path"
@@ -18,6 +20,14 @@ import (
 	"github.com/mitchellh/go-homedir"
 )
 
+// DefaultProfile is used from provider configuration files
+// that define profiles when no profile has been selected.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned when the selected profile
+// is not defined by a provider configuration file.
+var ErrProfileNotFound = errors.New("profile not defined")
+
 // MetaLookupFunc allows for unified type to preconfigure the
 // the state and allow it to be easer to ensure providers are working as expected.
 type MetaLookupFunc func(ctx context.Context, s *Meta) error
@@ -44,7 +54,7 @@ func FileMetaLookupFunc(path string) MetaLookupFunc {
 			"path": path,
 		})
 
-		f, err := os.Open(path)
+		content, err := os.ReadFile(path)
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				err = errors.New("file not found")
@@ -54,18 +64,65 @@ func FileMetaLookupFunc(path string) MetaLookupFunc {
 
 		tflog.Debug(ctx, "Reading file content")
 
-		dec := json.NewDecoder(f)
-		dec.DisallowUnknownFields()
+		return s.decodeConfigFile(content, true)
+	}
+}
 
-		if err = dec.Decode(s); err != nil {
-			if errors.Is(err, io.EOF) {
-				err = errors.New("no file content")
-			}
-			return errors.Join(err, f.Close())
+// DecodeConfigFile sets the values from the content of a provider configuration file,
+// any unknown fields within the content are ignored.
+//
+// The content is either a single set of values, or a set of named profiles
+// in the form of `{"profiles": {"<name>": {...}}}`. Values set alongside the
+// profiles are shared by all of them, and are overridden by the selected profile.
+// The `default` profile is used when no profile has been selected.
+func (m *Meta) DecodeConfigFile(content []byte) error {
+	return m.decodeConfigFile(content, false)
+}
+
+func (m *Meta) decodeConfigFile(content []byte, strict bool) error {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return errors.New("no file content")
+	}
+
+	var shared map[string]json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(&shared); err != nil {
+		return err
+	}
+
+	var profiles map[string]json.RawMessage
+	if raw, ok := shared["profiles"]; ok {
+		if err := json.Unmarshal(raw, &profiles); err != nil {
+			return errors.New("profiles must be an object of named profiles")
 		}
+		delete(shared, "profiles")
+	}
+
+	profile, ok := profiles[cmp.Or(m.Profile, DefaultProfile)]
+	if !ok && m.Profile != "" {
+		return fmt.Errorf("%w: %q", ErrProfileNotFound, m.Profile)
+	}
 
-		return f.Close()
+	values, err := json.Marshal(shared)
+	if err != nil {
+		return err
 	}
+	for _, v := range []json.RawMessage{values, profile} {
+		if v == nil {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(v))
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+	}
+
+	if ok && m.Profile != "" {
+		m.profileLoaded = true
+	}
+	return nil
 }
 
 func UserMetaLookupFunc(current func() (*user.User, error)) MetaLookupFunc {
@@ -78,6 +135,34 @@ func UserMetaLookupFunc(current func() (*user.User, error)) MetaLookupFunc {
 	}
 }
 
+// CredentialProcessMetaLookupFunc runs the configured credential process,
+// the credentials it returns take precedence over those read from files.
+// Nothing is done when a credential process has not been configured.
+// The credentials are shared with every provider configured with the same command.
+//
+// It is not one of the default lookups since a failing credential process
+// must stop the provider from being configured.
+func CredentialProcessMetaLookupFunc() MetaLookupFunc {
+	return func(ctx context.Context, s *Meta) error {
+		if s.CredentialProcess == "" {
+			return nil
+		}
+
+		cp := GetCredentialProcess(s.CredentialProcess)
+		creds, err := cp.Credentials(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.Credentials = cp
+		s.AuthToken = creds.AuthToken
+		if creds.APIURL != "" {
+			s.APIURL = creds.APIURL
+		}
+		return nil
+	}
+}
+
 func NetrcMetaLookupFunc(path string) MetaLookupFunc {
 	return func(ctx context.Context, s *Meta) error {
 		if path == "" {
@@ -104,12 +189,15 @@ func NetrcMetaLookupFunc(path string) MetaLookupFunc {
 			"path": path,
 		})
 
-		m, err := netrc.FindMachine(path, "api.signalfx.com")
+		rc, err := netrc.ParseFile(path)
 		if err != nil {
 			return err
 		}
-		if m != nil && !m.IsDefault() {
-			s.AuthToken = m.Password
+		for _, name := range s.NetrcMachines() {
+			if m := rc.FindMachine(name); m != nil && !m.IsDefault() {
+				s.AuthToken = m.Password
+				return nil
+			}
 		}
 		return nil
 	}
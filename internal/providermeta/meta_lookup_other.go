@@ -5,4 +5,14 @@
 
 package pmeta
 
+import (
+	"context"
+	"os/exec"
+)
+
 const NetrcFile = ".netrc"
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	//nolint:gosec // G204: The credential process is a command configured by the user to be run.
+	return exec.CommandContext(ctx, "/bin/sh", "-c", command)
+}
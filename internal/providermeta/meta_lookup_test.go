@@ -71,6 +71,7 @@ func TestFileProviderLookup(t *testing.T) {
 
 	for _, tc := range []struct {
 		name    string
+		profile string
 		create  bool
 		content string
 		expect  Meta
@@ -118,6 +119,59 @@ func TestFileProviderLookup(t *testing.T) {
 			expect:  Meta{AuthToken: "aaa"},
 			errVal:  "",
 		},
+		{
+			name:    "flat json with profile selected",
+			profile: "prod",
+			create:  true,
+			content: `{"auth_token":"aaa"}`,
+			expect:  Meta{Profile: "prod"},
+			errVal:  "profile not defined: \"prod\"",
+		},
+		{
+			name:    "profiles, none selected",
+			create:  true,
+			content: `{"api_url":"https://api.us1.signalfx.com","profiles":{"default":{"auth_token":"aaa"},"prod":{"auth_token":"bbb"}}}`,
+			expect:  Meta{APIURL: "https://api.us1.signalfx.com", AuthToken: "aaa"},
+			errVal:  "",
+		},
+		{
+			name:    "profiles, none selected without default",
+			create:  true,
+			content: `{"api_url":"https://api.us1.signalfx.com","profiles":{"prod":{"auth_token":"bbb"}}}`,
+			expect:  Meta{APIURL: "https://api.us1.signalfx.com"},
+			errVal:  "",
+		},
+		{
+			name:    "profiles, selected",
+			profile: "prod",
+			create:  true,
+			content: `{"api_url":"https://api.us1.signalfx.com","profiles":{"default":{"auth_token":"aaa"},"prod":{"auth_token":"bbb","realm":"eu0","api_url":"https://api.eu0.signalfx.com"}}}`,
+			expect:  Meta{Profile: "prod", profileLoaded: true, APIURL: "https://api.eu0.signalfx.com", Realm: "eu0", AuthToken: "bbb"},
+			errVal:  "",
+		},
+		{
+			name:    "profiles, selected profile missing",
+			profile: "staging",
+			create:  true,
+			content: `{"api_url":"https://api.us1.signalfx.com","profiles":{"prod":{"auth_token":"bbb"}}}`,
+			expect:  Meta{Profile: "staging"},
+			errVal:  "profile not defined: \"staging\"",
+		},
+		{
+			name:    "profiles, unknown field in profile",
+			profile: "prod",
+			create:  true,
+			content: `{"profiles":{"prod":{"token":"bbb"}}}`,
+			expect:  Meta{Profile: "prod"},
+			errVal:  "json: unknown field \"token\"",
+		},
+		{
+			name:    "profiles, invalid value",
+			create:  true,
+			content: `{"profiles":["prod"]}`,
+			expect:  Meta{},
+			errVal:  "profiles must be an object of named profiles",
+		},
 	} {
 
 		t.Run(tc.name, func(t *testing.T) {
@@ -131,7 +185,7 @@ func TestFileProviderLookup(t *testing.T) {
 				require.NoError(t, f.Close(), "Must not error closing file")
 			}
 
-			var actual Meta
+			actual := Meta{Profile: tc.profile}
 			if err := FileMetaLookupFunc(path).Do(context.Background(), &actual); tc.errVal != "" {
 				require.EqualError(t, err, tc.errVal, "Must match the expected error value")
 			} else {
@@ -142,6 +196,21 @@ func TestFileProviderLookup(t *testing.T) {
 	}
 }
 
+func TestMetaDecodeConfigFile(t *testing.T) {
+	t.Parallel()
+
+	m := Meta{Profile: "prod"}
+	err := m.DecodeConfigFile([]byte(`{"useless_config":"foo","profiles":{"prod":{"auth_token":"aaa","useless_config":"bar"}}}`))
+	require.NoError(t, err, "Must ignore unknown fields")
+	assert.Equal(t, "aaa", m.AuthToken, "Must read the selected profile")
+	assert.True(t, m.ProfileLoaded(), "Must report the profile was loaded")
+
+	m = Meta{Profile: "staging"}
+	err = m.DecodeConfigFile([]byte(`{"profiles":{"prod":{"auth_token":"aaa"}}}`))
+	assert.ErrorIs(t, err, ErrProfileNotFound, "Must report the profile is missing")
+	assert.False(t, m.ProfileLoaded(), "Must not report the profile was loaded")
+}
+
 func TestUserFileMetaLookup(t *testing.T) {
 	t.Parallel()
 
@@ -210,6 +279,7 @@ func TestNetrcMetaProvider(t *testing.T) {
 
 	for _, tc := range []struct {
 		name    string
+		realm   string
 		newFile func(t *testing.T) (path string)
 		expect  Meta
 		errVal  string
@@ -265,11 +335,45 @@ func TestNetrcMetaProvider(t *testing.T) {
 			},
 			errVal: "",
 		},
+		{
+			name:  "file exist, realm auth defined",
+			realm: "eu0",
+			newFile: func(t *testing.T) (path string) {
+				p := filepath.Join(t.TempDir(), NetrcFile)
+				f, err := os.Create(p)
+				require.NoError(t, err, "Must not error when creating file")
+				_, _ = fmt.Fprintln(f, "machine api.signalfx.com login user1 password secret")
+				_, _ = fmt.Fprintln(f, "machine api.eu0.signalfx.com login user1 password realm-secret")
+				require.NoError(t, f.Close(), "Must not error closing file")
+				return p
+			},
+			expect: Meta{
+				Realm:     "eu0",
+				AuthToken: "realm-secret",
+			},
+			errVal: "",
+		},
+		{
+			name:  "file exist, only legacy auth defined for realm",
+			realm: "eu0",
+			newFile: func(t *testing.T) (path string) {
+				p := filepath.Join(t.TempDir(), NetrcFile)
+				f, err := os.Create(p)
+				require.NoError(t, err, "Must not error when creating file")
+				_, _ = fmt.Fprintln(f, "machine api.signalfx.com login user1 password secret")
+				require.NoError(t, f.Close(), "Must not error closing file")
+				return p
+			},
+			expect: Meta{
+				Realm: "eu0",
+			},
+			errVal: "",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			var state Meta
+			state := Meta{Realm: tc.realm}
 			if err := NetrcMetaLookupFunc(tc.newFile(t)).Do(context.Background(), &state); tc.errVal != "" {
 				require.EqualError(t, err, tc.errVal, "Must match the expected error")
 			} else {
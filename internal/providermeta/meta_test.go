@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/signalfx/signalfx-go/sessiontoken"
@@ -263,6 +264,55 @@ func TestMetaToken(t *testing.T) {
 	}
 }
 
+func TestMetaDeleteSessionToken(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+		errVal  string
+	}{
+		{
+			name: "token deleted",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				_ = r.Body.Close()
+
+				if r.Method != http.MethodDelete || r.Header.Get("X-SF-Token") != "session" {
+					http.Error(w, "unexpected request", http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			},
+			errVal: "",
+		},
+		{
+			name: "token already expired",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				_ = r.Body.Close()
+
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			},
+			errVal: "route \"/v2/session\" had issues with status code 401",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := httptest.NewServer(tc.handler)
+			t.Cleanup(s.Close)
+
+			m := &Meta{APIURL: s.URL}
+
+			if err := m.DeleteSessionToken(context.Background(), "session"); tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected value")
+			} else {
+				assert.NoError(t, err, "Must not error")
+			}
+		})
+	}
+}
+
 func TestLoadProviderTags(t *testing.T) {
 	t.Parallel()
 
@@ -327,6 +377,57 @@ func TestLoadProviderTags(t *testing.T) {
 	}
 }
 
+func TestLoadProviderTeams(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   any
+		expect []string
+	}{
+		{
+			name:   "no provider set",
+			meta:   nil,
+			expect: nil,
+		},
+		{
+			name: "preview not enabled",
+			meta: &Meta{
+				Registry: func() *feature.Registry {
+					r := feature.NewRegistry()
+					_ = r.MustRegister(feature.PreviewProviderTeams)
+					return r
+				}(),
+				Teams: []string{"team-00", "team-01"},
+			},
+			expect: nil,
+		},
+		{
+			name: "preview enabled",
+			meta: &Meta{
+				Registry: func() *feature.Registry {
+					r := feature.NewRegistry()
+					_ = r.MustRegister(feature.PreviewProviderTeams, feature.WithPreviewGlobalAvailable())
+					return r
+				}(),
+				Teams: []string{"team-00", "team-01"},
+			},
+			expect: []string{"team-00", "team-01"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(
+				t,
+				tc.expect,
+				LoadProviderTeams(t.Context(), tc.meta),
+				"Must match the expected teams",
+			)
+		})
+	}
+}
+
 func TestMergeProviderTeams(t *testing.T) {
 	t.Parallel()
 
@@ -420,6 +521,7 @@ func TestDetectCustomAPPURL(t *testing.T) {
 				_ = r.Body.Close()
 
 				_ = json.NewEncoder(w).Encode(map[string]any{
+					"id":  "org-id",
 					"url": "https://custom.signalfx.com",
 				})
 			},
@@ -471,3 +573,134 @@ func TestMetaDetectorCustomAppURL_EdgeCases(t *testing.T) {
 		assert.Error(t, err, "Must return an error when api URL is invalid")
 	})
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func TestMetaLookupOrganizationID(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":  "org-id",
+			"url": "https://custom.signalfx.com",
+		})
+	}))
+	t.Cleanup(s.Close)
+
+	var transported atomic.Int32
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		transported.Add(1)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	m := &Meta{APIURL: s.URL, CustomAppURL: "https://app.signalfx.com", HTTPClient: client}
+	orgID, err := m.LookupOrganizationID(t.Context())
+	assert.NoError(t, err, "Must not error looking up the organization")
+	assert.Equal(t, "org-id", orgID, "Must return the organization ID")
+	assert.Equal(t, int32(1), transported.Load(), "Must use the configured HTTP client")
+
+	orgID, err = m.LookupOrganizationID(t.Context())
+	assert.NoError(t, err, "Must not error looking up the organization again")
+	assert.Equal(t, "org-id", orgID, "Must return the same organization ID")
+
+	domain, err := m.DetectCustomAPPURL(t.Context())
+	assert.NoError(t, err, "Must not error detecting the app URL")
+	assert.Equal(t, "https://custom.signalfx.com", domain, "Must match the organization app URL")
+	assert.Equal(t, int32(1), requests.Load(), "Must only fetch the organization details once")
+
+	configured := &Meta{APIURL: s.URL, OrganizationID: "configured-id"}
+	orgID, err = configured.LookupOrganizationID(t.Context())
+	assert.NoError(t, err, "Must not error with a configured organization")
+	assert.Equal(t, "configured-id", orgID, "Must return the configured organization ID")
+	assert.Equal(t, int32(1), requests.Load(), "Must not fetch the organization details when configured")
+
+	failed := &Meta{APIURL: "http://[::1]:namedport"}
+	orgID, err = failed.LookupOrganizationID(t.Context())
+	assert.Error(t, err, "Must return the error fetching the organization")
+	assert.Empty(t, orgID, "Must not return an organization ID")
+
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+	retried := &Meta{APIURL: s.URL, AuthToken: "retried"}
+	_, err = retried.LookupOrganizationID(canceled)
+	assert.Error(t, err, "Must return the error when the context has ended")
+	orgID, err = retried.LookupOrganizationID(t.Context())
+	assert.NoError(t, err, "Must fetch the organization once the context is valid")
+	assert.Equal(t, "org-id", orgID, "Must return the organization ID")
+}
+
+func TestMetaLoadRealm(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		realm  string
+		apiURL string
+		expect string
+	}{
+		{name: "legacy domain", apiURL: "https://api.signalfx.com", expect: "us0"},
+		{name: "signalfx domain", apiURL: "https://api.eu0.signalfx.com", expect: "eu0"},
+		{name: "splunk cloud domain", apiURL: "https://api.us1.observability.splunkcloud.com", expect: "us1"},
+		{name: "custom domain", apiURL: "https://api.example.com", expect: ""},
+		{name: "local server", apiURL: "http://127.0.0.1:8080", expect: ""},
+		{name: "invalid url", apiURL: "\tinvalid", expect: ""},
+		{name: "realm set", realm: "jp0", apiURL: "", expect: "jp0"},
+		{name: "realm set with api url", realm: "jp0", apiURL: "https://api.eu0.signalfx.com", expect: "jp0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Meta{Realm: tc.realm, APIURL: tc.apiURL}
+			assert.Equal(t, tc.expect, m.LoadRealm(), "Must match the expected realm")
+		})
+	}
+}
+
+func TestRealmURLs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://api.eu0.signalfx.com", RealmAPIURL("eu0"), "Must match the realm API URL")
+	assert.Equal(t, "https://app.eu0.signalfx.com", RealmAppURL("eu0"), "Must match the realm application URL")
+
+	m := &Meta{APIURL: RealmAPIURL("eu0")}
+	assert.Equal(t, "eu0", m.LoadRealm(), "Must derive the realm from its API URL")
+}
+
+func TestRealmPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, realm := range []string{"us0", "eu0", "us2-gcp"} {
+		assert.True(t, RealmPattern.MatchString(realm), "Must accept realm %q", realm)
+	}
+	for _, realm := range []string{"", "US0", "eu0.", "api.us0", "-us0", "us0/"} {
+		assert.False(t, RealmPattern.MatchString(realm), "Must reject realm %q", realm)
+	}
+}
+
+func TestMetaNetrcMachines(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   Meta
+		expect []string
+	}{
+		{name: "nothing set", meta: Meta{}, expect: []string{"api.signalfx.com"}},
+		{name: "custom domain", meta: Meta{APIURL: "https://api.example.com"}, expect: []string{"api.signalfx.com"}},
+		{name: "legacy domain", meta: Meta{APIURL: "https://api.signalfx.com"}, expect: []string{"api.us0.signalfx.com", "api.signalfx.com"}},
+		{name: "realm api url", meta: Meta{APIURL: "https://api.eu0.signalfx.com"}, expect: []string{"api.eu0.signalfx.com"}},
+		{name: "realm set", meta: Meta{Realm: "jp0"}, expect: []string{"api.jp0.signalfx.com"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, tc.meta.NetrcMachines(), "Must match the expected machines")
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+// The kinds of object that a notification can be sent to.
+const (
+	NotificationTargetIntegration = "integration"
+	NotificationTargetTeam        = "team"
+)
+
+// NotificationTargets looks up the integrations and teams that notifications are sent to.
+//
+// The result of each lookup is kept for the lifetime of the provider,
+// so each target is only requested once within a plan.
+type NotificationTargets struct {
+	mu    sync.Mutex
+	found map[string]bool
+}
+
+// NewNotificationTargets returns a [NotificationTargets] without any lookups.
+func NewNotificationTargets() *NotificationTargets {
+	return &NotificationTargets{
+		found: make(map[string]bool),
+	}
+}
+
+// Exists reports if the target of the kind exists.
+// Any error other than the target not being found is returned, and it is not kept
+// so the target is requested again by the next lookup.
+func (nt *NotificationTargets) Exists(ctx context.Context, client *signalfx.Client, kind, id string) (bool, error) {
+	key := kind + "/" + id
+
+	nt.mu.Lock()
+	found, ok := nt.found[key]
+	nt.mu.Unlock()
+	if ok {
+		return found, nil
+	}
+
+	var err error
+	switch kind {
+	case NotificationTargetTeam:
+		_, err = client.GetTeam(ctx, id)
+	default:
+		_, err = client.GetIntegration(ctx, id)
+	}
+	if re, ok := signalfx.AsResponseError(err); ok && re.Code() == http.StatusNotFound {
+		err = nil
+	} else if err != nil {
+		return false, err
+	} else {
+		found = true
+	}
+
+	nt.mu.Lock()
+	nt.found[key] = found
+	nt.mu.Unlock()
+
+	return found, nil
+}
+
+// LoadNotificationTargets returns the lookups of the notification targets,
+// nil is returned when they are not checked.
+//
+// Requires preview to be enabled in order to return values.
+func LoadNotificationTargets(ctx context.Context, meta any) *NotificationTargets {
+	if g, ok := LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderVerifyNotifications); !ok || !g.Enabled() {
+		tflog.Debug(
+			ctx,
+			"Feature Preview is not enabled, using default value",
+			feature.NewPreviewLogFields(feature.PreviewProviderVerifyNotifications, g),
+		)
+		return nil
+	}
+
+	if m, ok := meta.(*Meta); ok {
+		return m.NotificationTargets
+	}
+
+	return nil
+}
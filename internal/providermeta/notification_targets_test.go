@@ -0,0 +1,69 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+func TestNotificationTargetsExists(t *testing.T) {
+	t.Parallel()
+
+	s := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			http.Error(w, "not found", http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/broken"):
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	client, err := signalfx.NewClient("token", signalfx.HTTPClient(s.Client()), signalfx.APIUrl(s.URL))
+	require.NoError(t, err, "Must create the client")
+
+	nt := NewNotificationTargets()
+	for range 3 {
+		found, err := nt.Exists(t.Context(), client, NotificationTargetIntegration, "AAAA")
+		assert.NoError(t, err, "Must not error looking up the integration")
+		assert.True(t, found, "Must find the integration")
+
+		found, err = nt.Exists(t.Context(), client, NotificationTargetTeam, "missing")
+		assert.NoError(t, err, "Must not error looking up a missing team")
+		assert.False(t, found, "Must not find the team")
+
+		_, err = nt.Exists(t.Context(), client, NotificationTargetIntegration, "broken")
+		assert.Error(t, err, "Must return the error looking up the integration")
+	}
+
+	assert.Equal(t, 1, s.Requests("GET /v2/integration/AAAA"), "Must only look up the integration once")
+	assert.Equal(t, 1, s.Requests("GET /v2/team/missing"), "Must only look up the missing team once")
+	assert.Equal(t, 3, s.Requests("GET /v2/integration/broken"), "Must not keep failed lookups")
+}
+
+func TestLoadNotificationTargets(t *testing.T) {
+	t.Parallel()
+
+	newMeta := func(enabled bool) *Meta {
+		r := feature.NewRegistry()
+		if enabled {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications, feature.WithPreviewGlobalAvailable())
+		} else {
+			_ = r.MustRegister(feature.PreviewProviderVerifyNotifications)
+		}
+		return &Meta{Registry: r, NotificationTargets: NewNotificationTargets()}
+	}
+
+	assert.Nil(t, LoadNotificationTargets(t.Context(), nil), "Must not return targets without a provider")
+	assert.Nil(t, LoadNotificationTargets(t.Context(), newMeta(false)), "Must not return targets when the preview is not enabled")
+	assert.NotNil(t, LoadNotificationTargets(t.Context(), newMeta(true)), "Must return targets when the preview is enabled")
+}
@@ -0,0 +1,203 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go"
+	"go.uber.org/multierr"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+var (
+	sessionsMu sync.Mutex
+	sessions   []*SessionTokenSource
+)
+
+// SessionTokenSource provides the session token used when the provider
+// is configured with an email and password instead of an auth token.
+//
+// Session tokens expire, so applies that run longer than the token lifetime
+// would otherwise fail every request once it has. The token is replaced
+// when the API rejects it, and the rejected request is sent once more with the new token.
+type SessionTokenSource struct {
+	create func(ctx context.Context) (string, error)
+	delete func(ctx context.Context, token string) error
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewSessionTokenSource returns a source that creates session tokens using the email and password of m.
+// The source is tracked so that its token is deleted by [CloseSessionTokens].
+func NewSessionTokenSource(m *Meta) *SessionTokenSource {
+	sts := &SessionTokenSource{
+		create: func(ctx context.Context) (string, error) {
+			token, err := m.CreateSessionToken(ctx)
+			if err != nil {
+				return "", err
+			}
+			return token.AccessToken, nil
+		},
+		delete: m.DeleteSessionToken,
+	}
+
+	sessionsMu.Lock()
+	sessions = append(sessions, sts)
+	sessionsMu.Unlock()
+
+	return sts
+}
+
+// Token returns the current session token, creating one if there is none.
+func (sts *SessionTokenSource) Token(ctx context.Context) (string, error) {
+	sts.mu.Lock()
+	defer sts.mu.Unlock()
+
+	if sts.token != "" {
+		return sts.token, nil
+	}
+	token, err := sts.create(ctx)
+	if err != nil {
+		return "", err
+	}
+	sts.token = token
+	return token, nil
+}
+
+// refresh replaces the rejected token with a new session token, unless
+// a concurrent request has already replaced it.
+func (sts *SessionTokenSource) refresh(ctx context.Context, rejected string) (string, error) {
+	sts.mu.Lock()
+	defer sts.mu.Unlock()
+
+	if sts.token != rejected {
+		return sts.token, nil
+	}
+
+	tflog.Info(ctx, "Session token was rejected, creating a new session token")
+
+	token, err := sts.create(ctx)
+	if err != nil {
+		return "", err
+	}
+	sts.token = token
+	return token, nil
+}
+
+// Close deletes the current session token so it can no longer be used.
+func (sts *SessionTokenSource) Close(ctx context.Context) error {
+	sts.mu.Lock()
+	defer sts.mu.Unlock()
+
+	if sts.token == "" {
+		return nil
+	}
+	token := sts.token
+	sts.token = ""
+	return sts.delete(ctx, token)
+}
+
+// CloseSessionTokens deletes the session tokens created by every source
+// within the process, it is called once the provider has stopped serving.
+func CloseSessionTokens(ctx context.Context) (errs error) {
+	sessionsMu.Lock()
+	closing := slices.Clone(sessions)
+	sessions = nil
+	sessionsMu.Unlock()
+
+	for _, sts := range closing {
+		errs = multierr.Append(errs, sts.Close(ctx))
+	}
+	return errs
+}
+
+// Transport returns a [http.RoundTripper] that sets the session token of each request,
+// base is returned when sts is nil.
+//
+// It must wrap any transport that depends on the auth token, such as the [ResponseCache].
+func (sts *SessionTokenSource) Transport(base http.RoundTripper) http.RoundTripper {
+	if sts == nil {
+		return base
+	}
+	return &sessionTransport{source: sts, base: base}
+}
+
+type skipSessionKey struct{}
+
+// withoutSessionToken marks the requests made using ctx as providing their own token,
+// such as the requests that create and delete session tokens, so they are passed
+// through the session transport unchanged.
+func withoutSessionToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipSessionKey{}, true)
+}
+
+type sessionTransport struct {
+	source *SessionTokenSource
+	base   http.RoundTripper
+}
+
+func (st *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if skip, _ := ctx.Value(skipSessionKey{}).(bool); skip {
+		return st.base.RoundTrip(req)
+	}
+
+	token, err := st.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The body is kept so the request can be sent again with a new token.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		content, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(ctx)
+		req.Body = io.NopCloser(bytes.NewReader(content))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		}
+	}
+
+	resp, err := st.base.RoundTrip(withAuthToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	token, err = st.source.refresh(ctx, token)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to replace the rejected session token", tfext.ErrorLogFields(err))
+		return resp, nil
+	}
+
+	retry := withAuthToken(req, token)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return st.base.RoundTrip(retry)
+}
+
+func withAuthToken(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set(signalfx.AuthHeaderKey, token)
+	return req
+}
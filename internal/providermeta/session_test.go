@@ -0,0 +1,195 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionAPI is a stand in for the API that creates session tokens,
+// and only accepts the most recently created token.
+type sessionAPI struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	created int
+	deleted []string
+	bodies  []string
+}
+
+func newSessionAPI(t *testing.T) *sessionAPI {
+	api := &sessionAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/v2/session" && r.Method == http.MethodPost:
+			api.created++
+			//nolint:gosec // G117: The access token is synthetic data returned by this test-only HTTP server.
+			_ = json.NewEncoder(w).Encode(&sessiontoken.Token{AccessToken: fmt.Sprint("session-", api.created)})
+		case r.URL.Path == "/v2/session" && r.Method == http.MethodDelete:
+			api.deleted = append(api.deleted, r.Header.Get(signalfx.AuthHeaderKey))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Header.Get(signalfx.AuthHeaderKey) != fmt.Sprint("session-", api.created):
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		default:
+			api.bodies = append(api.bodies, string(body))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// expire creates a new session token so that the current one is rejected.
+func (api *sessionAPI) expire() {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.created++
+}
+
+func TestSessionTokenSourceRefresh(t *testing.T) {
+	t.Parallel()
+
+	api := newSessionAPI(t)
+	m := &Meta{APIURL: api.URL, Email: "user@example", Password: "notsosecret"}
+
+	token, err := m.LoadSessionToken(context.Background())
+	require.NoError(t, err, "Must not error creating session token")
+	assert.Equal(t, "session-1", token, "Must return the created session token")
+	require.NotNil(t, m.Session, "Must set the session token source")
+
+	token, err = m.LoadSessionToken(context.Background())
+	require.NoError(t, err, "Must not error loading session token")
+	assert.Equal(t, "session-1", token, "Must reuse the session token")
+
+	client := &http.Client{Transport: m.Session.Transport(http.DefaultTransport)}
+	send := func(body string) int {
+		// The body is wrapped so that it can not be read again by the request.
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, api.URL+"/v2/detector", io.NopCloser(strings.NewReader(body)))
+		require.NoError(t, err, "Must not error creating request")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err, "Must not error sending request")
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, send("first"), "Must accept the session token")
+
+	api.expire()
+	assert.Equal(t, http.StatusOK, send("second"), "Must replay the request with a new session token")
+	assert.Equal(t, http.StatusOK, send("third"), "Must keep using the new session token")
+
+	api.mu.Lock()
+	assert.Equal(t, 3, api.created, "Must only create one more session token")
+	assert.Equal(t, []string{"first", "second", "third"}, api.bodies, "Must send the request body with the replayed request")
+	api.mu.Unlock()
+
+	require.NoError(t, m.Session.Close(context.Background()), "Must not error deleting session token")
+	require.NoError(t, m.Session.Close(context.Background()), "Must not error once the token has been deleted")
+
+	api.mu.Lock()
+	assert.Equal(t, []string{"session-3"}, api.deleted, "Must delete the current session token once")
+	api.mu.Unlock()
+}
+
+func TestSessionTokenSourceProviderTransport(t *testing.T) {
+	t.Parallel()
+
+	api := newSessionAPI(t)
+	m := &Meta{APIURL: api.URL, Email: "user@example", Password: "notsosecret"}
+
+	var (
+		mu       sync.Mutex
+		sessions []string
+	)
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/v2/session" {
+			mu.Lock()
+			sessions = append(sessions, r.Method+" "+r.Header.Get(signalfx.AuthHeaderKey))
+			mu.Unlock()
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	m.HTTPClient = &http.Client{Transport: m.LoadSessionSource().Transport(base)}
+
+	token, err := m.LoadSessionToken(context.Background())
+	require.NoError(t, err, "Must not error creating session token")
+	assert.Equal(t, "session-1", token, "Must return the created session token")
+
+	api.expire()
+	resp, err := m.HTTPClient.Get(api.URL + "/v2/detector")
+	require.NoError(t, err, "Must not error sending request")
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Must replay the request with a new session token")
+
+	require.NoError(t, m.Session.Close(context.Background()), "Must not error deleting session token")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"POST ", "POST ", "DELETE session-3"}, sessions, "Must manage session tokens using the provider transport")
+}
+
+func TestSessionTokenSourceRefreshFailed(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	t.Cleanup(s.Close)
+
+	sts := &SessionTokenSource{
+		token: "expired",
+		create: func(context.Context) (string, error) {
+			return "", errors.New("account locked")
+		},
+	}
+
+	resp, err := (&http.Client{Transport: sts.Transport(http.DefaultTransport)}).Get(s.URL)
+	require.NoError(t, err, "Must not error when the token can not be replaced")
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Must return the rejected response")
+}
+
+func TestSessionTokenSourceNilTransport(t *testing.T) {
+	t.Parallel()
+
+	var sts *SessionTokenSource
+	assert.Equal(t, http.DefaultTransport, sts.Transport(http.DefaultTransport), "Must return the base transport")
+}
+
+func TestCloseSessionTokens(t *testing.T) {
+	api := newSessionAPI(t)
+	m := &Meta{APIURL: api.URL, Email: "user@example", Password: "notsosecret"}
+
+	_, err := m.LoadSessionToken(context.Background())
+	require.NoError(t, err, "Must not error creating session token")
+
+	require.NoError(t, CloseSessionTokens(context.Background()), "Must not error deleting session tokens")
+	require.NoError(t, CloseSessionTokens(context.Background()), "Must not error when there are no session tokens")
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.Equal(t, []string{"session-1"}, api.deleted, "Must delete the session token at shutdown")
+}
@@ -0,0 +1,272 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// TransportLimits configures how requests are sent through a [RateLimitTransport].
+type TransportLimits struct {
+	// RequestsPerSecond is the rate of the client side token bucket,
+	// a value of zero or less does not limit the rate.
+	RequestsPerSecond float64
+	// MaxConcurrentRequests is the number of requests that can be in flight at once,
+	// a value of zero or less does not limit concurrency.
+	MaxConcurrentRequests int
+	// MaxRetryWait bounds how long a `Retry-After` value returned by the API pauses requests.
+	MaxRetryWait time.Duration
+}
+
+var (
+	requestLimitsOnce sync.Once
+	requestLimits     *RequestLimits
+)
+
+// GetRequestLimits returns the limits shared by every provider served within the process,
+// so requests made by either provider draw from the same budget and a throttled
+// response seen by one pauses the requests of the other.
+func GetRequestLimits() *RequestLimits {
+	requestLimitsOnce.Do(func() {
+		requestLimits = NewRequestLimits(TransportLimits{})
+	})
+	return requestLimits
+}
+
+// RequestLimits holds the rate, concurrency and throttled state used by [RateLimitTransport],
+// the transports created from the same limits share one budget.
+type RequestLimits struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+	slots   chan struct{}
+	maxWait time.Duration
+	resume  time.Time
+}
+
+// NewRequestLimits returns limits that are not shared with other providers.
+func NewRequestLimits(limits TransportLimits) *RequestLimits {
+	rl := &RequestLimits{}
+	rl.Configure(limits)
+	return rl
+}
+
+// Configure updates the limits, requests already holding or waiting
+// for a concurrent request slot keep the previous limit.
+func (rl *RequestLimits) Configure(limits TransportLimits) {
+	limit, burst := rate.Inf, 1
+	if rps := limits.RequestsPerSecond; rps > 0 {
+		limit, burst = rate.Limit(rps), max(1, int(math.Floor(rps)))
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// The limiter is only replaced when the rate changes so that
+	// configuring the other provider with the same values keeps the current budget.
+	if rl.limiter == nil || rl.limiter.Limit() != limit || rl.limiter.Burst() != burst {
+		rl.limiter = rate.NewLimiter(limit, burst)
+	}
+	rl.maxWait = limits.MaxRetryWait
+	switch {
+	case limits.MaxConcurrentRequests <= 0:
+		rl.slots = nil
+	case rl.slots == nil || cap(rl.slots) != limits.MaxConcurrentRequests:
+		rl.slots = make(chan struct{}, limits.MaxConcurrentRequests)
+	}
+}
+
+// Transport wraps the base transport so requests are sent within the limits.
+func (rl *RequestLimits) Transport(base http.RoundTripper) *RateLimitTransport {
+	return &RateLimitTransport{base: base, limits: rl}
+}
+
+// RateLimitTransport limits the rate and concurrency of requests sent to the API,
+// and pauses every request once the API has responded with `429 Too Many Requests`
+// so that the organization quota is not exhausted further by concurrent requests.
+//
+// The providers create the transport from [GetRequestLimits], so every resource and data source
+// of both providers, including nested fan outs, draw from the same budget.
+type RateLimitTransport struct {
+	base   http.RoundTripper
+	limits *RequestLimits
+}
+
+var _ http.RoundTripper = (*RateLimitTransport)(nil)
+
+// NewRateLimitTransport wraps the base transport so requests are sent within limits
+// that are not shared with any other transport.
+func NewRateLimitTransport(base http.RoundTripper, limits TransportLimits) *RateLimitTransport {
+	return NewRequestLimits(limits).Transport(base)
+}
+
+func (rt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if wait := rt.limits.pause(); wait > 0 {
+		tflog.Debug(ctx, "Waiting for the API rate limit to reset", tfext.NewLogFields().
+			Duration("wait", wait),
+		)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	limiter := rt.limits.rate()
+	reserved := time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if waited := time.Since(reserved); waited > time.Second {
+		tflog.Debug(ctx, "Request delayed by the client rate limit", tfext.NewLogFields().
+			Duration("wait", waited).
+			Field("requests_per_second", float64(limiter.Limit())),
+		)
+	}
+
+	release, err := rt.limits.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The slot is held until the body is closed since the connection
+	// is in use by the request until then.
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	wait, ok := ParseRetryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		wait = time.Second
+	}
+	wait = rt.limits.throttled(wait)
+
+	tflog.Warn(ctx, "Request was throttled by the API", tfext.NewLogFields().
+		Field("method", req.Method).
+		Field("path", req.URL.Path).
+		Duration("retry_after", wait),
+	)
+
+	return resp, nil
+}
+
+// acquire waits for a free slot when concurrency is limited,
+// the returned function must be called once the request has completed.
+func (rl *RequestLimits) acquire(req *http.Request) (func(), error) {
+	rl.mu.Lock()
+	slots := rl.slots
+	rl.mu.Unlock()
+
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+	default:
+		start := time.Now()
+		select {
+		case slots <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if waited := time.Since(start); waited > time.Second {
+			tflog.Debug(req.Context(), "Request delayed by the concurrent request limit", tfext.NewLogFields().
+				Duration("wait", waited).
+				Field("max_concurrent_requests", cap(slots)),
+			)
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-slots })
+	}, nil
+}
+
+// throttled pauses all requests for the wait bounded by the maximum retry wait,
+// the bounded wait is returned.
+func (rl *RequestLimits) throttled(wait time.Duration) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	wait = min(wait, rl.maxWait)
+	if until := time.Now().Add(wait); until.After(rl.resume) {
+		rl.resume = until
+	}
+	return wait
+}
+
+// rate returns the limiter of the configured requests per second.
+func (rl *RequestLimits) rate() *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.limiter
+}
+
+// pause returns how long requests must wait before the API accepts requests again.
+func (rl *RequestLimits) pause() time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return time.Until(rl.resume)
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (rb *releaseBody) Close() error {
+	defer rb.release()
+	return rb.ReadCloser.Close()
+}
+
+// ParseRetryAfter reads the value of a `Retry-After` header
+// that is either a number of seconds or an HTTP date.
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(time.Until(at), 0), true
+}
+
+// RetryBackoff is used as the [retryablehttp.Backoff] by the providers,
+// it uses the `Retry-After` header when the API sets one and
+// exponential backoff otherwise, but never waits longer than waitmax.
+func RetryBackoff(waitmin, waitmax time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, waitmax)
+		}
+	}
+	return min(retryablehttp.DefaultBackoff(waitmin, waitmax, attempt, nil), waitmax)
+}
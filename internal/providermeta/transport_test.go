@@ -0,0 +1,373 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{name: "not set", value: "", wait: 0, ok: false},
+		{name: "seconds", value: "5", wait: 5 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", wait: 0, ok: false},
+		{name: "date in the past", value: "Fri, 31 Dec 1999 23:59:59 GMT", wait: 0, ok: true},
+		{name: "invalid value", value: "soon", wait: 0, ok: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			wait, ok := ParseRetryAfter(tc.value)
+			assert.Equal(t, tc.wait, wait, "Must match the expected wait")
+			assert.Equal(t, tc.ok, ok, "Must match the expected parse result")
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	newResponse := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	for _, tc := range []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		wait    time.Duration
+	}{
+		{name: "no response", attempt: 0, resp: nil, wait: time.Second},
+		{name: "exponential", attempt: 2, resp: newResponse(http.StatusInternalServerError, ""), wait: 4 * time.Second},
+		{name: "exponential bounded", attempt: 10, resp: newResponse(http.StatusBadGateway, ""), wait: 30 * time.Second},
+		{name: "rate limited", attempt: 0, resp: newResponse(http.StatusTooManyRequests, "7"), wait: 7 * time.Second},
+		{name: "unavailable", attempt: 0, resp: newResponse(http.StatusServiceUnavailable, "3"), wait: 3 * time.Second},
+		{name: "retry after bounded", attempt: 0, resp: newResponse(http.StatusTooManyRequests, "3600"), wait: 30 * time.Second},
+		{name: "retry after ignored", attempt: 1, resp: newResponse(http.StatusInternalServerError, "20"), wait: 2 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			wait := RetryBackoff(time.Second, 30*time.Second, tc.attempt, tc.resp)
+			assert.Equal(t, tc.wait, wait, "Must match the expected backoff")
+		})
+	}
+}
+
+func TestRateLimitTransportRequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	client := &http.Client{Transport: NewRateLimitTransport(s.Client().Transport, TransportLimits{
+		RequestsPerSecond: 20,
+		MaxRetryWait:      time.Minute,
+	})}
+
+	start := time.Now()
+	for range 30 {
+		resp, err := client.Get(s.URL)
+		require.NoError(t, err, "Must not error making request")
+		_ = resp.Body.Close()
+	}
+	// The first 20 requests are allowed by the burst,
+	// the remaining 10 are sent every 50ms.
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond, "Must limit the rate of requests")
+}
+
+func TestRateLimitTransportMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inflight, peak atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	client := &http.Client{Transport: NewRateLimitTransport(s.Client().Transport, TransportLimits{
+		MaxConcurrentRequests: 3,
+	})}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			resp, err := client.Get(s.URL)
+			if assert.NoError(t, err, "Must not error making request") {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), peak.Load(), "Must not exceed the concurrent request limit")
+}
+
+func TestRateLimitTransportSlotReleasedOnClose(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	client := &http.Client{Transport: NewRateLimitTransport(s.Client().Transport, TransportLimits{
+		MaxConcurrentRequests: 1,
+	})}
+
+	resp, err := client.Get(s.URL)
+	require.NoError(t, err, "Must not error making request")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, http.NoBody)
+	require.NoError(t, err, "Must not error creating request")
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must wait while the body is open")
+
+	require.NoError(t, resp.Body.Close(), "Must not error closing body")
+	require.NoError(t, resp.Body.Close(), "Must allow the body to be closed again")
+
+	resp, err = client.Get(s.URL)
+	require.NoError(t, err, "Must not error once the slot is released")
+	_ = resp.Body.Close()
+}
+
+func TestRateLimitTransportRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		maxWait time.Duration
+		minWait time.Duration
+		limit   time.Duration
+	}{
+		{
+			name:    "pauses until retry after",
+			maxWait: time.Minute,
+			minWait: time.Second,
+			limit:   5 * time.Second,
+		},
+		{
+			name:    "pause is bounded",
+			maxWait: 100 * time.Millisecond,
+			minWait: 100 * time.Millisecond,
+			limit:   time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			t.Cleanup(s.Close)
+
+			client := &http.Client{Transport: NewRateLimitTransport(s.Client().Transport, TransportLimits{
+				MaxRetryWait: tc.maxWait,
+			})}
+
+			resp, err := client.Get(s.URL)
+			require.NoError(t, err, "Must not error making request")
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "Must return the throttled response")
+
+			start := time.Now()
+			resp, err = client.Get(s.URL)
+			require.NoError(t, err, "Must not error making request")
+			_ = resp.Body.Close()
+
+			elapsed := time.Since(start)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Must return the response")
+			assert.GreaterOrEqual(t, elapsed, tc.minWait, "Must wait for the API to accept requests")
+			assert.Less(t, elapsed, tc.limit, "Must not wait longer than expected")
+		})
+	}
+}
+
+func TestRateLimitTransportCancelled(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(s.Close)
+
+	client := &http.Client{Transport: NewRateLimitTransport(s.Client().Transport, TransportLimits{
+		MaxRetryWait: time.Minute,
+	})}
+
+	resp, err := client.Get(s.URL)
+	require.NoError(t, err, "Must not error making request")
+	_ = resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, http.NoBody)
+	require.NoError(t, err, "Must not error creating request")
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must stop waiting once the request is cancelled")
+}
+
+func TestGetRequestLimits(t *testing.T) {
+	t.Parallel()
+
+	assert.Same(t, GetRequestLimits(), GetRequestLimits(), "Must return the limits shared within the process")
+}
+
+func TestRequestLimitsSharedRequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	limits := NewRequestLimits(TransportLimits{
+		RequestsPerSecond: 20,
+		MaxRetryWait:      time.Minute,
+	})
+	clients := []*http.Client{
+		{Transport: limits.Transport(s.Client().Transport)},
+		{Transport: limits.Transport(s.Client().Transport)},
+	}
+
+	start := time.Now()
+	for i := range 30 {
+		resp, err := clients[i%len(clients)].Get(s.URL)
+		require.NoError(t, err, "Must not error making request")
+		_ = resp.Body.Close()
+	}
+	// Both transports draw from the same burst of 20 requests,
+	// so the remaining 10 are sent every 50ms.
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond, "Must limit the rate of requests across transports")
+}
+
+func TestRequestLimitsSharedRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	throttled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(throttled.Close)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	limits := NewRequestLimits(TransportLimits{MaxRetryWait: time.Minute})
+	first := &http.Client{Transport: limits.Transport(throttled.Client().Transport)}
+	second := &http.Client{Transport: limits.Transport(s.Client().Transport)}
+
+	resp, err := first.Get(throttled.URL)
+	require.NoError(t, err, "Must not error making request")
+	_ = resp.Body.Close()
+
+	start := time.Now()
+	resp, err = second.Get(s.URL)
+	require.NoError(t, err, "Must not error making request")
+	_ = resp.Body.Close()
+
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "Must pause the requests of every transport")
+}
+
+func TestRequestLimitsConfigure(t *testing.T) {
+	t.Parallel()
+
+	limits := NewRequestLimits(TransportLimits{RequestsPerSecond: 1})
+	limiter := limits.rate()
+
+	limits.Configure(TransportLimits{RequestsPerSecond: 1})
+	assert.Same(t, limiter, limits.rate(), "Must keep the budget when configured with the same rate")
+
+	limits.Configure(TransportLimits{RequestsPerSecond: 20})
+	assert.Equal(t, 20, limits.rate().Burst(), "Must update the burst")
+
+	limits.Configure(TransportLimits{MaxConcurrentRequests: 2})
+	slots := limits.slots
+	assert.Equal(t, 2, cap(slots), "Must limit the concurrent requests")
+
+	limits.Configure(TransportLimits{MaxConcurrentRequests: 2})
+	assert.Equal(t, slots, limits.slots, "Must keep the slots when configured with the same limit")
+
+	limits.Configure(TransportLimits{})
+	assert.Equal(t, rate.Inf, limits.rate().Limit(), "Must not limit the rate once unset")
+	assert.Nil(t, limits.slots, "Must not limit the concurrent requests once unset")
+}
+
+func TestRequestLimitsSharedMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	limits := NewRequestLimits(TransportLimits{MaxConcurrentRequests: 1})
+	first := &http.Client{Transport: limits.Transport(s.Client().Transport)}
+	second := &http.Client{Transport: limits.Transport(s.Client().Transport)}
+
+	resp, err := first.Get(s.URL)
+	require.NoError(t, err, "Must not error making request")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, http.NoBody)
+	require.NoError(t, err, "Must not error creating request")
+
+	_, err = second.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must wait for the slot held by the other transport")
+
+	require.NoError(t, resp.Body.Close(), "Must not error closing body")
+
+	resp, err = second.Get(s.URL)
+	require.NoError(t, err, "Must not error once the slot is released")
+	_ = resp.Body.Close()
+}
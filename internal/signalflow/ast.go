@@ -0,0 +1,108 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// Node is any part of the parsed program.
+type Node interface {
+	Position() Position
+}
+
+// Ident is a reference to a named value, such as a variable or function.
+type Ident struct {
+	Pos  Position
+	Name string
+}
+
+// Literal is a constant number or string value,
+// the value of a string is stored without its quotes or prefix.
+type Literal struct {
+	Pos   Position
+	Kind  TokenKind
+	Value string
+	// Formatted is set for f-strings since
+	// the value is only known once evaluated.
+	Formatted bool
+}
+
+// Attribute is the access of a named field or method, `X.Name`.
+type Attribute struct {
+	Pos  Position
+	X    Node
+	Name string
+}
+
+// Keyword is a named argument passed to a call, `name=value`.
+type Keyword struct {
+	Pos   Position
+	Name  string
+	Value Node
+}
+
+// Call is the invocation of a function or method.
+type Call struct {
+	Pos      Position
+	Func     Node
+	Args     []Node
+	Keywords []*Keyword
+}
+
+// Assign binds the values to the targets, `a, b = c, d`.
+type Assign struct {
+	Pos     Position
+	Targets []Node
+	Value   Node
+}
+
+// Import is an `import` or `from ... import` statement,
+// the names it binds refer to SignalFlow library modules.
+type Import struct {
+	Pos Position
+}
+
+// Block is any other construct, such as an operator, collection or statement,
+// that is only tracked so that the nodes it contains can be visited.
+type Block struct {
+	Pos   Position
+	Nodes []Node
+}
+
+func (n *Ident) Position() Position     { return n.Pos }
+func (n *Literal) Position() Position   { return n.Pos }
+func (n *Attribute) Position() Position { return n.Pos }
+func (n *Keyword) Position() Position   { return n.Pos }
+func (n *Call) Position() Position      { return n.Pos }
+func (n *Assign) Position() Position    { return n.Pos }
+func (n *Import) Position() Position    { return n.Pos }
+func (n *Block) Position() Position     { return n.Pos }
+
+// Walk visits the node and all of its children in depth first order,
+// children are not visited when fn returns false.
+func Walk(n Node, fn func(Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	switch n := n.(type) {
+	case *Attribute:
+		Walk(n.X, fn)
+	case *Keyword:
+		Walk(n.Value, fn)
+	case *Call:
+		Walk(n.Func, fn)
+		for _, arg := range n.Args {
+			Walk(arg, fn)
+		}
+		for _, kw := range n.Keywords {
+			Walk(kw, fn)
+		}
+	case *Assign:
+		for _, t := range n.Targets {
+			Walk(t, fn)
+		}
+		Walk(n.Value, fn)
+	case *Block:
+		for _, child := range n.Nodes {
+			Walk(child, fn)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package signalflow provides an offline lexer and parser for SignalFlow
+// programs so that program text can be checked at plan time without
+// requiring access to the Splunk Observability Cloud API.
+//
+// The parser only builds enough of a syntax tree to check the structure
+// of the program and to extract the labels it publishes, it does not
+// evaluate or type check the program.
+package signalflow
@@ -0,0 +1,141 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// Publish is a call to `publish` found within the program.
+type Publish struct {
+	Pos Position
+	// Label is the value passed as the label,
+	// it is empty when the label is not a string literal.
+	Label string
+	// Dynamic is set when the label is computed
+	// and can only be known once the program is evaluated.
+	Dynamic bool
+	// Detect is set when the published stream is the result of `detect(...)`.
+	Detect bool
+}
+
+// Publishes returns every call to publish in the order they appear within the program.
+func (prog *Program) Publishes() []Publish {
+	detects := make(map[string]bool)
+	var publishes []Publish
+	for _, stmt := range prog.Statements {
+		Walk(stmt, func(n Node) bool {
+			switch n := n.(type) {
+			case *Assign:
+				for _, target := range n.Targets {
+					if ident, ok := target.(*Ident); ok {
+						detects[ident.Name] = isDetect(n.Value, detects)
+					}
+				}
+			case *Call:
+				if pub, ok := asPublish(n, detects); ok {
+					publishes = append(publishes, pub)
+				}
+			}
+			return true
+		})
+	}
+	return publishes
+}
+
+// Labels returns the unique labels published by the program, the result
+// is only complete when none of the published labels are dynamic and no
+// library modules are imported, since the functions of an imported module
+// can publish labels that are only known once the program is evaluated.
+func (prog *Program) Labels() (labels []string, complete bool) {
+	complete = !prog.Imports()
+	seen := make(map[string]struct{})
+	for _, pub := range prog.Publishes() {
+		if pub.Dynamic {
+			complete = false
+			continue
+		}
+		if _, ok := seen[pub.Label]; !ok {
+			seen[pub.Label] = struct{}{}
+			labels = append(labels, pub.Label)
+		}
+	}
+	return labels, complete
+}
+
+// Imports reports if the program imports any SignalFlow library modules.
+func (prog *Program) Imports() (found bool) {
+	for _, stmt := range prog.Statements {
+		Walk(stmt, func(n Node) bool {
+			if _, ok := n.(*Import); ok {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// DetectLabels returns the unique labels published by the result of `detect(...)`.
+func (prog *Program) DetectLabels() []string {
+	var labels []string
+	seen := make(map[string]struct{})
+	for _, pub := range prog.Publishes() {
+		if !pub.Detect || pub.Dynamic {
+			continue
+		}
+		if _, ok := seen[pub.Label]; !ok {
+			seen[pub.Label] = struct{}{}
+			labels = append(labels, pub.Label)
+		}
+	}
+	return labels
+}
+
+func asPublish(call *Call, detects map[string]bool) (Publish, bool) {
+	var stream Node
+	switch fn := call.Func.(type) {
+	case *Attribute:
+		if fn.Name != "publish" {
+			return Publish{}, false
+		}
+		stream = fn.X
+	case *Ident:
+		if fn.Name != "publish" {
+			return Publish{}, false
+		}
+	default:
+		return Publish{}, false
+	}
+
+	pub := Publish{Pos: call.Pos, Detect: isDetect(stream, detects)}
+
+	var label Node
+	if len(call.Args) > 0 {
+		label = call.Args[0]
+	}
+	for _, kw := range call.Keywords {
+		if kw.Name == "label" {
+			label = kw.Value
+		}
+	}
+	switch lit, ok := label.(*Literal); {
+	case label == nil:
+		// A publish without a label is valid, it is just not usable by a rule.
+	case ok && lit.Kind == String && !lit.Formatted:
+		pub.Label = lit.Value
+	default:
+		pub.Dynamic = true
+	}
+	return pub, true
+}
+
+// isDetect reports if the value is a stream created by `detect(...)`,
+// either directly or by a variable that was assigned one.
+func isDetect(value Node, detects map[string]bool) bool {
+	switch v := value.(type) {
+	case *Ident:
+		return detects[v.Name]
+	case *Call:
+		fn, ok := v.Func.(*Ident)
+		return ok && fn.Name == "detect"
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramPublishes(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		src       string
+		publishes []Publish
+		labels    []string
+		complete  bool
+		detects   []string
+	}{
+		{
+			name:     "no publishes",
+			src:      "A = data('cpu')",
+			complete: true,
+		},
+		{
+			name: "detect and data publishes",
+			src: `A = data('cpu').publish('cpu')
+detect(when(A > 90)).publish('High CPU')
+detect(when(A > 95)).publish(label="Very High CPU")`,
+			publishes: []Publish{
+				{Pos: Position{Line: 1, Column: 17}, Label: "cpu"},
+				{Pos: Position{Line: 2, Column: 22}, Label: "High CPU", Detect: true},
+				{Pos: Position{Line: 3, Column: 22}, Label: "Very High CPU", Detect: true},
+			},
+			labels:   []string{"cpu", "High CPU", "Very High CPU"},
+			complete: true,
+			detects:  []string{"High CPU", "Very High CPU"},
+		},
+		{
+			name: "detect assigned to variable",
+			src: `high = detect(when(data('cpu') > 90))
+high.publish('High CPU')
+high.publish('High CPU')`,
+			publishes: []Publish{
+				{Pos: Position{Line: 2, Column: 6}, Label: "High CPU", Detect: true},
+				{Pos: Position{Line: 3, Column: 6}, Label: "High CPU", Detect: true},
+			},
+			labels:   []string{"High CPU"},
+			complete: true,
+			detects:  []string{"High CPU"},
+		},
+		{
+			name: "computed labels",
+			src: `name = 'High'
+detect(when(data('cpu') > 90)).publish(name)
+detect(when(data('cpu') > 95)).publish(f'{name} CPU')
+detect(when(data('cpu') > 99)).publish('Critical ' 'CPU')`,
+			publishes: []Publish{
+				{Pos: Position{Line: 2, Column: 32}, Dynamic: true, Detect: true},
+				{Pos: Position{Line: 3, Column: 32}, Dynamic: true, Detect: true},
+				{Pos: Position{Line: 4, Column: 32}, Label: "Critical CPU", Detect: true},
+			},
+			labels:   []string{"Critical CPU"},
+			complete: false,
+			detects:  []string{"Critical CPU"},
+		},
+		{
+			name: "library import",
+			src: `from signalfx.detectors.against_recent import against_recent
+against_recent.detector_mean_std(stream=data('cpu'), current_window='5m', historical_window='1h').publish('Recent CPU')`,
+			publishes: []Publish{
+				{Pos: Position{Line: 2, Column: 99}, Label: "Recent CPU"},
+			},
+			labels:   []string{"Recent CPU"},
+			complete: false,
+		},
+		{
+			name: "library import within a function",
+			src: `def cpu():
+    import signalfx.detectors.against_periods
+    return data('cpu')
+detect(when(cpu() > 90)).publish('High CPU')`,
+			publishes: []Publish{
+				{Pos: Position{Line: 4, Column: 26}, Label: "High CPU", Detect: true},
+			},
+			labels:   []string{"High CPU"},
+			complete: false,
+			detects:  []string{"High CPU"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			prog, err := Parse(tc.src)
+			require.NoError(t, err, "Must not error parsing the program")
+
+			assert.Equal(t, tc.publishes, prog.Publishes(), "Must match the expected publishes")
+
+			labels, complete := prog.Labels()
+			assert.Equal(t, tc.labels, labels, "Must match the expected labels")
+			assert.Equal(t, tc.complete, complete, "Must match if the labels are complete")
+			assert.Equal(t, tc.detects, prog.DetectLabels(), "Must match the expected detect labels")
+		})
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind classifies the tokens produced by the lexer.
+type TokenKind int
+
+const (
+	EOF TokenKind = iota
+	Newline
+	Indent
+	Dedent
+	Name
+	Number
+	String
+	Operator
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case EOF:
+		return "end of program"
+	case Newline:
+		return "newline"
+	case Indent:
+		return "indent"
+	case Dedent:
+		return "dedent"
+	case Name:
+		return "name"
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Operator:
+		return "operator"
+	}
+	return "unknown"
+}
+
+// Position is the line and column, both starting at 1, within the program text.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+// Token is a single lexical unit of the program text.
+type Token struct {
+	Kind  TokenKind
+	Value string
+	Pos   Position
+}
+
+func (t Token) String() string {
+	switch t.Kind {
+	case Name, Number, String, Operator:
+		return fmt.Sprintf("%s %q", t.Kind, t.Value)
+	}
+	return t.Kind.String()
+}
+
+// Error is a syntax error found while reading the program text.
+type Error struct {
+	Pos Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// operators is ordered so that the longest operator is always matched first.
+var operators = []string{
+	"**=", "//=", ">>=", "<<=",
+	"**", "//", "==", "!=", "<=", ">=", "->", "<<", ">>",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=",
+	"+", "-", "*", "/", "%", "<", ">", "=", ".", ",", ":", ";",
+	"(", ")", "[", "]", "{", "}", "~", "&", "|", "^", "@",
+}
+
+type lexer struct {
+	src    string
+	offset int
+	pos    Position
+
+	depth     int
+	lineStart bool
+	indents   []int
+	tokens    []Token
+}
+
+// Tokenize splits the program text into tokens,
+// the returned tokens always end with an EOF token.
+func Tokenize(src string) ([]Token, error) {
+	l := &lexer{
+		src:       src,
+		pos:       Position{Line: 1, Column: 1},
+		lineStart: true,
+		indents:   []int{0},
+	}
+	if err := l.run(); err != nil {
+		return nil, err
+	}
+	return l.tokens, nil
+}
+
+func (l *lexer) peek(n int) rune {
+	offset := l.offset
+	for i := 0; i < n; i++ {
+		if offset >= len(l.src) {
+			return 0
+		}
+		_, size := utf8.DecodeRuneInString(l.src[offset:])
+		offset += size
+	}
+	if offset >= len(l.src) {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[offset:])
+	return r
+}
+
+func (l *lexer) next() rune {
+	if l.offset >= len(l.src) {
+		return 0
+	}
+	r, size := utf8.DecodeRuneInString(l.src[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+	return r
+}
+
+func (l *lexer) emit(kind TokenKind, value string, pos Position) {
+	l.tokens = append(l.tokens, Token{Kind: kind, Value: value, Pos: pos})
+}
+
+func (l *lexer) errorf(pos Position, format string, args ...any) error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) lastKind() TokenKind {
+	if len(l.tokens) == 0 {
+		return Newline
+	}
+	return l.tokens[len(l.tokens)-1].Kind
+}
+
+func (l *lexer) run() error {
+	for {
+		if l.lineStart && l.depth == 0 {
+			if err := l.indentation(); err != nil {
+				return err
+			}
+		}
+		l.lineStart = false
+
+		r := l.peek(0)
+		pos := l.pos
+		switch {
+		case l.offset >= len(l.src):
+			// An unclosed bracket leaves the statement incomplete
+			// so it is left to the parser to report it.
+			if l.depth == 0 && l.lastKind() != Newline && l.lastKind() != Dedent {
+				l.emit(Newline, "", pos)
+			}
+			for len(l.indents) > 1 {
+				l.indents = l.indents[:len(l.indents)-1]
+				l.emit(Dedent, "", pos)
+			}
+			l.emit(EOF, "", pos)
+			return nil
+		case r == ' ' || r == '\t' || r == '\f' || r == '\r':
+			l.next()
+		case r == '\\':
+			l.next()
+			if l.peek(0) == '\r' {
+				l.next()
+			}
+			if l.next() != '\n' {
+				return l.errorf(pos, "unexpected character after line continuation")
+			}
+		case r == '#':
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.next()
+			}
+		case r == '\n':
+			l.next()
+			if l.depth == 0 {
+				if l.lastKind() != Newline {
+					l.emit(Newline, "", pos)
+				}
+				l.lineStart = true
+			}
+		case l.isStringStart():
+			if err := l.string(); err != nil {
+				return err
+			}
+		case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+			l.number()
+		case r == '_' || unicode.IsLetter(r):
+			start := l.offset
+			for r := l.peek(0); r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r); r = l.peek(0) {
+				l.next()
+			}
+			l.emit(Name, l.src[start:l.offset], pos)
+		default:
+			if err := l.operator(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// indentation reads the leading whitespace of a line and emits
+// the indent and dedent tokens for it, blank lines and lines only
+// containing a comment are ignored.
+func (l *lexer) indentation() error {
+	for {
+		width := 0
+	measure:
+		for {
+			switch l.peek(0) {
+			case ' ':
+				width++
+			case '\t':
+				width += 8 - width%8
+			case '\f', '\r':
+			default:
+				break measure
+			}
+			l.next()
+		}
+
+		switch l.peek(0) {
+		case '\n':
+			l.next()
+			continue
+		case '#':
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.next()
+			}
+			continue
+		case 0:
+			if l.offset >= len(l.src) {
+				return nil
+			}
+		}
+
+		// Program text is commonly provided as an indented heredoc,
+		// so the first line sets the base indentation of the program.
+		if len(l.tokens) == 0 {
+			l.indents[0] = width
+			return nil
+		}
+
+		current := l.indents[len(l.indents)-1]
+		switch {
+		case width > current:
+			l.indents = append(l.indents, width)
+			l.emit(Indent, "", l.pos)
+		case width < current:
+			for len(l.indents) > 1 && width < l.indents[len(l.indents)-1] {
+				l.indents = l.indents[:len(l.indents)-1]
+				l.emit(Dedent, "", l.pos)
+			}
+			if width != l.indents[len(l.indents)-1] {
+				return l.errorf(l.pos, "unindent does not match any outer indentation level")
+			}
+		}
+		return nil
+	}
+}
+
+func (l *lexer) isStringStart() bool {
+	for i := 0; i < 3; i++ {
+		switch r := l.peek(i); r {
+		case '\'', '"':
+			return true
+		case 'r', 'R', 'u', 'U', 'b', 'B', 'f', 'F':
+			if i == 2 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// string reads a string literal including its prefix and quotes,
+// the token value holds the literal as it was written.
+func (l *lexer) string() error {
+	start, pos := l.offset, l.pos
+	for r := l.peek(0); r != '\'' && r != '"'; r = l.peek(0) {
+		l.next()
+	}
+	quote := string(l.peek(0))
+	if l.peek(1) == l.peek(0) && l.peek(2) == l.peek(0) {
+		quote = strings.Repeat(quote, 3)
+	}
+	for range quote {
+		l.next()
+	}
+
+	for {
+		if l.offset >= len(l.src) {
+			return l.errorf(pos, "unterminated string literal")
+		}
+		if strings.HasPrefix(l.src[l.offset:], quote) {
+			for range quote {
+				l.next()
+			}
+			l.emit(String, l.src[start:l.offset], pos)
+			return nil
+		}
+		switch r := l.next(); r {
+		case '\\':
+			l.next()
+		case '\n':
+			if len(quote) == 1 {
+				return l.errorf(pos, "unterminated string literal")
+			}
+		}
+	}
+}
+
+func (l *lexer) number() {
+	start, pos := l.offset, l.pos
+	for {
+		r := l.peek(0)
+		switch {
+		case (r == 'e' || r == 'E') && (l.peek(1) == '+' || l.peek(1) == '-') && unicode.IsDigit(l.peek(2)):
+			l.next()
+			l.next()
+		case r == '.' || r == '_' || unicode.IsDigit(r) || unicode.IsLetter(r):
+			l.next()
+		default:
+			l.emit(Number, l.src[start:l.offset], pos)
+			return
+		}
+	}
+}
+
+func (l *lexer) operator() error {
+	pos := l.pos
+	for _, op := range operators {
+		if !strings.HasPrefix(l.src[l.offset:], op) {
+			continue
+		}
+		for range op {
+			l.next()
+		}
+		switch op {
+		case "(", "[", "{":
+			l.depth++
+		case ")", "]", "}":
+			if l.depth > 0 {
+				l.depth--
+			}
+		}
+		l.emit(Operator, op, pos)
+		return nil
+	}
+	return l.errorf(pos, "unexpected character %q", l.peek(0))
+}
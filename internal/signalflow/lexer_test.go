@@ -0,0 +1,134 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		src    string
+		expect []Token
+		errVal string
+	}{
+		{
+			name: "empty program",
+			src:  "",
+			expect: []Token{
+				{Kind: EOF, Pos: Position{Line: 1, Column: 1}},
+			},
+		},
+		{
+			name: "simple statement",
+			src:  "A = data('cpu') # comment",
+			expect: []Token{
+				{Kind: Name, Value: "A", Pos: Position{Line: 1, Column: 1}},
+				{Kind: Operator, Value: "=", Pos: Position{Line: 1, Column: 3}},
+				{Kind: Name, Value: "data", Pos: Position{Line: 1, Column: 5}},
+				{Kind: Operator, Value: "(", Pos: Position{Line: 1, Column: 9}},
+				{Kind: String, Value: "'cpu'", Pos: Position{Line: 1, Column: 10}},
+				{Kind: Operator, Value: ")", Pos: Position{Line: 1, Column: 15}},
+				{Kind: Newline, Pos: Position{Line: 1, Column: 26}},
+				{Kind: EOF, Pos: Position{Line: 1, Column: 26}},
+			},
+		},
+		{
+			name: "brackets join lines",
+			src:  "f(1,\n  2.5e-3)\n",
+			expect: []Token{
+				{Kind: Name, Value: "f", Pos: Position{Line: 1, Column: 1}},
+				{Kind: Operator, Value: "(", Pos: Position{Line: 1, Column: 2}},
+				{Kind: Number, Value: "1", Pos: Position{Line: 1, Column: 3}},
+				{Kind: Operator, Value: ",", Pos: Position{Line: 1, Column: 4}},
+				{Kind: Number, Value: "2.5e-3", Pos: Position{Line: 2, Column: 3}},
+				{Kind: Operator, Value: ")", Pos: Position{Line: 2, Column: 9}},
+				{Kind: Newline, Pos: Position{Line: 2, Column: 10}},
+				{Kind: EOF, Pos: Position{Line: 3, Column: 1}},
+			},
+		},
+		{
+			name: "indented block",
+			src:  "def f():\n\n    return 1\nf()",
+			expect: []Token{
+				{Kind: Name, Value: "def", Pos: Position{Line: 1, Column: 1}},
+				{Kind: Name, Value: "f", Pos: Position{Line: 1, Column: 5}},
+				{Kind: Operator, Value: "(", Pos: Position{Line: 1, Column: 6}},
+				{Kind: Operator, Value: ")", Pos: Position{Line: 1, Column: 7}},
+				{Kind: Operator, Value: ":", Pos: Position{Line: 1, Column: 8}},
+				{Kind: Newline, Pos: Position{Line: 1, Column: 9}},
+				{Kind: Indent, Pos: Position{Line: 3, Column: 5}},
+				{Kind: Name, Value: "return", Pos: Position{Line: 3, Column: 5}},
+				{Kind: Number, Value: "1", Pos: Position{Line: 3, Column: 12}},
+				{Kind: Newline, Pos: Position{Line: 3, Column: 13}},
+				{Kind: Dedent, Pos: Position{Line: 4, Column: 1}},
+				{Kind: Name, Value: "f", Pos: Position{Line: 4, Column: 1}},
+				{Kind: Operator, Value: "(", Pos: Position{Line: 4, Column: 2}},
+				{Kind: Operator, Value: ")", Pos: Position{Line: 4, Column: 3}},
+				{Kind: Newline, Pos: Position{Line: 4, Column: 4}},
+				{Kind: EOF, Pos: Position{Line: 4, Column: 4}},
+			},
+		},
+		{
+			name: "indented program",
+			src:  "    A = 1\n    B\n",
+			expect: []Token{
+				{Kind: Name, Value: "A", Pos: Position{Line: 1, Column: 5}},
+				{Kind: Operator, Value: "=", Pos: Position{Line: 1, Column: 7}},
+				{Kind: Number, Value: "1", Pos: Position{Line: 1, Column: 9}},
+				{Kind: Newline, Pos: Position{Line: 1, Column: 10}},
+				{Kind: Name, Value: "B", Pos: Position{Line: 2, Column: 5}},
+				{Kind: Newline, Pos: Position{Line: 2, Column: 6}},
+				{Kind: EOF, Pos: Position{Line: 3, Column: 1}},
+			},
+		},
+		{
+			name: "string prefixes and quotes",
+			src:  `r'a\'b' """c"d"""`,
+			expect: []Token{
+				{Kind: String, Value: `r'a\'b'`, Pos: Position{Line: 1, Column: 1}},
+				{Kind: String, Value: `"""c"d"""`, Pos: Position{Line: 1, Column: 9}},
+				{Kind: Newline, Pos: Position{Line: 1, Column: 18}},
+				{Kind: EOF, Pos: Position{Line: 1, Column: 18}},
+			},
+		},
+		{
+			name:   "unterminated string",
+			src:    "A = data('cpu)\n",
+			errVal: "line 1, column 10: unterminated string literal",
+		},
+		{
+			name:   "inconsistent unindent",
+			src:    "def f():\n    A = 1\n  B = 2\n",
+			errVal: "line 3, column 3: unindent does not match any outer indentation level",
+		},
+		{
+			name:   "unindent before program indentation",
+			src:    "    A = 1\n  B = 2\n",
+			errVal: "line 2, column 3: unindent does not match any outer indentation level",
+		},
+		{
+			name:   "unknown character",
+			src:    "A = data('cpu') $ 2",
+			errVal: "line 1, column 17: unexpected character '$'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens, err := Tokenize(tc.src)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			assert.NoError(t, err, "Must not error tokenizing the program")
+			assert.Equal(t, tc.expect, tokens, "Must match the expected tokens")
+		})
+	}
+}
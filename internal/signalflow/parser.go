@@ -0,0 +1,808 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Program is the parsed representation of SignalFlow program text.
+type Program struct {
+	Statements []Node
+}
+
+var (
+	compareOperators = []string{"<", ">", "==", "!=", "<=", ">="}
+	assignOperators  = []string{"+=", "-=", "*=", "/=", "//=", "%=", "**=", "&=", "|=", "^=", ">>=", "<<="}
+	binaryOperators  = [][]string{
+		{"|"}, {"^"}, {"&"}, {"<<", ">>"}, {"+", "-"}, {"*", "/", "//", "%", "@"},
+	}
+	reserved = []string{
+		"and", "as", "def", "elif", "else", "for", "from", "if",
+		"import", "in", "is", "lambda", "not", "or", "pass", "return",
+	}
+)
+
+type parser struct {
+	tokens []Token
+	offset int
+}
+
+// Parse reads the program text and returns the parsed program,
+// the returned error is an *Error describing the first syntax issue found.
+func Parse(src string) (*Program, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	prog := &Program{}
+	for !p.at(EOF, "") {
+		if p.accept(Newline, "") {
+			continue
+		}
+		stmts, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		prog.Statements = append(prog.Statements, stmts...)
+	}
+	return prog, nil
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.offset]
+}
+
+func (p *parser) advance() Token {
+	tok := p.tokens[p.offset]
+	if tok.Kind != EOF {
+		p.offset++
+	}
+	return tok
+}
+
+// at reports if the current token matches the kind,
+// and the value when one is provided.
+func (p *parser) at(kind TokenKind, value string) bool {
+	tok := p.peek()
+	return tok.Kind == kind && (value == "" || tok.Value == value)
+}
+
+func (p *parser) atOperator(values ...string) bool {
+	return p.peek().Kind == Operator && slices.Contains(values, p.peek().Value)
+}
+
+func (p *parser) atKeyword(values ...string) bool {
+	return p.peek().Kind == Name && slices.Contains(values, p.peek().Value)
+}
+
+func (p *parser) accept(kind TokenKind, value string) bool {
+	if p.at(kind, value) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind TokenKind, value string) (Token, error) {
+	if p.at(kind, value) {
+		return p.advance(), nil
+	}
+	want := kind.String()
+	if value != "" {
+		want = fmt.Sprintf("%q", value)
+	}
+	return Token{}, p.unexpected("expected " + want)
+}
+
+func (p *parser) expectName() (Token, error) {
+	if p.at(Name, "") && !slices.Contains(reserved, p.peek().Value) {
+		return p.advance(), nil
+	}
+	return Token{}, p.unexpected("expected name")
+}
+
+func (p *parser) unexpected(reason string) error {
+	tok := p.peek()
+	msg := fmt.Sprintf("unexpected %s", tok)
+	switch tok.Kind {
+	case Indent:
+		msg = "unexpected indent"
+	case Dedent:
+		msg = "unexpected unindent"
+	}
+	if reason != "" {
+		msg += ", " + reason
+	}
+	return &Error{Pos: tok.Pos, Msg: msg}
+}
+
+func (p *parser) statement() ([]Node, error) {
+	switch {
+	case p.at(Name, "def"):
+		stmt, err := p.funcDef()
+		return []Node{stmt}, err
+	case p.at(Name, "if"):
+		stmt, err := p.ifStatement()
+		return []Node{stmt}, err
+	}
+	return p.simpleStatements()
+}
+
+// simpleStatements reads one or more statements separated by `;` up to the end of the line.
+func (p *parser) simpleStatements() ([]Node, error) {
+	var stmts []Node
+	for {
+		stmt, err := p.smallStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+		if !p.accept(Operator, ";") || p.at(Newline, "") {
+			break
+		}
+	}
+	if _, err := p.expect(Newline, ""); err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
+
+func (p *parser) smallStatement() (Node, error) {
+	pos := p.peek().Pos
+	switch {
+	case p.accept(Name, "pass"):
+		return &Block{Pos: pos}, nil
+	case p.accept(Name, "return"):
+		if p.at(Newline, "") || p.atOperator(";") {
+			return &Block{Pos: pos}, nil
+		}
+		value, err := p.testList()
+		return &Block{Pos: pos, Nodes: []Node{value}}, err
+	case p.accept(Name, "import"):
+		return &Import{Pos: pos}, p.importNames()
+	case p.accept(Name, "from"):
+		return &Import{Pos: pos}, p.importFrom()
+	}
+
+	value, err := p.testList()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.atOperator("="):
+		assign := &Assign{Pos: pos}
+		for p.accept(Operator, "=") {
+			assign.Targets = append(assign.Targets, value)
+			if value, err = p.testList(); err != nil {
+				return nil, err
+			}
+		}
+		assign.Value = value
+		return assign, nil
+	case p.atOperator(assignOperators...):
+		p.advance()
+		rhs, err := p.testList()
+		return &Block{Pos: pos, Nodes: []Node{value, rhs}}, err
+	}
+	return value, nil
+}
+
+func (p *parser) dottedName() error {
+	if _, err := p.expectName(); err != nil {
+		return err
+	}
+	for p.accept(Operator, ".") {
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) importNames() error {
+	for {
+		if err := p.dottedName(); err != nil {
+			return err
+		}
+		if p.accept(Name, "as") {
+			if _, err := p.expectName(); err != nil {
+				return err
+			}
+		}
+		if !p.accept(Operator, ",") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) importFrom() error {
+	for p.accept(Operator, ".") {
+	}
+	if !p.at(Name, "import") {
+		if err := p.dottedName(); err != nil {
+			return err
+		}
+	}
+	if _, err := p.expect(Name, "import"); err != nil {
+		return err
+	}
+	if p.accept(Operator, "*") {
+		return nil
+	}
+	paren := p.accept(Operator, "(")
+	for {
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.accept(Name, "as") {
+			if _, err := p.expectName(); err != nil {
+				return err
+			}
+		}
+		if !p.accept(Operator, ",") || (paren && p.atOperator(")")) {
+			break
+		}
+	}
+	if paren {
+		if _, err := p.expect(Operator, ")"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) funcDef() (Node, error) {
+	pos := p.advance().Pos
+	if _, err := p.expectName(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(Operator, "("); err != nil {
+		return nil, err
+	}
+	params, err := p.parameters(")")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(Operator, ")"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(Operator, ":"); err != nil {
+		return nil, err
+	}
+	body, err := p.suite()
+	if err != nil {
+		return nil, err
+	}
+	return &Block{Pos: pos, Nodes: append(params, body...)}, nil
+}
+
+func (p *parser) ifStatement() (Node, error) {
+	block := &Block{Pos: p.advance().Pos}
+	for {
+		cond, err := p.test()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(Operator, ":"); err != nil {
+			return nil, err
+		}
+		body, err := p.suite()
+		if err != nil {
+			return nil, err
+		}
+		block.Nodes = append(block.Nodes, cond)
+		block.Nodes = append(block.Nodes, body...)
+		if !p.accept(Name, "elif") {
+			break
+		}
+	}
+	if p.accept(Name, "else") {
+		if _, err := p.expect(Operator, ":"); err != nil {
+			return nil, err
+		}
+		body, err := p.suite()
+		if err != nil {
+			return nil, err
+		}
+		block.Nodes = append(block.Nodes, body...)
+	}
+	return block, nil
+}
+
+// suite reads the body of a compound statement which is either
+// on the same line or an indented block on the following lines.
+func (p *parser) suite() ([]Node, error) {
+	if !p.accept(Newline, "") {
+		return p.simpleStatements()
+	}
+	if _, err := p.expect(Indent, ""); err != nil {
+		return nil, err
+	}
+	var body []Node
+	for !p.accept(Dedent, "") {
+		if p.at(EOF, "") {
+			return nil, p.unexpected("expected unindent")
+		}
+		stmts, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmts...)
+	}
+	return body, nil
+}
+
+// parameters reads the parameter list of a function or lambda up to the closing operator.
+func (p *parser) parameters(closing string) ([]Node, error) {
+	var params []Node
+	for !p.atOperator(closing) {
+		switch {
+		case p.accept(Operator, "**"), p.accept(Operator, "*"):
+			if p.atOperator(",") {
+				break
+			}
+			fallthrough
+		default:
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			param := Node(&Ident{Pos: name.Pos, Name: name.Value})
+			if p.accept(Operator, "=") {
+				value, err := p.test()
+				if err != nil {
+					return nil, err
+				}
+				param = &Block{Pos: name.Pos, Nodes: []Node{param, value}}
+			}
+			params = append(params, param)
+		}
+		if !p.accept(Operator, ",") {
+			break
+		}
+	}
+	return params, nil
+}
+
+// testList reads one or more comma separated expressions,
+// more than one is returned as a tuple.
+func (p *parser) testList() (Node, error) {
+	pos := p.peek().Pos
+	first, err := p.test()
+	if err != nil {
+		return nil, err
+	}
+	if !p.atOperator(",") {
+		return first, nil
+	}
+	tuple := &Block{Pos: pos, Nodes: []Node{first}}
+	for p.accept(Operator, ",") {
+		if !p.startsExpression() {
+			break
+		}
+		next, err := p.test()
+		if err != nil {
+			return nil, err
+		}
+		tuple.Nodes = append(tuple.Nodes, next)
+	}
+	return tuple, nil
+}
+
+func (p *parser) startsExpression() bool {
+	tok := p.peek()
+	switch tok.Kind {
+	case Name:
+		return tok.Value == "not" || tok.Value == "lambda" || !slices.Contains(reserved, tok.Value)
+	case Number, String:
+		return true
+	case Operator:
+		return slices.Contains([]string{"(", "[", "{", "-", "+", "~"}, tok.Value)
+	}
+	return false
+}
+
+func (p *parser) test() (Node, error) {
+	if p.at(Name, "lambda") {
+		pos := p.advance().Pos
+		params, err := p.parameters(":")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(Operator, ":"); err != nil {
+			return nil, err
+		}
+		body, err := p.test()
+		if err != nil {
+			return nil, err
+		}
+		return &Block{Pos: pos, Nodes: append(params, body)}, nil
+	}
+
+	value, err := p.orTest()
+	if err != nil || !p.at(Name, "if") {
+		return value, err
+	}
+	p.advance()
+	cond, err := p.orTest()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(Name, "else"); err != nil {
+		return nil, err
+	}
+	other, err := p.test()
+	if err != nil {
+		return nil, err
+	}
+	return &Block{Pos: value.Position(), Nodes: []Node{value, cond, other}}, nil
+}
+
+func (p *parser) orTest() (Node, error) {
+	return p.logical("or", p.andTest)
+}
+
+func (p *parser) andTest() (Node, error) {
+	return p.logical("and", p.notTest)
+}
+
+func (p *parser) logical(keyword string, operand func() (Node, error)) (Node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(Name, keyword) {
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &Block{Pos: left.Position(), Nodes: []Node{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) notTest() (Node, error) {
+	if p.at(Name, "not") {
+		pos := p.advance().Pos
+		value, err := p.notTest()
+		if err != nil {
+			return nil, err
+		}
+		return &Block{Pos: pos, Nodes: []Node{value}}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (Node, error) {
+	left, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.atOperator(compareOperators...), p.at(Name, "in"):
+			p.advance()
+		case p.at(Name, "is"):
+			p.advance()
+			p.accept(Name, "not")
+		case p.at(Name, "not"):
+			p.advance()
+			if _, err := p.expect(Name, "in"); err != nil {
+				return nil, err
+			}
+		default:
+			return left, nil
+		}
+		right, err := p.binary(0)
+		if err != nil {
+			return nil, err
+		}
+		left = &Block{Pos: left.Position(), Nodes: []Node{left, right}}
+	}
+}
+
+// binary reads the binary operators in order of their precedence.
+func (p *parser) binary(level int) (Node, error) {
+	if level == len(binaryOperators) {
+		return p.factor()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.atOperator(binaryOperators[level]...) {
+		p.advance()
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &Block{Pos: left.Position(), Nodes: []Node{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) factor() (Node, error) {
+	if p.atOperator("+", "-", "~") {
+		pos := p.advance().Pos
+		value, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &Block{Pos: pos, Nodes: []Node{value}}, nil
+	}
+	base, err := p.primary()
+	if err != nil || !p.atOperator("**") {
+		return base, err
+	}
+	p.advance()
+	exp, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	return &Block{Pos: base.Position(), Nodes: []Node{base, exp}}, nil
+}
+
+// primary reads an atom followed by any attribute access, call or subscript.
+func (p *parser) primary() (Node, error) {
+	node, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.atOperator("."):
+			p.advance()
+			name, err := p.expect(Name, "")
+			if err != nil {
+				return nil, err
+			}
+			node = &Attribute{Pos: name.Pos, X: node, Name: name.Value}
+		case p.atOperator("("):
+			p.advance()
+			if node, err = p.arguments(node); err != nil {
+				return nil, err
+			}
+		case p.atOperator("["):
+			pos := p.advance().Pos
+			index, err := p.subscripts()
+			if err != nil {
+				return nil, err
+			}
+			node = &Block{Pos: pos, Nodes: []Node{node, index}}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *parser) arguments(fn Node) (Node, error) {
+	call := &Call{Pos: fn.Position(), Func: fn}
+	for !p.atOperator(")") {
+		pos := p.peek().Pos
+		switch {
+		case p.accept(Operator, "*"), p.accept(Operator, "**"):
+			value, err := p.test()
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, &Block{Pos: pos, Nodes: []Node{value}})
+		case p.at(Name, "") && p.tokens[p.offset+1].Kind == Operator && p.tokens[p.offset+1].Value == "=":
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			p.advance()
+			value, err := p.test()
+			if err != nil {
+				return nil, err
+			}
+			call.Keywords = append(call.Keywords, &Keyword{Pos: name.Pos, Name: name.Value, Value: value})
+		default:
+			if len(call.Keywords) > 0 {
+				return nil, &Error{Pos: pos, Msg: "positional argument follows keyword argument"}
+			}
+			value, err := p.test()
+			if err != nil {
+				return nil, err
+			}
+			if p.at(Name, "for") {
+				if value, err = p.comprehension(value); err != nil {
+					return nil, err
+				}
+			}
+			call.Args = append(call.Args, value)
+		}
+		if !p.accept(Operator, ",") {
+			break
+		}
+	}
+	if _, err := p.expect(Operator, ")"); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+func (p *parser) subscripts() (Node, error) {
+	block := &Block{Pos: p.peek().Pos}
+	for {
+		for {
+			if !p.atOperator(":", ",", "]") {
+				value, err := p.test()
+				if err != nil {
+					return nil, err
+				}
+				block.Nodes = append(block.Nodes, value)
+			}
+			if !p.accept(Operator, ":") {
+				break
+			}
+		}
+		if !p.accept(Operator, ",") || p.atOperator("]") {
+			break
+		}
+	}
+	if _, err := p.expect(Operator, "]"); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// comprehension reads the `for ... in ... if ...` clauses following the value.
+func (p *parser) comprehension(value Node) (Node, error) {
+	block := &Block{Pos: value.Position(), Nodes: []Node{value}}
+	for p.accept(Name, "for") {
+		for {
+			if _, err := p.expectName(); err != nil {
+				return nil, err
+			}
+			if !p.accept(Operator, ",") {
+				break
+			}
+		}
+		if _, err := p.expect(Name, "in"); err != nil {
+			return nil, err
+		}
+		iter, err := p.orTest()
+		if err != nil {
+			return nil, err
+		}
+		block.Nodes = append(block.Nodes, iter)
+		for p.accept(Name, "if") {
+			cond, err := p.orTest()
+			if err != nil {
+				return nil, err
+			}
+			block.Nodes = append(block.Nodes, cond)
+		}
+	}
+	return block, nil
+}
+
+func (p *parser) atom() (Node, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case Number:
+		p.advance()
+		return &Literal{Pos: tok.Pos, Kind: Number, Value: tok.Value}, nil
+	case String:
+		return p.strings()
+	case Name:
+		if slices.Contains(reserved, tok.Value) {
+			return nil, p.unexpected("expected expression")
+		}
+		p.advance()
+		return &Ident{Pos: tok.Pos, Name: tok.Value}, nil
+	case Operator:
+		switch tok.Value {
+		case "(":
+			return p.collection(")", false)
+		case "[":
+			return p.collection("]", false)
+		case "{":
+			return p.collection("}", true)
+		}
+	}
+	return nil, p.unexpected("expected expression")
+}
+
+// strings reads adjacent string literals which are joined into a single value.
+func (p *parser) strings() (Node, error) {
+	lit := &Literal{Pos: p.peek().Pos, Kind: String}
+	var sb strings.Builder
+	for p.at(String, "") {
+		value, formatted := unquote(p.advance().Value)
+		sb.WriteString(value)
+		lit.Formatted = lit.Formatted || formatted
+	}
+	lit.Value = sb.String()
+	return lit, nil
+}
+
+// collection reads a parenthesised expression, tuple, list, set, or dict.
+func (p *parser) collection(closing string, mapping bool) (Node, error) {
+	block := &Block{Pos: p.advance().Pos}
+	for !p.atOperator(closing) {
+		if mapping && p.accept(Operator, "**") {
+			value, err := p.binary(0)
+			if err != nil {
+				return nil, err
+			}
+			block.Nodes = append(block.Nodes, value)
+		} else {
+			value, err := p.test()
+			if err != nil {
+				return nil, err
+			}
+			block.Nodes = append(block.Nodes, value)
+			if mapping && p.accept(Operator, ":") {
+				if value, err = p.test(); err != nil {
+					return nil, err
+				}
+				block.Nodes = append(block.Nodes, value)
+			}
+		}
+		if p.at(Name, "for") {
+			last := block.Nodes[len(block.Nodes)-1]
+			value, err := p.comprehension(last)
+			if err != nil {
+				return nil, err
+			}
+			block.Nodes[len(block.Nodes)-1] = value
+		}
+		if !p.accept(Operator, ",") {
+			break
+		}
+	}
+	if _, err := p.expect(Operator, closing); err != nil {
+		return nil, err
+	}
+	if closing == ")" && len(block.Nodes) == 1 {
+		return block.Nodes[0], nil
+	}
+	return block, nil
+}
+
+// unquote returns the value of the string literal and
+// if it is a formatted string that is only known once evaluated.
+func unquote(raw string) (string, bool) {
+	prefix := strings.ToLower(raw[:strings.IndexAny(raw, `'"`)])
+	body := raw[len(prefix):]
+	quote := body[:1]
+	if strings.HasPrefix(body, strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	body = body[len(quote) : len(body)-len(quote)]
+	if strings.Contains(prefix, "r") {
+		return body, strings.Contains(prefix, "f")
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i+1 == len(body) {
+			sb.WriteByte(body[i])
+			continue
+		}
+		i++
+		switch body[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case '\\', '\'', '"':
+			sb.WriteByte(body[i])
+		case '\n':
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(body[i])
+		}
+	}
+	return sb.String(), strings.Contains(prefix, "f")
+}
@@ -0,0 +1,101 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		src    string
+		errVal string
+	}{
+		{
+			name: "detector program",
+			src: `A = data('cpu.utilization', filter=filter('env', 'prod')).mean(by=['host'])
+detect(when(A > 90, lasting='5m')).publish('High CPU')
+`,
+		},
+		{
+			name: "library import",
+			src: `from signalfx.detectors.against_recent import against_recent
+against_recent.detector_mean_std(stream=data('cpu'), current_window='5m').publish('Deviation')`,
+		},
+		{
+			name: "function definitions",
+			src: `def threshold(stream, value=90, *args, **kwargs):
+    # comments are ignored
+    if value > 100:
+        return stream > 100
+    elif value < 0: return stream < 0
+    else:
+        pass
+    return stream > value
+
+detect(when(threshold(data('cpu')))).publish('High CPU')
+`,
+		},
+		{
+			name: "expressions",
+			src: `A = data('a') if True else data('b'); B = -A ** 2 // 3 % 4
+C = [x for x in (1, 2, 3) if x is not None and not x in {1: 'a', **{}}]
+D = A.top(count=5)[0:2, ::1]
+E = lambda x, y=1: x + y
+F = """multi
+line""" r'raw\d' f'{A}'
+G, H = 1, 2,
+G += 1
+`,
+		},
+		{
+			name:   "missing closing parenthesis",
+			src:    "detect(when(data('cpu') > 90).publish('High CPU')\n",
+			errVal: "line 2, column 1: unexpected end of program, expected \")\"",
+		},
+		{
+			name:   "unexpected indent",
+			src:    "A = data('cpu')\n  detect(when(A > 90)).publish('High CPU')\n",
+			errVal: "line 2, column 3: unexpected indent, expected expression",
+		},
+		{
+			name:   "incomplete expression",
+			src:    "A = data('cpu') >\n",
+			errVal: "line 1, column 18: unexpected newline, expected expression",
+		},
+		{
+			name:   "keyword as name",
+			src:    "A = data('cpu').publish(label=)",
+			errVal: "line 1, column 31: unexpected operator \")\", expected expression",
+		},
+		{
+			name:   "positional after keyword",
+			src:    "data(metric='cpu', 'host')",
+			errVal: "line 1, column 20: positional argument follows keyword argument",
+		},
+		{
+			name:   "missing function body",
+			src:    "def f():\nf()",
+			errVal: "line 2, column 1: unexpected name \"f\", expected indent",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			prog, err := Parse(tc.src)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				assert.IsType(t, &Error{}, err, "Must return a syntax error")
+				return
+			}
+			assert.NoError(t, err, "Must not error parsing the program")
+			assert.NotEmpty(t, prog.Statements, "Must have parsed statements")
+		})
+	}
+}
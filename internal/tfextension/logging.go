@@ -4,10 +4,16 @@
 package tfext
 
 import (
+	"bytes"
 	"encoding/json"
+	"slices"
+	"strings"
 	"time"
 )
 
+// RedactedValue replaces the sensitive values written by [LogFields.JSON].
+const RedactedValue = "REDACTED"
+
 // LogFields is an extension to logging fields parameter
 // to help as a convience and provides some level of standards.
 type LogFields map[string]any
@@ -44,9 +50,56 @@ func (lf LogFields) Field(key string, val any) LogFields {
 	return lf
 }
 
+// JSON appends the value encoded as JSON, any object field
+// that is named as a secret, such as the secret of a webhook notification,
+// has its value replaced with [RedactedValue] so it is not written to the logs.
 func (lf LogFields) JSON(key string, val any) LogFields {
-	if buf, err := json.Marshal(val); err == nil {
-		lf[key] = string(buf)
+	buf, err := json.Marshal(val)
+	if err != nil {
+		return lf
 	}
+	lf[key] = string(redactJSON(buf))
 	return lf
 }
+
+// RedactedJSON returns the value encoded as JSON with the same values replaced as [LogFields.JSON],
+// along with any object field matching one of the names, such as the password of an integration.
+// It is used by resources that write the payloads sent to the API using the standard logger.
+func RedactedJSON(val any, names ...string) string {
+	buf, _ := json.Marshal(val)
+	return string(redactJSON(buf, names...))
+}
+
+func redactJSON(buf []byte, names ...string) []byte {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+
+	var content any
+	if err := dec.Decode(&content); err == nil && redact(content, names) {
+		if redacted, err := json.Marshal(content); err == nil {
+			return redacted
+		}
+	}
+	return buf
+}
+
+// redact replaces the sensitive values within the decoded JSON content,
+// and reports if any values were replaced.
+func redact(content any, names []string) (redacted bool) {
+	switch v := content.(type) {
+	case map[string]any:
+		for name, field := range v {
+			sensitive := strings.HasSuffix(strings.ToLower(name), "secret") || slices.Contains(names, name)
+			if s, ok := field.(string); ok && s != "" && sensitive {
+				v[name], redacted = RedactedValue, true
+				continue
+			}
+			redacted = redact(field, names) || redacted
+		}
+	case []any:
+		for _, item := range v {
+			redacted = redact(item, names) || redacted
+		}
+	}
+	return redacted
+}
@@ -51,6 +51,23 @@ func TestLogFields(t *testing.T) {
 				"data": `{"hello":"world"}`,
 			},
 		},
+		{
+			name: "json field with secrets",
+			lf: NewLogFields().JSON("data", map[string]any{
+				"rules": []any{
+					map[string]any{
+						"notifications": []any{
+							map[string]any{"type": "Webhook", "secret": "hunter2", "url": "http://localhost"},
+						},
+					},
+				},
+				"sharedSecret": "hunter2",
+				"count":        1700000000001,
+			}),
+			expect: map[string]any{
+				"data": `{"count":1700000000001,"rules":[{"notifications":[{"secret":"REDACTED","type":"Webhook","url":"http://localhost"}]}],"sharedSecret":"REDACTED"}`,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -59,3 +76,23 @@ func TestLogFields(t *testing.T) {
 		})
 	}
 }
+
+func TestRedactedJSON(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `{"hello":"world"}`, RedactedJSON(map[string]any{"hello": "world"}), "Must encode the value")
+	assert.Equal(t,
+		`{"notifications":[{"secret":"REDACTED","type":"Webhook"}]}`,
+		RedactedJSON(map[string]any{"notifications": []any{map[string]any{"type": "Webhook", "secret": "hunter2"}}}),
+		"Must redact the secrets",
+	)
+	assert.Equal(t,
+		`{"password":"REDACTED","projectServiceKeys":[{"projectId":"project-a","projectKey":"REDACTED"}],"username":"admin"}`,
+		RedactedJSON(map[string]any{
+			"username":           "admin",
+			"password":           "hunter2",
+			"projectServiceKeys": []any{map[string]any{"projectId": "project-a", "projectKey": "key-a"}},
+		}, "password", "projectKey"),
+		"Must redact the named fields",
+	)
+}
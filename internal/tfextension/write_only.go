@@ -0,0 +1,40 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetWriteOnlyString returns the configured value of the write only attribute at the provided path.
+//
+// Write only values are never persisted within the plan or state,
+// so they can only be read from the raw configuration during create or update.
+// An empty string is returned when the value is not configured.
+func GetWriteOnlyString(d *schema.ResourceData, p cty.Path) (string, error) {
+	val, diags := d.GetRawConfigAt(p)
+	if diags.HasError() {
+		var errs error
+		for _, issue := range diags {
+			errs = errors.Join(errs, errors.New(issue.Summary))
+		}
+		return "", errs
+	}
+	if val.IsNull() || !val.IsKnown() || !val.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return val.AsString(), nil
+}
+
+// GetStringOrWriteOnly returns the value of the attribute `key`, and if it is not set
+// it will read the write only attribute `wo` from the raw configuration.
+func GetStringOrWriteOnly(d *schema.ResourceData, key, wo string) (string, error) {
+	if val, ok := d.GetOk(key); ok {
+		return val.(string), nil
+	}
+	return GetWriteOnlyString(d, cty.GetAttrPath(wo))
+}
@@ -0,0 +1,157 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWriteOnlyResourceData(tb testing.TB, state map[string]string, config cty.Value) *schema.ResourceData {
+	tb.Helper()
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"password": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password_wo": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				WriteOnly: true,
+			},
+		},
+	}
+
+	return r.Data(&terraform.InstanceState{
+		ID:         "id",
+		Attributes: state,
+		RawConfig:  config,
+	})
+}
+
+func TestGetWriteOnlyString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		config cty.Value
+		path   cty.Path
+		expect string
+		errVal string
+	}{
+		{
+			name:   "no config provided",
+			config: cty.NullVal(cty.DynamicPseudoType),
+			path:   cty.GetAttrPath("password_wo"),
+			expect: "",
+			errVal: "Empty Raw Config",
+		},
+		{
+			name: "value not set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.NullVal(cty.String),
+			}),
+			path:   cty.GetAttrPath("password_wo"),
+			expect: "",
+		},
+		{
+			name: "value is unknown",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.UnknownVal(cty.String),
+			}),
+			path:   cty.GetAttrPath("password_wo"),
+			expect: "",
+		},
+		{
+			name: "value set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.StringVal("hunter2"),
+			}),
+			path:   cty.GetAttrPath("password_wo"),
+			expect: "hunter2",
+		},
+		{
+			name: "invalid path",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.StringVal("hunter2"),
+			}),
+			path:   cty.GetAttrPath("missing"),
+			expect: "",
+			errVal: "Invalid config path",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newWriteOnlyResourceData(t, nil, tc.config)
+
+			actual, err := GetWriteOnlyString(d, tc.path)
+			assert.Equal(t, tc.expect, actual, "Must match the expected value")
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, err, "Must not error")
+			}
+		})
+	}
+}
+
+func TestGetStringOrWriteOnly(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		state  map[string]string
+		config cty.Value
+		expect string
+	}{
+		{
+			name:  "attribute set",
+			state: map[string]string{"password": "plain"},
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.StringVal("plain"),
+				"password_wo": cty.NullVal(cty.String),
+			}),
+			expect: "plain",
+		},
+		{
+			name:  "write only attribute set",
+			state: map[string]string{},
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.StringVal("hidden"),
+			}),
+			expect: "hidden",
+		},
+		{
+			name:  "neither set",
+			state: map[string]string{},
+			config: cty.ObjectVal(map[string]cty.Value{
+				"password":    cty.NullVal(cty.String),
+				"password_wo": cty.NullVal(cty.String),
+			}),
+			expect: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newWriteOnlyResourceData(t, tc.state, tc.config)
+
+			actual, err := GetStringOrWriteOnly(d, "password", "password_wo")
+			assert.NoError(t, err, "Must not error")
+			assert.Equal(t, tc.expect, actual, "Must match the expected value")
+		})
+	}
+}
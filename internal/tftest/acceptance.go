@@ -4,15 +4,20 @@
 package tftest
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"go.uber.org/multierr"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 )
 
 // AcceptanceHandler is used to abstract some of the more raw
@@ -20,6 +25,7 @@ import (
 type AcceptanceHandler struct {
 	beforeAll func()
 	provider  *schema.Provider
+	fake      *fakeapi.API
 }
 
 // AcceptanceHandlerOption is used to supply additional values to a test case.
@@ -43,6 +49,14 @@ func WithAcceptanceBeforeAll(before func()) AcceptanceHandlerOption {
 	}
 }
 
+// WithAcceptanceFakeAPI runs the test steps against the in memory fake API
+// instead of Splunk Observability Cloud, so no credentials are required.
+func WithAcceptanceFakeAPI(api *fakeapi.API) AcceptanceHandlerOption {
+	return func(ah *AcceptanceHandler) {
+		ah.fake = api
+	}
+}
+
 func NewAcceptanceHandler(opts ...AcceptanceHandlerOption) *AcceptanceHandler {
 	ah := &AcceptanceHandler{
 		provider: &schema.Provider{
@@ -68,10 +82,10 @@ func (ah *AcceptanceHandler) Validate() (errs error) {
 
 func (ah *AcceptanceHandler) Test(t *testing.T, steps []resource.TestStep) {
 	var msgs []string
-	if _, set := os.LookupEnv("SFX_AUTH_TOKEN"); !set {
+	if _, set := os.LookupEnv("SFX_AUTH_TOKEN"); !set && ah.fake == nil {
 		msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_AUTH_TOKEN"))
 	}
-	if _, set := os.LookupEnv("SFX_API_URL"); !set {
+	if _, set := os.LookupEnv("SFX_API_URL"); !set && ah.fake == nil {
 		msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_API_URL"))
 	}
 	if len(msgs) != 0 {
@@ -87,6 +101,16 @@ func (ah *AcceptanceHandler) Test(t *testing.T, steps []resource.TestStep) {
 		return
 	}
 
+	if ah.fake != nil {
+		s := httptest.NewServer(ah.fake)
+		t.Cleanup(s.Close)
+
+		meta := newFakeAPIMeta(t.Name(), s)
+		ah.provider.ConfigureContextFunc = func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
+			return meta, nil
+		}
+	}
+
 	// Due to how the terraform library works,
 	// if this is globally set for each test case,
 	// it will cause some functions to panic instead of returning an error.
@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 )
 
 func TestAcceptanceHandlerOptions(t *testing.T) {
@@ -72,6 +74,7 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 	for _, tc := range []struct {
 		name    string
 		env     map[string]string
+		opts    []AcceptanceHandlerOption
 		skipped bool
 	}{
 		{
@@ -87,6 +90,12 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 			},
 			skipped: false,
 		},
+		{
+			name:    "fake api",
+			env:     map[string]string{},
+			opts:    []AcceptanceHandlerOption{WithAcceptanceFakeAPI(fakeapi.New())},
+			skipped: false,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			CleanEnvVars(t)
@@ -95,11 +104,11 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 				t.Setenv(k, v)
 			}
 
-			handler := NewAcceptanceHandler(
+			handler := NewAcceptanceHandler(append([]AcceptanceHandlerOption{
 				WithAcceptanceResources(map[string]*schema.Resource{
 					"nop": {},
 				}),
-			)
+			}, tc.opts...)...)
 
 			t.Cleanup(func() {
 				assert.Equal(t, tc.skipped, t.Skipped(), "Must have been skipped")
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
@@ -52,6 +53,33 @@ func NewTestHTTPMockMeta(routes map[string]http.HandlerFunc) func(testing.TB) an
 	}
 }
 
+// NewFakeAPIMeta configures a `providermeta.Meta` that is backed by the stateful fake API,
+// so objects created by one operation can be read, updated, or deleted by the next.
+func NewFakeAPIMeta(api *fakeapi.API) func(testing.TB) any {
+	return func(t testing.TB) any {
+		s := httptest.NewServer(api)
+		t.Cleanup(s.Close)
+
+		return newFakeAPIMeta(t.Name(), s)
+	}
+}
+
+func newFakeAPIMeta(token string, s *httptest.Server) *pmeta.Meta {
+	sfx, _ := signalfx.NewClient(
+		token,
+		signalfx.HTTPClient(s.Client()),
+		signalfx.APIUrl(s.URL),
+	)
+
+	return &pmeta.Meta{
+		APIURL:         s.URL,
+		AuthToken:      token,
+		CustomAppURL:   fakeapi.DefaultAppURL,
+		OrganizationID: fakeapi.DefaultOrganizationID,
+		Client:         sfx,
+	}
+}
+
 // newAcceptanceConfigure is used for acceptance testing purposes and not be used within unit tests.
 func newAcceptanceConfigure(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
 	meta := &pmeta.Meta{
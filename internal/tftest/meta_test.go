@@ -11,9 +11,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/team"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
@@ -38,6 +40,26 @@ func TestNewHTTPMockStat(t *testing.T) {
 	require.Error(t, client.DeleteTeam(context.Background(), "002"), "Must error trying to make request to endpoint not defined in mock")
 }
 
+func TestNewFakeAPIMeta(t *testing.T) {
+	t.Parallel()
+
+	api := fakeapi.New()
+	meta := NewFakeAPIMeta(api)(t)
+
+	client, err := pmeta.LoadClient(t.Context(), meta)
+	require.NoError(t, err, "Must not error loading client")
+
+	created, err := client.CreateTeam(t.Context(), &team.CreateUpdateTeamRequest{Name: "team"})
+	require.NoError(t, err, "Must create team")
+
+	read, err := client.GetTeam(t.Context(), created.Id)
+	require.NoError(t, err, "Must read the created team")
+	assert.Equal(t, "team", read.Name, "Must match the created team")
+
+	require.NoError(t, client.DeleteTeam(t.Context(), created.Id), "Must delete team")
+	assert.Zero(t, api.Len(fakeapi.Teams), "Must have removed the team")
+}
+
 func TestNewAcceptanceConfigure(t *testing.T) {
 
 	for _, tc := range []struct {
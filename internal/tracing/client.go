@@ -0,0 +1,71 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type attemptsKey struct{}
+
+// NewHTTPClient returns the standard client of rc where each request
+// is recorded as a span that is a child of the operation that made it.
+// The span covers every retry made by rc, and records the number of retries.
+func NewHTTPClient(rc *retryablehttp.Client) *http.Client {
+	hook := rc.RequestLogHook
+	rc.RequestLogHook = func(l retryablehttp.Logger, req *http.Request, attempt int) {
+		if attempts, ok := req.Context().Value(attemptsKey{}).(*atomic.Int64); ok {
+			attempts.Store(int64(attempt))
+		}
+		if hook != nil {
+			hook(l, req, attempt)
+		}
+	}
+
+	client := rc.StandardClient()
+	client.Transport = &transport{base: client.Transport}
+	return client
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttributeHTTPMethod.String(req.Method),
+			AttributeHTTPRoute.String(req.URL.Path),
+			AttributeServerHost.String(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	if !span.IsRecording() {
+		return t.base.RoundTrip(req)
+	}
+
+	attempts := new(atomic.Int64)
+	resp, err := t.base.RoundTrip(req.WithContext(context.WithValue(ctx, attemptsKey{}, attempts)))
+
+	span.SetAttributes(AttributeRetryCount.Int64(attempts.Load()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(AttributeHTTPStatus.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
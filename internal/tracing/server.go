@@ -0,0 +1,140 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	OperationCreate = "create"
+	OperationRead   = "read"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationImport = "import"
+)
+
+// providerServer is the set of RPCs served by the mux server,
+// the optional interfaces are included so they remain visible to the plugin server.
+type providerServer interface {
+	tfprotov5.ProviderServer
+	tfprotov5.ListResourceServer
+	tfprotov5.ActionServer
+}
+
+// NewProviderServer wraps the provider server so that each resource and data source
+// operation is recorded as a span, which is the parent of the API requests made by it.
+//
+// Terraform does not send the resource address to the provider,
+// so spans are identified by the resource type and operation.
+func NewProviderServer(server func() tfprotov5.ProviderServer) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		s := server()
+		if full, ok := s.(providerServer); ok {
+			return &tracedServer{providerServer: full}
+		}
+		return s
+	}
+}
+
+type tracedServer struct {
+	providerServer
+}
+
+var _ tfprotov5.ProviderServer = (*tracedServer)(nil)
+
+func (ts *tracedServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	ctx, span := startOperation(ctx, OperationRead, req.TypeName)
+	resp, err := ts.providerServer.ReadResource(ctx, req)
+	if resp != nil {
+		endOperation(span, resp.Diagnostics, err)
+	} else {
+		endOperation(span, nil, err)
+	}
+	return resp, err
+}
+
+func (ts *tracedServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	ctx, span := startOperation(ctx, applyOperation(req), req.TypeName)
+	resp, err := ts.providerServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		endOperation(span, resp.Diagnostics, err)
+	} else {
+		endOperation(span, nil, err)
+	}
+	return resp, err
+}
+
+func (ts *tracedServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	ctx, span := startOperation(ctx, OperationImport, req.TypeName)
+	resp, err := ts.providerServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		endOperation(span, resp.Diagnostics, err)
+	} else {
+		endOperation(span, nil, err)
+	}
+	return resp, err
+}
+
+func (ts *tracedServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	ctx, span := startOperation(ctx, OperationRead, req.TypeName)
+	resp, err := ts.providerServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		endOperation(span, resp.Diagnostics, err)
+	} else {
+		endOperation(span, nil, err)
+	}
+	return resp, err
+}
+
+// applyOperation uses the same rules as Terraform to determine
+// which operation is being applied from the states within the request.
+func applyOperation(req *tfprotov5.ApplyResourceChangeRequest) string {
+	switch {
+	case isNull(req.PriorState):
+		return OperationCreate
+	case isNull(req.PlannedState):
+		return OperationDelete
+	default:
+		return OperationUpdate
+	}
+}
+
+func isNull(v *tfprotov5.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	null, err := v.IsNull()
+	return err == nil && null
+}
+
+func startOperation(ctx context.Context, operation, typeName string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, operation+" "+typeName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			AttributeOperation.String(operation),
+			AttributeResourceType.String(typeName),
+		),
+	)
+}
+
+func endOperation(span trace.Span, diags []*tfprotov5.Diagnostic, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			span.SetStatus(codes.Error, d.Summary)
+			return
+		}
+	}
+}
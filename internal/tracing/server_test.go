@@ -0,0 +1,51 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOperation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		null  = &tfprotov5.DynamicValue{JSON: []byte(`null`)}
+		value = &tfprotov5.DynamicValue{JSON: []byte(`{"id":"AAAAAAAAAAA"}`)}
+	)
+
+	for _, tc := range []struct {
+		name    string
+		prior   *tfprotov5.DynamicValue
+		planned *tfprotov5.DynamicValue
+		expect  string
+	}{
+		{name: "create", prior: null, planned: value, expect: OperationCreate},
+		{name: "create without prior state", prior: nil, planned: value, expect: OperationCreate},
+		{name: "update", prior: value, planned: value, expect: OperationUpdate},
+		{name: "delete", prior: value, planned: null, expect: OperationDelete},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			op := applyOperation(&tfprotov5.ApplyResourceChangeRequest{
+				TypeName:     "signalfx_team",
+				PriorState:   tc.prior,
+				PlannedState: tc.planned,
+			})
+			assert.Equal(t, tc.expect, op, "Must match the expected operation")
+		})
+	}
+}
+
+func TestNewProviderServerUnsupported(t *testing.T) {
+	t.Parallel()
+
+	var s struct{ tfprotov5.ProviderServer }
+	server := NewProviderServer(func() tfprotov5.ProviderServer { return s })()
+	assert.Equal(t, s, server, "Must return servers without list resources or actions unchanged")
+}
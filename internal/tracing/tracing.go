@@ -0,0 +1,107 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tracing records the operations performed by the provider
+// as OpenTelemetry spans that are exported using OTLP over HTTP.
+//
+// Spans are only recorded once [Enable] has been called, which is done by
+// the provider when the `provider.tracing` feature preview is enabled.
+// The exporter is configured with the standard `OTEL_EXPORTER_OTLP_*` environment variables.
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// TracerName is the instrumentation scope used for every span.
+	TracerName  = "github.com/splunk-terraform/terraform-provider-signalfx"
+	ServiceName = "terraform-provider-signalfx"
+)
+
+const (
+	AttributeOperation    = attribute.Key("terraform.operation")
+	AttributeResourceType = attribute.Key("terraform.resource.type")
+	AttributeHTTPMethod   = attribute.Key("http.request.method")
+	AttributeHTTPRoute    = attribute.Key("url.path")
+	AttributeHTTPStatus   = attribute.Key("http.response.status_code")
+	AttributeRetryCount   = attribute.Key("http.request.resend_count")
+	AttributeServerHost   = attribute.Key("server.address")
+)
+
+var (
+	mu       sync.Mutex
+	provider *sdktrace.TracerProvider
+)
+
+// Enable starts exporting spans, calling it again once enabled does nothing.
+// Values set by `OTEL_SERVICE_NAME` and `OTEL_RESOURCE_ATTRIBUTES` take precedence
+// over the service name and version set by the provider.
+func Enable(ctx context.Context, version string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if provider != nil {
+		return nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return err
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+// Enabled reports if spans are being exported.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return provider != nil
+}
+
+// Shutdown exports any remaining spans and stops recording new ones.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if provider == nil {
+		return nil
+	}
+
+	err := provider.Shutdown(ctx)
+	provider = nil
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	return err
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
@@ -0,0 +1,195 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a stand in for an OTLP collector that
+// accepts spans sent using protobuf over HTTP.
+type collector struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	res   map[string]*commonpb.AnyValue
+	spans []*tracepb.Span
+}
+
+func newCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err, "Must not error reading export request") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		if !assert.NoError(t, proto.Unmarshal(body, &req), "Must send a valid export request") {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		c.mu.Lock()
+		for _, rs := range req.GetResourceSpans() {
+			c.res = attributes(rs.GetResource().GetAttributes())
+			for _, ss := range rs.GetScopeSpans() {
+				c.spans = append(c.spans, ss.GetSpans()...)
+			}
+		}
+		c.mu.Unlock()
+
+		out, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(out)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *collector) Span(name string) *tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range c.spans {
+		if s.GetName() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func attributes(kvs []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	attrs := make(map[string]*commonpb.AnyValue, len(kvs))
+	for _, kv := range kvs {
+		attrs[kv.GetKey()] = kv.GetValue()
+	}
+	return attrs
+}
+
+// stubServer makes an API request when a resource is applied,
+// and fails to read every resource.
+type stubServer struct {
+	providerServer
+
+	client *http.Client
+	url    string
+}
+
+func (s *stubServer) ApplyResourceChange(ctx context.Context, _ *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"/v2/team/AAAAAAAAAAA", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	return &tfprotov5.ApplyResourceChangeResponse{}, nil
+}
+
+func (s *stubServer) ReadResource(context.Context, *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	return &tfprotov5.ReadResourceResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{Severity: tfprotov5.DiagnosticSeverityError, Summary: "Route not found"},
+		},
+	}, nil
+}
+
+func TestTracingExport(t *testing.T) {
+	c := newCollector(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", c.URL+"/v1/traces")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	var requests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first traced request is retried once.
+		if requests.Add(1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"AAAAAAAAAAA"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	rc := retryablehttp.NewClient()
+	rc.Logger = nil
+	rc.RetryWaitMin, rc.RetryWaitMax = time.Millisecond, time.Millisecond
+
+	// Spans created before tracing is enabled are not recorded.
+	_, err := (&stubServer{client: NewHTTPClient(rc), url: api.URL}).ApplyResourceChange(context.Background(), nil)
+	require.NoError(t, err, "Must not error making untraced request")
+
+	require.NoError(t, Enable(context.Background(), "v10.0.0"), "Must not error enabling tracing")
+	t.Cleanup(func() { _ = Shutdown(context.Background()) })
+	assert.True(t, Enabled(), "Must report tracing is enabled")
+	require.NoError(t, Enable(context.Background(), "v10.0.0"), "Must allow enabling tracing again")
+
+	server := NewProviderServer(func() tfprotov5.ProviderServer {
+		return &stubServer{client: NewHTTPClient(rc), url: api.URL}
+	})()
+
+	_, err = server.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     "signalfx_team",
+		PriorState:   &tfprotov5.DynamicValue{JSON: []byte(`null`)},
+		PlannedState: &tfprotov5.DynamicValue{JSON: []byte(`{"name":"my team"}`)},
+	})
+	require.NoError(t, err, "Must not error applying resource")
+
+	_, err = server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{TypeName: "signalfx_team"})
+	require.NoError(t, err, "Must not error reading resource")
+
+	require.NoError(t, Shutdown(context.Background()), "Must not error exporting spans")
+	assert.False(t, Enabled(), "Must report tracing is disabled once shutdown")
+
+	assert.Equal(t, "terraform-provider-signalfx", c.res["service.name"].GetStringValue(), "Must set the service name")
+	assert.Equal(t, "v10.0.0", c.res["service.version"].GetStringValue(), "Must set the service version")
+
+	create := c.Span("create signalfx_team")
+	require.NotNil(t, create, "Must export the operation span")
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, create.GetKind(), "Must mark the operation as handled by the provider")
+	assert.Equal(t, "signalfx_team", attributes(create.GetAttributes())["terraform.resource.type"].GetStringValue(), "Must set the resource type")
+	assert.Equal(t, "create", attributes(create.GetAttributes())["terraform.operation"].GetStringValue(), "Must set the operation")
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, create.GetStatus().GetCode(), "Must not mark the operation as failed")
+
+	call := c.Span("HTTP GET")
+	require.NotNil(t, call, "Must export the request span")
+	assert.Equal(t, create.GetTraceId(), call.GetTraceId(), "Must be part of the operation trace")
+	assert.Equal(t, create.GetSpanId(), call.GetParentSpanId(), "Must be a child of the operation")
+
+	attrs := attributes(call.GetAttributes())
+	assert.Equal(t, "/v2/team/AAAAAAAAAAA", attrs["url.path"].GetStringValue(), "Must set the route")
+	assert.Equal(t, int64(http.StatusOK), attrs["http.response.status_code"].GetIntValue(), "Must set the final status")
+	assert.Equal(t, int64(1), attrs["http.request.resend_count"].GetIntValue(), "Must count the retries")
+
+	read := c.Span("read signalfx_team")
+	require.NotNil(t, read, "Must export the failed operation span")
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, read.GetStatus().GetCode(), "Must mark the operation as failed")
+	assert.Equal(t, "Route not found", read.GetStatus().GetMessage(), "Must use the diagnostic summary")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Len(t, c.spans, 3, "Must only export the spans made while enabled")
+}
@@ -11,10 +11,17 @@ import (
 	"github.com/go-git/go-git/v5"
 )
 
+// Details describes where terraform is being run from, so that
+// resources can be tagged with the project and change that manages them.
 type Details struct {
 	name   string
 	branch string
 	dirty  bool
+
+	commit    string
+	runURL    string
+	actor     string
+	workspace string
 }
 
 func NewDetailsFromGit(repo *git.Repository) (*Details, error) {
@@ -41,14 +48,53 @@ func NewDetailsFromGit(repo *git.Repository) (*Details, error) {
 		name:   cleanGitURL(origin.Config().URLs[0]),
 		branch: head.Name().Short(),
 		dirty:  !st.IsClean(),
+		commit: head.Hash().String(),
 	}, nil
 }
 
+// Project is the name of the repository.
+func (d Details) Project() string { return d.name }
+
+// Branch is the name of the branch, or `HEAD` when it is detached.
+func (d Details) Branch() string { return d.branch }
+
+// Experimental reports if the working tree has uncommitted changes.
+func (d Details) Experimental() bool { return d.dirty }
+
+// Commit is the SHA of the commit being run.
+func (d Details) Commit() string { return d.commit }
+
+// RunURL is the URL of the CI/CD pipeline or job running terraform.
+func (d Details) RunURL() string { return d.runURL }
+
+// Actor is the user that started the CI/CD pipeline or job.
+func (d Details) Actor() string { return d.actor }
+
+// Workspace is the name of the selected terraform workspace.
+func (d Details) Workspace() string { return d.workspace }
+
+// Tags returns the details rendered using the [DefaultTagTemplates].
 func (d Details) Tags() []string {
-	return []string{
-		fmt.Sprint("project:", d.name),
-		fmt.Sprint("branch:", d.branch),
-		fmt.Sprint("experimental:", d.dirty),
+	tags, _ := defaultTagTemplate.Tags(d)
+	return tags
+}
+
+// merge overrides the details with any of the values set by other.
+func (d *Details) merge(other *Details) {
+	if other == nil {
+		return
+	}
+	for field, v := range map[*string]string{
+		&d.name:      other.name,
+		&d.branch:    other.branch,
+		&d.commit:    other.commit,
+		&d.runURL:    other.runURL,
+		&d.actor:     other.actor,
+		&d.workspace: other.workspace,
+	} {
+		if v != "" {
+			*field = v
+		}
 	}
 }
 
@@ -88,6 +88,10 @@ func TestNewDetailsFromGit(t *testing.T) {
 			t.Parallel()
 
 			actual, err := NewDetailsFromGit(tc.get(t))
+			if actual != nil {
+				assert.Len(t, actual.Commit(), 40, "Must set the commit SHA")
+				actual.commit = ""
+			}
 			if tc.errVal != "" {
 				assert.EqualError(t, err, tc.errVal, "Must match the expected value")
 			} else {
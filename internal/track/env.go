@@ -0,0 +1,94 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package track
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LookupEnvFunc is used to allow for mocking the environment variables
+// that are read, it matches the signature of [os.Getenv].
+type LookupEnvFunc func(key string) string
+
+// Getenv returns the value of the environment variable, and if there is a mock set,
+// then it will call that implementation otherwise, it will call [os.Getenv].
+func (fn LookupEnvFunc) Getenv(key string) string {
+	if fn != nil {
+		return fn(key)
+	}
+	return os.Getenv(key)
+}
+
+// NewDetailsFromEnv reads the details set by the CI/CD system running terraform.
+// GitHub Actions, GitLab CI, Jenkins, and Azure Pipelines are supported,
+// and false is returned when none of them are detected.
+func NewDetailsFromEnv(fn LookupEnvFunc) (*Details, bool) {
+	switch {
+	case fn.Getenv("GITHUB_ACTIONS") == "true":
+		d := &Details{
+			name:   fn.Getenv("GITHUB_REPOSITORY"),
+			branch: fn.Getenv("GITHUB_REF_NAME"),
+			commit: fn.Getenv("GITHUB_SHA"),
+			actor:  fn.Getenv("GITHUB_ACTOR"),
+		}
+		// Pull requests are run from a merge ref, so the source branch is used instead.
+		if head := fn.Getenv("GITHUB_HEAD_REF"); head != "" {
+			d.branch = head
+		}
+		if id := fn.Getenv("GITHUB_RUN_ID"); id != "" {
+			d.runURL = strings.Join([]string{fn.Getenv("GITHUB_SERVER_URL"), d.name, "actions", "runs", id}, "/")
+		}
+		return d, true
+	case fn.Getenv("GITLAB_CI") == "true":
+		return &Details{
+			name:   fn.Getenv("CI_PROJECT_PATH"),
+			branch: fn.Getenv("CI_COMMIT_REF_NAME"),
+			commit: fn.Getenv("CI_COMMIT_SHA"),
+			runURL: fn.Getenv("CI_PIPELINE_URL"),
+			actor:  fn.Getenv("GITLAB_USER_LOGIN"),
+		}, true
+	case fn.Getenv("JENKINS_URL") != "":
+		return &Details{
+			name:   cleanGitURL(fn.Getenv("GIT_URL")),
+			branch: strings.TrimPrefix(fn.Getenv("GIT_BRANCH"), "origin/"),
+			commit: fn.Getenv("GIT_COMMIT"),
+			runURL: fn.Getenv("BUILD_URL"),
+			// Only set when the build user vars plugin is installed.
+			actor: fn.Getenv("BUILD_USER_ID"),
+		}, true
+	case strings.EqualFold(fn.Getenv("TF_BUILD"), "true"):
+		d := &Details{
+			name:   fn.Getenv("BUILD_REPOSITORY_NAME"),
+			branch: fn.Getenv("BUILD_SOURCEBRANCHNAME"),
+			commit: fn.Getenv("BUILD_SOURCEVERSION"),
+			actor:  fn.Getenv("BUILD_REQUESTEDFOR"),
+		}
+		if id := fn.Getenv("BUILD_BUILDID"); id != "" {
+			d.runURL = fn.Getenv("SYSTEM_COLLECTIONURI") + fn.Getenv("SYSTEM_TEAMPROJECT") + "/_build/results?buildId=" + id
+		}
+		return d, true
+	}
+	return nil, false
+}
+
+// readWorkspace returns the name of the selected terraform workspace,
+// which is set by `TF_WORKSPACE` or `terraform workspace select`.
+func readWorkspace(fn LookupEnvFunc) string {
+	if ws := fn.Getenv("TF_WORKSPACE"); ws != "" {
+		return ws
+	}
+
+	dir := fn.Getenv("TF_DATA_DIR")
+	if dir == "" {
+		dir = ".terraform"
+	}
+	if ws, err := os.ReadFile(filepath.Join(dir, "environment")); err == nil {
+		if ws := strings.TrimSpace(string(ws)); ws != "" {
+			return ws
+		}
+	}
+	return "default"
+}
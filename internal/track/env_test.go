@@ -0,0 +1,158 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package track
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDetailsFromEnv(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		env    map[string]string
+		expect *Details
+		found  bool
+	}{
+		{
+			name:   "no ci system",
+			env:    map[string]string{},
+			expect: nil,
+			found:  false,
+		},
+		{
+			name: "github actions",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_REPOSITORY": "splunk/my-project",
+				"GITHUB_REF_NAME":   "1/merge",
+				"GITHUB_HEAD_REF":   "feature",
+				"GITHUB_SHA":        "abc123",
+				"GITHUB_ACTOR":      "octocat",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_RUN_ID":     "42",
+			},
+			expect: &Details{
+				name:   "splunk/my-project",
+				branch: "feature",
+				commit: "abc123",
+				runURL: "https://github.com/splunk/my-project/actions/runs/42",
+				actor:  "octocat",
+			},
+			found: true,
+		},
+		{
+			name: "gitlab ci",
+			env: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_PROJECT_PATH":    "group/my-project",
+				"CI_COMMIT_REF_NAME": "main",
+				"CI_COMMIT_SHA":      "abc123",
+				"CI_PIPELINE_URL":    "https://gitlab.com/group/my-project/-/pipelines/42",
+				"GITLAB_USER_LOGIN":  "user",
+			},
+			expect: &Details{
+				name:   "group/my-project",
+				branch: "main",
+				commit: "abc123",
+				runURL: "https://gitlab.com/group/my-project/-/pipelines/42",
+				actor:  "user",
+			},
+			found: true,
+		},
+		{
+			name: "jenkins",
+			env: map[string]string{
+				"JENKINS_URL":   "https://jenkins.example.com/",
+				"GIT_URL":       "git@github.com:splunk/my-project.git",
+				"GIT_BRANCH":    "origin/main",
+				"GIT_COMMIT":    "abc123",
+				"BUILD_URL":     "https://jenkins.example.com/job/my-project/42/",
+				"BUILD_USER_ID": "user",
+			},
+			expect: &Details{
+				name:   "splunk/my-project",
+				branch: "main",
+				commit: "abc123",
+				runURL: "https://jenkins.example.com/job/my-project/42/",
+				actor:  "user",
+			},
+			found: true,
+		},
+		{
+			name: "azure pipelines",
+			env: map[string]string{
+				"TF_BUILD":               "True",
+				"BUILD_REPOSITORY_NAME":  "my-project",
+				"BUILD_SOURCEBRANCHNAME": "main",
+				"BUILD_SOURCEVERSION":    "abc123",
+				"BUILD_REQUESTEDFOR":     "User Name",
+				"BUILD_BUILDID":          "42",
+				"SYSTEM_COLLECTIONURI":   "https://dev.azure.com/org/",
+				"SYSTEM_TEAMPROJECT":     "project",
+			},
+			expect: &Details{
+				name:   "my-project",
+				branch: "main",
+				commit: "abc123",
+				runURL: "https://dev.azure.com/org/project/_build/results?buildId=42",
+				actor:  "User Name",
+			},
+			found: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, found := NewDetailsFromEnv(func(key string) string {
+				return tc.env[key]
+			})
+			assert.Equal(t, tc.found, found, "Must match the expected found value")
+			assert.Equal(t, tc.expect, actual, "Must match the expected details")
+		})
+	}
+}
+
+func TestReadWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "environment"), []byte("staging\n"), 0o600), "Must not error writing environment file")
+
+	for _, tc := range []struct {
+		name   string
+		env    map[string]string
+		expect string
+	}{
+		{
+			name:   "no workspace selected",
+			env:    map[string]string{"TF_DATA_DIR": t.TempDir()},
+			expect: "default",
+		},
+		{
+			name:   "workspace selected",
+			env:    map[string]string{"TF_DATA_DIR": dir},
+			expect: "staging",
+		},
+		{
+			name:   "workspace set by environment",
+			env:    map[string]string{"TF_DATA_DIR": dir, "TF_WORKSPACE": "production"},
+			expect: "production",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, readWorkspace(func(key string) string {
+				return tc.env[key]
+			}), "Must match the expected workspace")
+		})
+	}
+}
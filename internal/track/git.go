@@ -23,6 +23,30 @@ func ReadGitDetails(ctx context.Context) (*Details, error) {
 	return (GitRepositoryFunc)(nil).ReadDetails(ctx)
 }
 
+// ReadDetails reads the git details of the working directory, and then overrides them
+// with any details set by the CI/CD system running terraform, since CI/CD checkouts are often
+// shallow clones with a detached head. The git details are not required when the CI/CD details are found.
+func ReadDetails(ctx context.Context) (*Details, error) {
+	return readDetails(ctx, nil, nil)
+}
+
+func readDetails(ctx context.Context, repo GitRepositoryFunc, env LookupEnvFunc) (*Details, error) {
+	details, err := repo.ReadDetails(ctx)
+	ci, found := NewDetailsFromEnv(env)
+	if err != nil {
+		if !found {
+			return nil, err
+		}
+		tflog.Debug(ctx, "Unable to load git details, using CI/CD details", tfext.ErrorLogFields(err))
+		details = &Details{}
+	}
+
+	details.merge(ci)
+	details.workspace = readWorkspace(env)
+
+	return details, nil
+}
+
 // Open will read the git repo details, and if there is a mock set, then it will call that implementation
 // otherwise, it will call the git implementation.
 func (fn GitRepositoryFunc) Open(path string, opts *git.PlainOpenOptions) (*git.Repository, error) {
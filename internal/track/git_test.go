@@ -26,6 +26,58 @@ func TestReadGitDetails(t *testing.T) {
 	})
 }
 
+func TestReadDetails(t *testing.T) {
+	t.Parallel()
+
+	failed := GitRepositoryFunc(func(string, *git.PlainOpenOptions) (*git.Repository, error) {
+		return nil, errors.New("repository does not exist")
+	})
+
+	for _, tc := range []struct {
+		name   string
+		env    map[string]string
+		expect *Details
+		errVal string
+	}{
+		{
+			name:   "no git or ci details",
+			env:    map[string]string{},
+			expect: nil,
+			errVal: "repository does not exist",
+		},
+		{
+			name: "ci details without git",
+			env: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_PROJECT_PATH":    "group/my-project",
+				"CI_COMMIT_REF_NAME": "main",
+				"CI_COMMIT_SHA":      "abc123",
+				"TF_WORKSPACE":       "production",
+			},
+			expect: &Details{
+				name:      "group/my-project",
+				branch:    "main",
+				commit:    "abc123",
+				workspace: "production",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := readDetails(t.Context(), failed, func(key string) string {
+				return tc.env[key]
+			})
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, err, "Must not error reading details")
+			}
+			assert.Equal(t, tc.expect, actual, "Must match the expected details")
+		})
+	}
+}
+
 func TestGitRepositoryFuncOpen(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +164,10 @@ func TestGitRepositoryReadDetails(t *testing.T) {
 			})
 
 			actual, err := fn.ReadDetails(t.Context())
+			if actual != nil {
+				assert.Len(t, actual.Commit(), 40, "Must set the commit SHA")
+				actual.commit = ""
+			}
 			if tc.errVal != "" {
 				assert.EqualError(t, err, tc.errVal, "Must match the expecting error")
 			} else {
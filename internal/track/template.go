@@ -0,0 +1,67 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package track
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultTagTemplates are used when no tag templates are configured.
+// The commit, run, actor, and workspace change on every run, so they
+// are only tagged when configured to avoid planning an update each time.
+var DefaultTagTemplates = []string{
+	"project:{{ .Project }}",
+	"branch:{{ .Branch }}",
+	"experimental:{{ .Experimental }}",
+}
+
+var defaultTagTemplate = func() *TagTemplate {
+	tt, err := NewTagTemplate(DefaultTagTemplates...)
+	if err != nil {
+		panic(err)
+	}
+	return tt
+}()
+
+// TagTemplate renders the tracking details into tags,
+// with each template producing a single tag.
+type TagTemplate struct {
+	templates []*template.Template
+}
+
+// NewTagTemplate parses each of the templates,
+// the [DefaultTagTemplates] are used when none are provided.
+func NewTagTemplate(templates ...string) (*TagTemplate, error) {
+	if len(templates) == 0 {
+		templates = DefaultTagTemplates
+	}
+
+	tt := &TagTemplate{}
+	for i, text := range templates {
+		t, err := template.New(fmt.Sprint("tag.", i)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag template %q: %w", text, err)
+		}
+		tt.templates = append(tt.templates, t)
+	}
+	return tt, nil
+}
+
+// Tags renders the details using each template,
+// any template that renders as empty is skipped.
+func (tt *TagTemplate) Tags(d Details) ([]string, error) {
+	var tags []string
+	for _, t := range tt.templates {
+		var sb strings.Builder
+		if err := t.Execute(&sb, d); err != nil {
+			return nil, fmt.Errorf("unable to render tag template: %w", err)
+		}
+		if tag := strings.TrimSpace(sb.String()); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
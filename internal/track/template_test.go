@@ -0,0 +1,78 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package track
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagTemplate(t *testing.T) {
+	t.Parallel()
+
+	details := Details{
+		name:      "splunk/my-project",
+		branch:    "main",
+		commit:    "abc123",
+		runURL:    "https://github.com/splunk/my-project/actions/runs/42",
+		workspace: "default",
+	}
+
+	for _, tc := range []struct {
+		name      string
+		templates []string
+		expect    []string
+		errVal    string
+	}{
+		{
+			name:      "default templates",
+			templates: nil,
+			expect: []string{
+				"project:splunk/my-project",
+				"branch:main",
+				"experimental:false",
+			},
+		},
+		{
+			name: "custom templates",
+			templates: []string{
+				"repo:{{ .Project }}",
+				"{{ with .Actor }}actor:{{ . }}{{ end }}",
+				"env:{{ .Workspace }}",
+			},
+			expect: []string{
+				"repo:splunk/my-project",
+				"env:default",
+			},
+		},
+		{
+			name:      "invalid template",
+			templates: []string{"repo:{{ .Project "},
+			errVal:    `invalid tag template "repo:{{ .Project ": template: tag.0:1: unclosed action`,
+		},
+		{
+			name:      "unknown detail",
+			templates: []string{"{{ .Unknown }}"},
+			errVal:    `unable to render tag template: template: tag.0:1:3: executing "tag.0" at <.Unknown>: can't evaluate field Unknown in type track.Details`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt, err := NewTagTemplate(tc.templates...)
+			if err == nil {
+				var tags []string
+				tags, err = tt.Tags(details)
+				assert.Equal(t, tc.expect, tags, "Must match the expected tags")
+			}
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error rendering tags")
+			}
+		})
+	}
+}
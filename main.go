@@ -14,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 
 	internalframework "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tracing"
 	"github.com/splunk-terraform/terraform-provider-signalfx/signalfx"
 )
 
@@ -30,9 +32,14 @@ var (
 func main() {
 	flag.Parse()
 
+	legacy := signalfx.Provider() // Provider to be sunset during the migration of 10.x
+
 	providers := []func() tfprotov5.ProviderServer{
-		providerserver.NewProtocol5(internalframework.NewProvider(Version)),
-		signalfx.Provider().GRPCProvider, // Provider to be sunset during the migration of 10.x
+		providerserver.NewProtocol5(internalframework.NewProvider(
+			Version,
+			internalframework.WithProviderLegacyResources(legacy.ResourcesMap),
+		)),
+		legacy.GRPCProvider,
 	}
 
 	mux, err := tf5muxserver.NewMuxServer(context.Background(), providers...)
@@ -45,7 +52,16 @@ func main() {
 		opts = append(opts, tf5server.WithManagedDebug())
 	}
 
-	if err = tf5server.Serve(ProviderRegistry, mux.ProviderServer, opts...); err != nil {
+	err = tf5server.Serve(ProviderRegistry, tracing.NewProviderServer(mux.ProviderServer), opts...)
+	// Export any spans that remain from the operations performed before exiting.
+	if err := tracing.Shutdown(context.Background()); err != nil {
+		log.Println("Unable to export traces:", err)
+	}
+	// Session tokens created from the email and password are no longer needed.
+	if err := pmeta.CloseSessionTokens(context.Background()); err != nil {
+		log.Println("Unable to delete session tokens:", err)
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -4,13 +4,14 @@
 package signalfx
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 func handleIntegrationChange(err error, d *schema.ResourceData, in interface{}) bool {
@@ -24,19 +25,20 @@ func handleIntegrationChange(err error, d *schema.ResourceData, in interface{})
 	return true
 }
 
-func logIntegrationData(format string, serviceName string, out interface{}) {
-	debugOutput, _ := json.Marshal(out)
-	log.Printf(format, serviceName, string(debugOutput))
+// logIntegrationData writes the integration as JSON, the values of the
+// sensitive fields are redacted so they are not written to the logs.
+func logIntegrationData(format string, serviceName string, out interface{}, sensitive ...string) {
+	log.Printf(format, serviceName, tfext.RedactedJSON(out, sensitive...))
 }
 
-func logIntegrationResponse(in interface{}, serviceName string) {
-	logIntegrationData("[DEBUG] SignalFx: Got %s Integration to enState: %s", serviceName, in)
+func logIntegrationResponse(in interface{}, serviceName string, sensitive ...string) {
+	logIntegrationData("[DEBUG] SignalFx: Got %s Integration to enState: %s", serviceName, in, sensitive...)
 }
 
-func logIntegrationCreateRequest(out interface{}, serviceName string) {
-	logIntegrationData("[DEBUG] SignalFx: Create %s Integration Payload: %s", serviceName, out)
+func logIntegrationCreateRequest(out interface{}, serviceName string, sensitive ...string) {
+	logIntegrationData("[DEBUG] SignalFx: Create %s Integration Payload: %s", serviceName, out, sensitive...)
 }
 
-func logIntegrationUpdateRequest(out interface{}, serviceName string) {
-	logIntegrationData("[DEBUG] SignalFx: Update %s Integration Payload: %s", serviceName, out)
+func logIntegrationUpdateRequest(out interface{}, serviceName string, sensitive ...string) {
+	logIntegrationData("[DEBUG] SignalFx: Update %s Integration Payload: %s", serviceName, out, sensitive...)
 }
@@ -5,7 +5,7 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -28,9 +28,12 @@ import (
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchiveexemptmetric"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchivesettings"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/organization"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/tagging"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tracing"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 	"github.com/splunk-terraform/terraform-provider-signalfx/version"
 )
@@ -55,14 +58,29 @@ func Provider() *schema.Provider {
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", "https://api.signalfx.com"),
+				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", nil),
 				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
 			},
+			"credential_process": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SFX_CREDENTIAL_PROCESS", nil),
+				ConflictsWith: []string{"auth_token"},
+				Description:   "Command that is run to fetch the auth token, and optionally the API URL, written to stdout as JSON with the fields auth_token, api_url, and expires_at. The command is run again once the token expires. Conflicts with auth_token",
+			},
+			"realm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SFX_REALM", nil),
+				ConflictsWith: []string{"api_url"},
+				ValidateFunc:  validation.StringMatch(pmeta.RealmPattern, "must be a realm name such as us1"),
+				Description:   "Realm of your Splunk Observability Cloud org, such as us1, used to set the API URL and application URL. Conflicts with api_url",
+			},
 			"custom_app_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Deprecated:  "Remove the definition, the provider will automatically populate the custom app URL as needed",
-				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", "https://app.signalfx.com"),
+				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", nil),
 				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO",
 			},
 			"timeout_seconds": {
@@ -89,6 +107,20 @@ func Provider() *schema.Provider {
 				Default:     30,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests sent to the API every second, shared across all resources. Defaults to 0 which does not limit requests",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests to the API that can be in flight at once, shared across all resources and data sources. Defaults to 0 which does not limit requests",
+			},
 			"email": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -108,6 +140,12 @@ func Provider() *schema.Provider {
 					"organization_id",
 				},
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_PROFILE", nil),
+				Description: "Name of the profile to read from the provider configuration files, /etc/signalfx.conf and ~/.signalfx.conf. Uses the default profile when not set",
+			},
 			"organization_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -139,6 +177,16 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Allows for teams to be defined at a provider level, and apply to all applicable resources created.",
 			},
+			"tracking_tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Optional:    true,
+				Description: "Templates of the tags added to resources by the provider.track feature preview, rendered using the Project, Branch, Experimental, Commit, RunURL, Actor, and Workspace details. Tags that render as empty are not added. Defaults to the project, branch, and experimental tags",
+			},
+			"default_rule": rule.NewDefaultSchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"signalfx_dimension_values":      dataSourceDimensionValues(),
@@ -182,6 +230,26 @@ func Provider() *schema.Provider {
 		ConfigureFunc: signalfxConfigure,
 	}
 
+	for _, name := range []string{
+		"signalfx_dashboard",
+		"signalfx_dashboard_group",
+		"signalfx_detector",
+		"signalfx_event_feed_chart",
+		"signalfx_heatmap_chart",
+		"signalfx_list_chart",
+		"signalfx_single_value_chart",
+		"signalfx_table_chart",
+		"signalfx_text_chart",
+		"signalfx_time_chart",
+	} {
+		sfxProvider.ResourcesMap[name] = resourceIdentityDecorator(sfxProvider.ResourcesMap[name])
+	}
+
+	// Only the resources that have tags or teams are decorated.
+	for name, res := range sfxProvider.ResourcesMap {
+		sfxProvider.ResourcesMap[name] = tagging.Decorate(res)
+	}
+
 	for _, res := range sfxProvider.ResourcesMap {
 		res = deprecatedMethodDecorator(res)
 	}
@@ -195,11 +263,14 @@ func Provider() *schema.Provider {
 
 func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
 	config := signalfxConfig{
-		Email:          data.Get("email").(string),
-		Password:       data.Get("password").(string),
-		OrganizationID: data.Get("organization_id").(string),
-		Tags:           convert.SliceAll(data.Get("tags").([]any), convert.ToString),
-		Teams:          convert.SliceAll(data.Get("teams").([]any), convert.ToString),
+		Email:             data.Get("email").(string),
+		Password:          data.Get("password").(string),
+		OrganizationID:    data.Get("organization_id").(string),
+		Realm:             data.Get("realm").(string),
+		Profile:           data.Get("profile").(string),
+		CredentialProcess: data.Get("credential_process").(string),
+		Tags:              convert.SliceAll(data.Get("tags").([]any), convert.ToString),
+		Teams:             convert.SliceAll(data.Get("teams").([]any), convert.ToString),
 	}
 
 	// /etc/signalfx.conf has the lowest priority
@@ -232,22 +303,60 @@ func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
-	// provider is the top priority
-	if token, ok := data.GetOk("auth_token"); ok {
+	if config.Profile != "" && !config.ProfileLoaded() {
+		return nil, fmt.Errorf("profile %q is not defined within the provider configuration files", config.Profile)
+	}
+
+	// A credential process takes precedence over the files
+	if err := pmeta.CredentialProcessMetaLookupFunc().Do(context.Background(), &config); err != nil {
+		return nil, err
+	}
+
+	// provider is the top priority, but the credential process
+	// takes precedence over the auth token set within the environment.
+	if token, ok := data.GetOk("auth_token"); ok && config.Credentials == nil {
 		config.AuthToken = token.(string)
 	}
 
+	// ConflictsWith does not consider the realm set within the environment,
+	// so the conflict is checked here to match the framework provider.
+	if _, ok := data.GetOk("realm"); ok && configuredAPIURL(data) {
+		return nil, pmeta.ErrConflictingAPIEndpoint
+	}
+
 	if url, ok := data.GetOk("api_url"); ok {
 		config.APIURL = url.(string)
 	}
 
+	if realm, ok := data.GetOk("realm"); ok {
+		config.Realm = realm.(string)
+		config.APIURL = pmeta.RealmAPIURL(config.Realm)
+	}
+
+	// The API URL can be set by a config file, the defaults are used when it was not.
+	switch {
+	case config.APIURL != "":
+	case config.Realm != "":
+		config.APIURL = pmeta.RealmAPIURL(config.Realm)
+	default:
+		config.APIURL = "https://api.signalfx.com"
+	}
+
 	if err = config.Validate(); err != nil {
 		return nil, err
 	}
 
+	if config.DefaultRule, err = rule.DecodeDefault(data); err != nil {
+		return nil, err
+	}
+
 	if site, err := config.DetectCustomAPPURL(context.TODO()); err != nil {
 		if app, ok := data.GetOk("custom_app_url"); ok {
 			config.CustomAppURL = app.(string)
+		} else if config.CustomAppURL == "" && config.Realm != "" {
+			config.CustomAppURL = pmeta.RealmAppURL(config.Realm)
+		} else if config.CustomAppURL == "" {
+			config.CustomAppURL = "https://app.signalfx.com"
 		}
 	} else {
 		config.CustomAppURL = site
@@ -270,19 +379,34 @@ func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
 	retryMaxAttempts := data.Get("retry_max_attempts").(int)
 	retryWaitMinSeconds := data.Get("retry_wait_min_seconds").(int)
 	retryWaitMaxSeconds := data.Get("retry_wait_max_seconds").(int)
+	requestsPerSecond := data.Get("requests_per_second").(float64)
+	maxConcurrentRequests := data.Get("max_concurrent_requests").(int)
 	log.Printf("[DEBUG] SignalFx: HTTP Timeout is %d seconds", totalTimeoutSeconds)
 	log.Printf("[DEBUG] SignalFx: HTTP max retry attempts: %d", retryMaxAttempts)
 	log.Printf("[DEBUG] SignalFx: HTTP retry wait min is %d seconds", retryWaitMinSeconds)
 	log.Printf("[DEBUG] SignalFx: HTTP retry wait max is %d seconds", retryWaitMaxSeconds)
+	log.Printf("[DEBUG] SignalFx: HTTP requests per second is %g", requestsPerSecond)
+	log.Printf("[DEBUG] SignalFx: HTTP max concurrent requests is %d", maxConcurrentRequests)
 
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = retryMaxAttempts
 	retryClient.RetryWaitMin = time.Second * time.Duration(int64(retryWaitMinSeconds))
 	retryClient.RetryWaitMax = time.Second * time.Duration(int64(retryWaitMaxSeconds))
+	retryClient.Backoff = pmeta.RetryBackoff
 	retryClient.HTTPClient.Timeout = time.Second * time.Duration(int64(totalTimeoutSeconds))
-	retryClient.HTTPClient.Transport = netTransport
-	standardClient := retryClient.StandardClient()
+	config.Cache = pmeta.GetResponseCache()
+	config.NotificationTargets = pmeta.NewNotificationTargets()
+	limits := pmeta.GetRequestLimits()
+	limits.Configure(pmeta.TransportLimits{
+		RequestsPerSecond:     requestsPerSecond,
+		MaxConcurrentRequests: maxConcurrentRequests,
+		MaxRetryWait:          retryClient.RetryWaitMax,
+	})
+	retryClient.HTTPClient.Transport = config.LoadSessionSource().Transport(config.Credentials.Transport(config.Cache.Transport(limits.Transport(netTransport))))
+	config.HTTPClient = tracing.NewHTTPClient(retryClient)
 
+	// The session token is created once the HTTP client is configured,
+	// so it shares the same transport, retries and request limits.
 	token, err := config.LoadSessionToken(context.Background())
 	if err != nil {
 		return nil, err
@@ -291,7 +415,7 @@ func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
 	client, err := sfx.NewClient(
 		token,
 		sfx.APIUrl(config.APIURL),
-		sfx.HTTPClient(standardClient),
+		sfx.HTTPClient(config.HTTPClient),
 		sfx.UserAgent(providerUserAgent),
 	)
 	if err != nil {
@@ -311,13 +435,31 @@ func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
 		}
 	}
 
+	if gate, ok := pmeta.LoadPreviewRegistry(context.TODO(), config).Get(feature.PreviewProviderCache); ok {
+		config.Cache.SetEnabled(gate.Enabled())
+	}
+
+	if gate, ok := pmeta.LoadPreviewRegistry(context.TODO(), config).Get(feature.PreviewProviderTracing); ok && gate.Enabled() {
+		if err := tracing.Enable(context.TODO(), pv); err != nil {
+			log.Printf("[WARN] Unable to enable tracing: %v", err)
+		}
+	}
+
 	if gate, ok := pmeta.LoadPreviewRegistry(context.TODO(), config).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(context.TODO())
+		tt, err := track.NewTagTemplate(convert.SliceAll(data.Get("tracking_tags").([]any), convert.ToString)...)
+		if err != nil {
+			return nil, err
+		}
+		tracking, err := track.ReadDetails(context.TODO())
 		if err != nil {
-			log.Printf("[INFO] Unable to load git details, skipping: %v", err)
-			tflog.Info(context.TODO(), "Unable to load git details, skipping", tfext.ErrorLogFields(err))
+			log.Printf("[INFO] Unable to load tracking details, skipping: %v", err)
+			tflog.Info(context.TODO(), "Unable to load tracking details, skipping", tfext.ErrorLogFields(err))
 		} else {
-			config.Tags = append(config.Tags, tracking.Tags()...)
+			tags, err := tt.Tags(*tracking)
+			if err != nil {
+				return nil, err
+			}
+			config.Tags = append(config.Tags, tags...)
 		}
 	}
 
@@ -329,7 +471,11 @@ func readConfigFile(configPath string, config *signalfxConfig) error {
 	if err != nil {
 		return fmt.Errorf("failed to open config file. %s", err.Error())
 	}
-	err = json.Unmarshal(configFile, config)
+	err = config.DecodeConfigFile(configFile)
+	if errors.Is(err, pmeta.ErrProfileNotFound) {
+		// The selected profile can be defined within any of the config files.
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to parse config file. %s", err.Error())
 	}
@@ -373,7 +519,12 @@ func readNetrcFile(config *signalfxConfig) error {
 		return fmt.Errorf("error parsing netrc file at %q: %s", path, err)
 	}
 
-	machine := netRC.FindMachine("api.signalfx.com")
+	var machine *netrc.Machine
+	for _, name := range config.NetrcMachines() {
+		if machine = netRC.FindMachine(name); machine != nil && !machine.IsDefault() {
+			break
+		}
+	}
 	if machine == nil {
 		// Machine not found, no problem
 		return nil
@@ -383,3 +534,13 @@ func readNetrcFile(config *signalfxConfig) error {
 	config.AuthToken = machine.Password
 	return nil
 }
+
+// configuredAPIURL reports if the api_url was set within the provider configuration,
+// rather than by the SFX_API_URL environment variable.
+func configuredAPIURL(data *schema.ResourceData) bool {
+	raw := data.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return false
+	}
+	return !raw.GetAttr("api_url").IsNull()
+}
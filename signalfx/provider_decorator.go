@@ -4,10 +4,14 @@
 package signalfx
 
 import (
+	"context"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 func deprecatedMethodDecorator(res *schema.Resource) *schema.Resource {
@@ -51,3 +55,111 @@ func wrapDeprecatedMethod[Func schema.CreateFunc | schema.UpdateFunc | schema.Re
 		return fmt.Errorf("%w\nAPI response: %s", err, rerr.Details())
 	}
 }
+
+// resourceIdentityDecorator adds a resource identity made up of the resource id,
+// and the organization and realm that own it, matching the identity of the framework resources
+// so that the resource can be imported by identity, or discovered using `terraform query`.
+// The identity is updated after each successful create, read, or update.
+func resourceIdentityDecorator(res *schema.Resource) *schema.Resource {
+	if res == nil {
+		return nil
+	}
+
+	res.Identity = &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"id": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       "The unique identifier for the resource.",
+				},
+				"org_id": {
+					Type:              schema.TypeString,
+					OptionalForImport: true,
+					Description:       "The ID of the organization that owns the resource.",
+				},
+				"realm": {
+					Type:              schema.TypeString,
+					OptionalForImport: true,
+					Description:       "The realm of the organization that owns the resource.",
+				},
+			}
+		},
+	}
+
+	if res.Importer != nil {
+		res.Importer = &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughWithIdentity("id"),
+		}
+	}
+
+	if res.Create != nil {
+		res.Create = wrapResourceIdentityMethod(res.Create)
+	}
+
+	if res.Read != nil {
+		res.Read = wrapResourceIdentityMethod(res.Read)
+	}
+
+	if res.Update != nil {
+		res.Update = wrapResourceIdentityMethod(res.Update)
+	}
+
+	return res
+}
+
+func wrapResourceIdentityMethod[Func schema.CreateFunc | schema.UpdateFunc | schema.ReadFunc](fn Func) Func {
+	return func(data *schema.ResourceData, meta any) error {
+		if err := fn(data, meta); err != nil {
+			return err
+		}
+
+		// The resource has been removed from the API
+		// so there is no identity to update.
+		if data.Id() == "" {
+			return nil
+		}
+
+		identity, err := data.Identity()
+		if err != nil {
+			return err
+		}
+
+		values := map[string]string{"id": data.Id()}
+		if m, ok := meta.(*pmeta.Meta); ok {
+			// The organization is looked up the first time it is needed when it has not been configured.
+			orgID, err := m.LookupOrganizationID(context.Background())
+			if err != nil {
+				log.Printf("[WARN] SignalFx: Unable to look up the organization ID: %v", err)
+			}
+			values["org_id"] = orgID
+			values["realm"] = m.LoadRealm()
+		}
+
+		// The resource is not able to be managed with the current provider configuration
+		// when the existing identity belongs to a different organization or realm.
+		for _, field := range []struct{ name, key string }{
+			{name: "organization", key: "org_id"},
+			{name: "realm", key: "realm"},
+		} {
+			existing, _ := identity.Get(field.key).(string)
+			if existing == "" || values[field.key] == "" || existing == values[field.key] {
+				continue
+			}
+			return fmt.Errorf(
+				"resource %q was created in the %s %q, however the provider is configured to use %q",
+				data.Id(), field.name, existing, values[field.key],
+			)
+		}
+
+		for _, key := range []string{"id", "org_id", "realm"} {
+			if values[key] == "" {
+				continue
+			}
+			if err := identity.Set(key, values[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -8,8 +8,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/signalfx/signalfx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 func HelperValidateMethodCalled[Func schema.CreateFunc | schema.ReadFunc | schema.UpdateFunc | schema.DeleteFunc](tb testing.TB) Func {
@@ -132,3 +136,111 @@ func TestWrapDeprecatedMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceIdentityDecorator(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, resourceIdentityDecorator(nil), "Must return nil when no resource is provided")
+
+	for _, tc := range []struct {
+		name     string
+		id       string
+		err      error
+		meta     any
+		existing map[string]string
+		identity map[string]any
+		errVal   string
+	}{
+		{
+			name:     "identity set from id",
+			id:       "AAAAAAAAAAA",
+			identity: map[string]any{"id": "AAAAAAAAAAA", "org_id": "", "realm": ""},
+		},
+		{
+			name: "identity set from meta",
+			id:   "AAAAAAAAAAA",
+			meta: &pmeta.Meta{OrganizationID: "org-1", Realm: "us1"},
+			identity: map[string]any{
+				"id":     "AAAAAAAAAAA",
+				"org_id": "org-1",
+				"realm":  "us1",
+			},
+		},
+		{
+			name:     "existing identity without organization",
+			id:       "AAAAAAAAAAA",
+			meta:     &pmeta.Meta{OrganizationID: "org-1", Realm: "us1"},
+			existing: map[string]string{"id": "AAAAAAAAAAA"},
+			identity: map[string]any{
+				"id":     "AAAAAAAAAAA",
+				"org_id": "org-1",
+				"realm":  "us1",
+			},
+		},
+		{
+			name:     "existing identity from another organization",
+			id:       "AAAAAAAAAAA",
+			meta:     &pmeta.Meta{OrganizationID: "org-1", Realm: "us1"},
+			existing: map[string]string{"id": "AAAAAAAAAAA", "org_id": "org-2", "realm": "us1"},
+			identity: map[string]any{"id": "AAAAAAAAAAA", "org_id": "org-2", "realm": "us1"},
+			errVal:   `resource "AAAAAAAAAAA" was created in the organization "org-2", however the provider is configured to use "org-1"`,
+		},
+		{
+			name:     "existing identity from another realm",
+			id:       "AAAAAAAAAAA",
+			meta:     &pmeta.Meta{OrganizationID: "org-1", Realm: "us1"},
+			existing: map[string]string{"id": "AAAAAAAAAAA", "org_id": "org-1", "realm": "eu0"},
+			identity: map[string]any{"id": "AAAAAAAAAAA", "org_id": "org-1", "realm": "eu0"},
+			errVal:   `resource "AAAAAAAAAAA" was created in the realm "eu0", however the provider is configured to use "us1"`,
+		},
+		{
+			name:     "resource removed",
+			id:       "",
+			identity: map[string]any{"id": "", "org_id": "", "realm": ""},
+		},
+		{
+			name:     "method returns error",
+			id:       "AAAAAAAAAAA",
+			err:      fmt.Errorf("failed"),
+			identity: map[string]any{"id": "", "org_id": "", "realm": ""},
+			errVal:   "failed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := resourceIdentityDecorator(&schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Optional: true},
+				},
+				Importer: &schema.ResourceImporter{
+					State: schema.ImportStatePassthrough,
+				},
+				Read: func(data *schema.ResourceData, meta any) error {
+					if tc.err != nil {
+						return tc.err
+					}
+					data.SetId(tc.id)
+					return nil
+				},
+			})
+			require.NotNil(t, res.Identity, "Must have an identity configured")
+			require.NotNil(t, res.Importer.StateContext, "Must import using the identity")
+
+			data := res.Data(&terraform.InstanceState{Identity: tc.existing})
+
+			err := res.Read(data, tc.meta)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must return the expected error")
+			} else {
+				assert.NoError(t, err, "Must not return an error")
+			}
+
+			identity, err := data.Identity()
+			require.NoError(t, err, "Must be able to read identity")
+			for key, value := range tc.identity {
+				assert.Equal(t, value, identity.Get(key), "Must match the expected identity %q", key)
+			}
+		})
+	}
+}
@@ -4,17 +4,21 @@
 package signalfx
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	sfx "github.com/signalfx/signalfx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var OldSystemConfigPath = SystemConfigPath
@@ -53,8 +57,8 @@ func createTempConfigFile(tb testing.TB, content string, name string) (*os.File,
 }
 
 func newTestClient() *sfx.Client {
-	apiURL, _ := sfxProvider.Schema["api_url"].DefaultFunc()
-	client, _ := sfx.NewClient(os.Getenv("SFX_AUTH_TOKEN"), sfx.APIUrl(apiURL.(string)))
+	apiURL := cmp.Or(os.Getenv("SFX_API_URL"), "https://api.signalfx.com")
+	client, _ := sfx.NewClient(os.Getenv("SFX_AUTH_TOKEN"), sfx.APIUrl(apiURL))
 	return client
 }
 
@@ -149,6 +153,149 @@ func TestProviderConfigureFromTerraformOnly(t *testing.T) {
 	assert.Equal(t, "https://myotherdomain.signalfx.com", configuration.CustomAppURL)
 }
 
+func TestProviderConfigureFromRealm(t *testing.T) {
+	defer resetGlobals()
+	SystemConfigPath = "filedoesnotexist"
+	HomeConfigPath = "filedoesnotexist"
+
+	t.Setenv("SFX_API_URL", "https://api.signalfx.com")
+	t.Setenv("SFX_CUSTOM_APP_URL", "")
+	t.Setenv("SFX_REALM", "")
+
+	raw := map[string]interface{}{
+		"auth_token": "XXX",
+		"realm":      "eu0",
+	}
+
+	rp := Provider()
+	diag := rp.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	meta := rp.Meta()
+	if meta == nil {
+		t.Fatalf("Expected metadata, got nil. err: %s", spew.Sdump(diag))
+	}
+	configuration := meta.(*signalfxConfig)
+	assert.Equal(t, "eu0", configuration.Realm)
+	assert.Equal(t, "https://api.eu0.signalfx.com", configuration.APIURL)
+	assert.Equal(t, "https://app.eu0.signalfx.com", configuration.CustomAppURL)
+}
+
+func TestProviderConfigureRealmFromEnvironment(t *testing.T) {
+	defer resetGlobals()
+	SystemConfigPath = "filedoesnotexist"
+	HomeConfigPath = "filedoesnotexist"
+
+	for _, tc := range []struct {
+		name   string
+		env    map[string]string
+		raw    map[string]any
+		apiURL string
+		errVal string
+	}{
+		{
+			name:   "realm and api url set within the environment",
+			env:    map[string]string{"SFX_REALM": "eu0", "SFX_API_URL": "https://api.us1.signalfx.com"},
+			raw:    map[string]any{"auth_token": "XXX"},
+			apiURL: "https://api.eu0.signalfx.com",
+		},
+		{
+			name:   "api url configured with the realm set within the environment",
+			env:    map[string]string{"SFX_REALM": "eu0", "SFX_API_URL": ""},
+			raw:    map[string]any{"auth_token": "XXX", "api_url": "https://api.us1.signalfx.com"},
+			errVal: "only one of 'realm' or 'api_url' can be set, this includes the realm set by the SFX_REALM environment variable",
+		},
+		{
+			name:   "api url configured",
+			env:    map[string]string{"SFX_REALM": "", "SFX_API_URL": ""},
+			raw:    map[string]any{"auth_token": "XXX", "api_url": "https://api.us1.signalfx.com"},
+			apiURL: "https://api.us1.signalfx.com",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SFX_CUSTOM_APP_URL", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			rp := Provider()
+			block := schema.InternalMap(rp.Schema).CoreConfigSchema()
+			raw, err := json.Marshal(tc.raw)
+			require.NoError(t, err, "Must encode the configuration")
+			cfg, err := ctyjson.Unmarshal(raw, block.ImpliedType())
+			require.NoError(t, err, "Must decode the configuration")
+
+			// As done by the plugin server, the raw configuration is kept alongside the shimmed values.
+			rc := terraform.NewResourceConfigShimmed(cfg, block)
+			rc.CtyValue = cfg
+
+			diags := rp.Configure(t.Context(), rc)
+			if tc.errVal != "" {
+				require.True(t, diags.HasError(), "Must error configuring the provider")
+				assert.Equal(t, tc.errVal, diags[0].Summary, "Must match the expected error")
+				return
+			}
+			require.False(t, diags.HasError(), "Must not error configuring the provider: %v", diags)
+			assert.Equal(t, tc.apiURL, rp.Meta().(*signalfxConfig).APIURL, "Must match the expected api url")
+		})
+	}
+}
+
+func TestProviderConfigureFromCredentialProcess(t *testing.T) {
+	defer resetGlobals()
+	SystemConfigPath = "filedoesnotexist"
+	HomeConfigPath = "filedoesnotexist"
+
+	t.Setenv("SFX_AUTH_TOKEN", "ZZZ")
+	t.Setenv("SFX_API_URL", "")
+	t.Setenv("SFX_CUSTOM_APP_URL", "")
+	t.Setenv("SFX_REALM", "")
+
+	raw := map[string]interface{}{
+		"credential_process": `echo '{"auth_token":"XXX","api_url":"https://api.eu0.signalfx.com"}'`,
+	}
+
+	rp := Provider()
+	diag := rp.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	meta := rp.Meta()
+	if meta == nil {
+		t.Fatalf("Expected metadata, got nil. err: %s", spew.Sdump(diag))
+	}
+	configuration := meta.(*signalfxConfig)
+	assert.Equal(t, "XXX", configuration.AuthToken)
+	assert.Equal(t, "https://api.eu0.signalfx.com", configuration.APIURL)
+	assert.NotNil(t, configuration.Credentials)
+
+	rp = Provider()
+	diag = rp.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"credential_process": "exit 1",
+	}))
+	require.True(t, diag.HasError(), "Must error when the credential process fails")
+	assert.Equal(t, "credential process failed: exit status 1", diag[0].Summary)
+}
+
+func TestProviderValidateRealm(t *testing.T) {
+	t.Setenv("SFX_REALM", "")
+
+	for _, tc := range []struct {
+		name   string
+		raw    map[string]any
+		errVal string
+	}{
+		{name: "realm set", raw: map[string]any{"realm": "eu0"}},
+		{name: "invalid realm", raw: map[string]any{"realm": "api.eu0"}, errVal: "must be a realm name such as us1"},
+		{name: "realm and api url set", raw: map[string]any{"realm": "eu0", "api_url": "https://api.eu0.signalfx.com"}, errVal: `"realm": conflicts with api_url`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(tc.raw))
+			if tc.errVal == "" {
+				assert.False(t, diags.HasError(), "Must not error validating the configuration")
+				return
+			}
+			require.True(t, diags.HasError(), "Must error validating the configuration")
+			assert.Contains(t, diags[0].Detail+diags[0].Summary, tc.errVal, "Must match the expected error")
+		})
+	}
+}
+
 func TestProviderConfigureFromEnvironment(t *testing.T) {
 	defer resetGlobals()
 	tmpfileSystem, err := createTempConfigFile(t, `{"useless_config":"foo","auth_token":"ZZZ"}`, "signalfx.conf")
@@ -326,6 +473,47 @@ func TestSignalFxConfigureFromHomeFileOnly(t *testing.T) {
 	assert.Equal(t, "https://app.signalfx.com", configuration.CustomAppURL)
 }
 
+func TestSignalFxConfigureFromHomeFileProfile(t *testing.T) {
+	defer resetGlobals()
+	SystemConfigPath = "filedoesnotexist"
+	tmpfileHome, err := createTempConfigFile(t, `{
+		"auth_token": "WWW",
+		"profiles": {
+			"default": {"auth_token": "XXX"},
+			"staging": {"auth_token": "YYY", "realm": "eu0"}
+		}
+	}`, "signalfx.conf")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.Remove(tmpfileHome.Name())
+	HomeConfigPath = tmpfileHome.Name()
+
+	t.Setenv("SFX_AUTH_TOKEN", "")
+	t.Setenv("SFX_API_URL", "")
+	t.Setenv("SFX_CUSTOM_APP_URL", "")
+	t.Setenv("SFX_REALM", "")
+	t.Setenv("SFX_PROFILE", "staging")
+
+	rp := Provider()
+	diag := rp.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	meta := rp.Meta()
+	if meta == nil {
+		t.Fatalf("Expected metadata, got nil. err: %s", spew.Sdump(diag))
+	}
+	configuration := meta.(*signalfxConfig)
+	assert.Equal(t, "YYY", configuration.AuthToken)
+	assert.Equal(t, "https://api.eu0.signalfx.com", configuration.APIURL)
+	assert.Equal(t, "https://app.eu0.signalfx.com", configuration.CustomAppURL)
+
+	rp = Provider()
+	diag = rp.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"profile": "production",
+	}))
+	require.True(t, diag.HasError(), "Must error when the profile is not defined")
+	assert.Contains(t, diag[0].Summary, `profile "production" is not defined`)
+}
+
 func TestSignalFxConfigureFromSystemFileOnly(t *testing.T) {
 	defer resetGlobals()
 
@@ -5,7 +5,6 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -19,6 +18,7 @@ import (
 	"github.com/signalfx/signalfx-go"
 	"github.com/signalfx/signalfx-go/integration"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 	"go.uber.org/multierr"
 )
 
@@ -139,9 +139,23 @@ func integrationAWSResource() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"role_arn", "external_id"},
+				ConflictsWith: []string{"role_arn", "external_id", "key_wo"},
 				Description:   "Used with `signalfx_aws_token_integration`. Use this property to specify the token.",
 			},
+			"key_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"role_arn", "external_id", "key"},
+				Description:   "Used with `signalfx_aws_token_integration`. Write-only variant of `key`, the value is never stored in state. Requires Terraform 1.11 or later.",
+			},
+			"key_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"key_wo"},
+				Description:  "Used to trigger an update of `key_wo`, increment the value whenever the key changes.",
+			},
 			"regions": {
 				Type:     schema.TypeSet,
 				Required: true,
@@ -301,8 +315,7 @@ func integrationAWSRead(d *schema.ResourceData, meta any) error {
 }
 
 func awsIntegrationAPIToTF(d *schema.ResourceData, aws *integration.AwsCloudWatchIntegration) error {
-	debugOutput, _ := json.Marshal(aws)
-	log.Printf("[DEBUG] SignalFx: Got AWS Integration to enState: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Got AWS Integration to enState: %s", tfext.RedactedJSON(aws, awsSensitiveFields...))
 
 	err := multierr.Combine(
 		d.Set("integration_id", aws.Id),
@@ -328,12 +341,16 @@ func awsIntegrationAPIToTF(d *schema.ResourceData, aws *integration.AwsCloudWatc
 			return err
 		}
 	}
+	// When the write only key is used, the token is kept in state without the key
+	// and the returned value must not be stored. This must be checked before the token is updated,
+	// otherwise an imported integration would never store the key.
+	storeKey := d.Get("key").(string) != "" || d.Get("token").(string) == ""
 	if aws.Token != "" {
 		if err := d.Set("token", aws.Token); err != nil {
 			return err
 		}
 	}
-	if aws.Key != "" {
+	if aws.Key != "" && storeKey {
 		if err := d.Set("key", aws.Key); err != nil {
 			return err
 		}
@@ -432,6 +449,9 @@ func awsIntegrationAPIToTF(d *schema.ResourceData, aws *integration.AwsCloudWatc
 	return nil
 }
 
+// awsSensitiveFields are redacted from the logged payloads.
+var awsSensitiveFields = []string{"key"}
+
 func getPayloadAWSIntegration(d *schema.ResourceData) (*integration.AwsCloudWatchIntegration, error) {
 
 	aws := &integration.AwsCloudWatchIntegration{
@@ -457,9 +477,13 @@ func getPayloadAWSIntegration(d *schema.ResourceData) (*integration.AwsCloudWatc
 		aws.ExternalId = d.Get("external_id").(string)
 		aws.RoleArn = d.Get("role_arn").(string)
 	} else if d.Get("token").(string) != "" {
+		key, err := tfext.GetStringOrWriteOnly(d, "key", "key_wo")
+		if err != nil {
+			return nil, err
+		}
 		aws.AuthMethod = integration.SECURITY_TOKEN
 		aws.Token = d.Get("token").(string)
-		aws.Key = d.Get("key").(string)
+		aws.Key = key
 	} else {
 		return nil, fmt.Errorf("Please specify one of `external_id` or `token` and `key`")
 	}
@@ -632,8 +656,7 @@ func integrationAWSUpdate(d *schema.ResourceData, meta any) error {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update AWS Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Update AWS Integration Payload: %s", tfext.RedactedJSON(payload, awsSensitiveFields...))
 
 	int, err := config.Client.UpdateAWSCloudWatchIntegration(context.TODO(), d.Id(), payload)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const newIntegrationAWSConfig = `
@@ -371,3 +372,48 @@ func skipTestWhenAWSCredentialsAreMissing(t *testing.T, awsAccessKeyID, awsSecre
 		return true, nil
 	}
 }
+
+func TestIntegrationAWSWriteOnlyKey(t *testing.T) {
+	t.Parallel()
+
+	ts, meta := newWriteOnlyTestServer(t, map[string]any{
+		"id":   "integration-id",
+		"name": "AWS",
+		"type": "AWSCloudWatch",
+	})
+
+	state := applyWriteOnlyConfig(t, integrationAWSResource(), meta, map[string]any{
+		"integration_id": "integration-id",
+		"enabled":        true,
+		"regions":        []any{"us-east-1"},
+		"token":          "my-token",
+		"key_wo":         "my-key",
+	})
+
+	payload := ts.Payload()
+	assert.Equal(t, "my-token", payload["token"], "Must send the token")
+	assert.Equal(t, "my-key", payload["key"], "Must send the write only key")
+	assert.Equal(t, "my-token", state.Attributes["token"], "Must store the token")
+	assert.Empty(t, state.Attributes["key"], "Must not store the key")
+	assert.Empty(t, state.Attributes["key_wo"], "Must not store the write only key")
+}
+
+func TestIntegrationAWSImportKey(t *testing.T) {
+	t.Parallel()
+
+	_, meta := newWriteOnlyTestServer(t, map[string]any{
+		"id":         "integration-id",
+		"name":       "AWS",
+		"type":       "AWSCloudWatch",
+		"authMethod": "SecurityToken",
+		"token":      "my-token",
+		"key":        "my-key",
+	})
+
+	r := integrationAWSResource()
+	d := r.Data(&terraform.InstanceState{ID: "integration-id"})
+	require.NoError(t, integrationAWSRead(d, meta), "Must read the integration")
+
+	assert.Equal(t, "my-token", d.Get("token"), "Must store the token")
+	assert.Equal(t, "my-key", d.Get("key"), "Must store the key of an imported integration")
+}
@@ -5,7 +5,6 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -13,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/signalfx/signalfx-go/integration"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 func integrationAzureResource() *schema.Resource {
@@ -65,10 +66,24 @@ func integrationAzureResource() *schema.Resource {
 				Description: "Allows for more fine-grained control of syncing of custom namespaces, should the boolean convenience parameter `sync_guest_os_namespaces` be not enough. The customer may specify a map of services to custom namespaces. If they do so, for each service which is a key in this map, we will attempt to sync metrics from namespaces in the value list in addition to the default namespaces.",
 			},
 			"secret_key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"secret_key", "secret_key_wo"},
+				Description:  "Azure secret key that associates the Splunk Observability Cloud app in Azure with the Azure tenant.",
+			},
+			"secret_key_wo": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Azure secret key that associates the Splunk Observability Cloud app in Azure with the Azure tenant.",
+				WriteOnly:   true,
+				Description: "Write-only Azure secret key that associates the Splunk Observability Cloud app in Azure with the Azure tenant, the value is never stored in state. Requires Terraform 1.11 or later.",
+			},
+			"secret_key_wo_version": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"secret_key_wo"},
+				Description:  "Used to trigger an update of `secret_key_wo`, increment the value whenever the secret key changes.",
 			},
 			"poll_rate": &schema.Schema{
 				Type:         schema.TypeInt,
@@ -171,8 +186,7 @@ func integrationAzureRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func azureIntegrationAPIToTF(d *schema.ResourceData, azure *integration.AzureIntegration) error {
-	debugOutput, _ := json.Marshal(azure)
-	log.Printf("[DEBUG] SignalFx: Got Azure Integration to enState: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Got Azure Integration to enState: %s", tfext.RedactedJSON(azure, azureSensitiveFields...))
 
 	if err := d.Set("name", azure.Name); err != nil {
 		return err
@@ -262,6 +276,11 @@ func azureIntegrationAPIToTF(d *schema.ResourceData, azure *integration.AzureInt
 }
 
 func getPayloadAzureIntegration(d *schema.ResourceData) (*integration.AzureIntegration, error) {
+	secretKey, err := tfext.GetStringOrWriteOnly(d, "secret_key", "secret_key_wo")
+	if err != nil {
+		return nil, err
+	}
+
 	importAzureMonitor := d.Get("import_azure_monitor").(bool)
 	useBatchApi := d.Get("use_batch_api").(bool)
 	azure := &integration.AzureIntegration{
@@ -270,7 +289,7 @@ func getPayloadAzureIntegration(d *schema.ResourceData) (*integration.AzureInteg
 		Enabled:               d.Get("enabled").(bool),
 		AppId:                 d.Get("app_id").(string),
 		AzureEnvironment:      integration.AzureEnvironment(strings.ToUpper(d.Get("environment").(string))),
-		SecretKey:             d.Get("secret_key").(string),
+		SecretKey:             secretKey,
 		TenantId:              d.Get("tenant_id").(string),
 		SyncGuestOsNamespaces: d.Get("sync_guest_os_namespaces").(bool),
 		ImportAzureMonitor:    &importAzureMonitor,
@@ -344,6 +363,9 @@ func getPayloadAzureIntegration(d *schema.ResourceData) (*integration.AzureInteg
 	return azure, nil
 }
 
+// azureSensitiveFields are redacted from the logged payloads.
+var azureSensitiveFields = []string{"secretKey"}
+
 func integrationAzureCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
 	payload, err := getPayloadAzureIntegration(d)
@@ -351,8 +373,7 @@ func integrationAzureCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Create Azure Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Create Azure Integration Payload: %s", tfext.RedactedJSON(payload, azureSensitiveFields...))
 
 	int, err := config.Client.CreateAzureIntegration(context.TODO(), payload)
 	if err != nil {
@@ -373,8 +394,7 @@ func integrationAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update Azure Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Update Azure Integration Payload: %s", tfext.RedactedJSON(payload, azureSensitiveFields...))
 
 	int, err := config.Client.UpdateAzureIntegration(context.TODO(), d.Id(), payload)
 	if err != nil {
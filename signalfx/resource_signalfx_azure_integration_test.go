@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 const newIntegrationAzureConfig = `
@@ -144,3 +145,24 @@ func testAccIntegrationAzureDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestIntegrationAzureWriteOnlySecretKey(t *testing.T) {
+	t.Parallel()
+
+	ts, meta := newWriteOnlyTestServer(t, nil)
+
+	state := applyWriteOnlyConfig(t, integrationAzureResource(), meta, map[string]any{
+		"name":          "Azure",
+		"enabled":       true,
+		"app_id":        "my-app",
+		"tenant_id":     "my-tenant",
+		"services":      []any{"microsoft.sql/servers/elasticpools"},
+		"subscriptions": []any{"my-subscription"},
+		"secret_key_wo": "my-secret",
+	})
+
+	assert.Equal(t, "my-secret", ts.Payload()["secretKey"], "Must send the write only secret key")
+	assert.Equal(t, "integration-id", state.ID, "Must create the integration")
+	assert.Empty(t, state.Attributes["secret_key"], "Must not store the secret key")
+	assert.Empty(t, state.Attributes["secret_key_wo"], "Must not store the write only secret key")
+}
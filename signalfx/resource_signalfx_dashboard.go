@@ -883,6 +883,9 @@ func dashboardAPIToTF(d *schema.ResourceData, dash *dashboard.Dashboard) error {
 	if err := d.Set("description", dash.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", dash.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("charts_resolution", strings.ToLower(string(*dash.ChartDensity))); err != nil {
 		return err
 	}
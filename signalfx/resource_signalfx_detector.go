@@ -21,7 +21,10 @@ import (
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/visual"
 )
 
@@ -48,14 +51,16 @@ var (
 			Description: "Description of the rule",
 		},
 		"notifications": {
-			Type:     schema.TypeList,
-			Optional: true,
+			Type:      schema.TypeList,
+			Optional:  true,
+			Sensitive: true,
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
 			},
 			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": notify.NewSchema("Where notifications will be sent when an incident occurs, set using a nested block for each notification type. Can not be set with notifications"),
 		"disabled": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -129,10 +134,13 @@ func detectorResource() *schema.Resource {
 				Description: "Name of the detector",
 			},
 			"program_text": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
-				ValidateFunc: validation.StringLenBetween(1, 50000),
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+				ValidateDiagFunc: validation.AllDiag(
+					validation.ToDiagFunc(validation.StringLenBetween(1, 50000)),
+					check.ProgramText(),
+				),
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -209,8 +217,11 @@ func detectorResource() *schema.Resource {
 				Description: "Team IDs to associate the detector to",
 			},
 			"rule": {
-				Type:        schema.TypeSet,
-				Required:    true,
+				Type: schema.TypeSet,
+				// Computed allows the provider default rule to be merged into the plan,
+				// the block is still required by check.RequiredBlock.
+				Optional:    true,
+				Computed:    true,
 				Description: "Set of rules used for alerting",
 				Elem: &schema.Resource{
 					Schema: detectorRuleSchema,
@@ -306,7 +317,16 @@ func detectorResource() *schema.Resource {
 			},
 		},
 
-		CustomizeDiff: customdiff.If(validateProgramTextCondition, validateProgramText),
+		CustomizeDiff: customdiff.All(
+			rule.CustomizeDiffDefault,
+			rule.CustomizeDiffNotifications,
+			customdiff.If(validateProgramTextCondition, validateProgramText),
+		),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.RequiredBlock("rule"),
+			check.DetectLabels(),
+			check.PublishLabels("viz_options"),
+		},
 
 		Create: detectorCreate,
 		Read:   detectorRead,
@@ -457,13 +477,11 @@ func getDetectorRule(tfRule map[string]any) (*detector.Rule, error) {
 		rule.Tip = val.(string)
 	}
 
-	if notifications, ok := tfRule["notifications"]; ok {
-		notify, err := common.NewNotificationList(notifications.([]any))
-		if err != nil {
-			return nil, err
-		}
-		rule.Notifications = notify
+	notifys, err := notify.Notifications.Decode(notify.FromMap(tfRule))
+	if err != nil {
+		return nil, err
 	}
+	rule.Notifications = notifys
 
 	reminder := convert.ToReminderNotification(tfRule)
 	if reminder != nil {
@@ -559,8 +577,9 @@ func detectorCreate(d *schema.ResourceData, meta any) error {
 		payload.Teams,
 	)
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Create Detector Payload: %s", string(debugOutput))
+	payload.Rules = pmeta.MergeProviderDefaultRule(context.TODO(), meta, payload.Rules)
+
+	log.Printf("[DEBUG] SignalFx: Create Detector Payload: %s", tfext.RedactedJSON(payload))
 
 	det, err := config.Client.CreateDetector(context.TODO(), payload)
 	if err != nil {
@@ -714,12 +733,16 @@ func detectorAPIToTF(d *schema.ResourceData, det *detector.Detector) error {
 		}
 	}
 
+	// The notifications of each rule are kept in the form used by the prior state.
+	prior := notify.ByKey(d.Get("rule").(*schema.Set).List(), "detect_label")
+
 	rules := make([]map[string]any, len(det.Rules))
 	for i, r := range det.Rules {
 		rule, err := getTfDetectorRule(r)
 		if err != nil {
 			return err
 		}
+		notify.Notifications.Select(rule, notify.FromMap(prior[r.DetectLabel]))
 		rules[i] = rule
 	}
 	if err := d.Set("rule", rules); err != nil {
@@ -729,21 +752,16 @@ func detectorAPIToTF(d *schema.ResourceData, det *detector.Detector) error {
 	return nil
 }
 
+// getTfDetectorRule converts the rule with the notifications set in both forms,
+// the caller uses [notify.Field.Select] to keep the one that was configured.
 func getTfDetectorRule(r *detector.Rule) (map[string]any, error) {
-	rule := make(map[string]any)
+	rule, err := notify.Notifications.Encode(r.Notifications)
+	if err != nil {
+		return nil, err
+	}
 	rule["severity"] = r.Severity
 	rule["detect_label"] = r.DetectLabel
 	rule["description"] = r.Description
-
-	notifications := make([]string, len(r.Notifications))
-	for i, not := range r.Notifications {
-		tfNot, err := common.NewNotificationStringFromAPI(not)
-		if err != nil {
-			return nil, err
-		}
-		notifications[i] = tfNot
-	}
-	rule["notifications"] = notifications
 	rule["disabled"] = r.Disabled
 	rule["parameterized_body"] = r.ParameterizedBody
 	rule["parameterized_subject"] = r.ParameterizedSubject
@@ -775,8 +793,15 @@ func detectorUpdate(d *schema.ResourceData, meta any) error {
 		payload.Tags,
 	)
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update Detector Payload: %s", string(debugOutput))
+	payload.Teams = pmeta.MergeProviderTeams(
+		context.TODO(),
+		meta,
+		payload.Teams,
+	)
+
+	payload.Rules = pmeta.MergeProviderDefaultRule(context.TODO(), meta, payload.Rules)
+
+	log.Printf("[DEBUG] SignalFx: Update Detector Payload: %s", tfext.RedactedJSON(payload))
 
 	det, err := config.Client.UpdateDetector(context.TODO(), d.Id(), payload)
 	if err != nil {
@@ -884,20 +909,10 @@ func resourceRuleHash(v any) int {
 		}
 	}
 
-	// Sort the notifications so that we generate a consistent hash
-	if v, ok := m["notifications"]; ok {
-		notifications := v.([]any)
-		s_notifications := make([]string, len(notifications))
-		for i, raw := range notifications {
-			if raw != nil {
-				s_notifications[i] = raw.(string)
-			}
-		}
-		sort.Strings(s_notifications)
-
-		for _, notification := range s_notifications {
-			buf.WriteString(fmt.Sprintf("%s-", notification))
-		}
+	// Sort the notifications so that we generate a consistent hash,
+	// both forms of the same notifications produce the same values.
+	for _, notification := range notify.Notifications.HashValues(m) {
+		buf.WriteString(fmt.Sprintf("%s-", notification))
 	}
 
 	if states, ok := m["skip_clear_notification_states"].(*schema.Set); ok {
@@ -5,15 +5,24 @@ package signalfx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sfx "github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/detector"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
 )
 
 func TestResourceRuleHash(t *testing.T) {
@@ -107,6 +116,27 @@ func TestChangesInReminderNotificationRuleHashing(t *testing.T) {
 	assert.NotEqual(t, hashWithChangedTimeout, hashWithoutReminder)
 }
 
+func TestNotificationBlocksInRuleHashing(t *testing.T) {
+	values := map[string]any{
+		"description":   "Test Rule Name",
+		"detect_label":  "Test Detect Label",
+		"severity":      "Critical",
+		"disabled":      "true",
+		"notifications": []any{"Slack,credId,alerts"},
+	}
+	hashWithStrings := resourceRuleHash(values)
+
+	delete(values, "notifications")
+	values["notification"] = []any{
+		map[string]any{
+			"slack": []any{map[string]any{"credential_id": "credId", "channel": "alerts"}},
+		},
+	}
+	expected := HashCodeString("Test Rule Name-Critical-Test Detect Label-true-Slack,credId,alerts-")
+	assert.Equal(t, expected, resourceRuleHash(values))
+	assert.Equal(t, hashWithStrings, resourceRuleHash(values))
+}
+
 func TestValidateSeverityAllowed(t *testing.T) {
 	_, errors := validateSeverity("Critical", "severity")
 	assert.Equal(t, len(errors), 0)
@@ -606,3 +636,53 @@ func TestSerializeReminderToString(t *testing.T) {
 		assert.Equal(t, expected, serializeReminderToString(reminder))
 	})
 }
+
+func TestDetectorUpdateProviderTeams(t *testing.T) {
+	t.Parallel()
+
+	var payload detector.CreateUpdateDetectorRequest
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v2/detector/detector-id" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&detector.Detector{
+			Id:          "detector-id",
+			Name:        payload.Name,
+			ProgramText: payload.ProgramText,
+			Rules:       payload.Rules,
+			Teams:       payload.Teams,
+		})
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := sfx.NewClient("token", sfx.HTTPClient(s.Client()), sfx.APIUrl(s.URL))
+	require.NoError(t, err, "Must create the client")
+
+	reg := feature.NewRegistry()
+	_ = reg.MustRegister(feature.PreviewProviderTeams, feature.WithPreviewGlobalAvailable())
+
+	meta := &signalfxConfig{
+		Client:       client,
+		CustomAppURL: "https://app.signalfx.com",
+		Registry:     reg,
+		Teams:        []string{"provider-team"},
+	}
+
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, map[string]any{
+		"name":         "example detector",
+		"program_text": "detect(when(data('cpu.utilization').mean() > 90)).publish('cpu')",
+		"teams":        []any{"configured-team"},
+		"rule": []any{
+			map[string]any{"detect_label": "cpu", "severity": "Critical"},
+		},
+	})
+	d.SetId("detector-id")
+
+	require.NoError(t, detectorUpdate(d, meta), "Must update the detector")
+	assert.Equal(t, []string{"provider-team", "configured-team"}, payload.Teams, "Must merge the provider teams into the update")
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	chart "github.com/signalfx/signalfx-go/chart"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 func eventFeedChartResource() *schema.Resource {
@@ -103,6 +107,7 @@ func getPayloadEventFeedChart(d *schema.ResourceData) *chart.CreateUpdateChartRe
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		ProgramText: d.Get("program_text").(string),
+		Tags:        convert.SchemaListAll(d.Get("tags"), convert.ToString),
 		Options: &chart.Options{
 			Time: timeOptions,
 			Type: "Event",
@@ -114,6 +119,11 @@ func eventFeedChartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
 	payload := getPayloadEventFeedChart(d)
 
+	payload.Tags = common.Unique(
+		pmeta.LoadProviderTags(context.Background(), meta),
+		payload.Tags,
+	)
+
 	debugOutput, _ := json.Marshal(payload)
 	log.Printf("[DEBUG] SignalFx: Create Event Feed Chart Payload: %s", string(debugOutput))
 
@@ -146,6 +156,9 @@ func eventfeedchartAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("program_text", c.ProgramText); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 
 	options := c.Options
 
@@ -199,6 +212,12 @@ func eventFeedChartRead(d *schema.ResourceData, meta interface{}) error {
 func eventFeedChartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
 	payload := getPayloadEventFeedChart(d)
+
+	payload.Tags = common.Unique(
+		pmeta.LoadProviderTags(context.Background(), meta),
+		payload.Tags,
+	)
+
 	debugOutput, _ := json.Marshal(payload)
 	log.Printf("[DEBUG] SignalFx: Update Event Feed Chart Payload: %s", string(debugOutput))
 
@@ -5,15 +5,16 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/signalfx/signalfx-go/integration"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 func integrationGCPResource() *schema.Resource {
@@ -76,7 +77,33 @@ func integrationGCPResource() *schema.Resource {
 						},
 					},
 				},
-				ConflictsWith: []string{"project_wif_configs"},
+				ConflictsWith: []string{"project_wif_configs", "project_service_keys_wo"},
+			},
+			"project_service_keys_wo": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Write-only variant of `project_service_keys`, the project keys are never stored in state. Requires Terraform 1.11 or later.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"project_key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+					},
+				},
+				ConflictsWith: []string{"project_service_keys", "project_wif_configs"},
+			},
+			"project_service_keys_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"project_service_keys_wo"},
+				Description:  "Used to trigger an update of `project_service_keys_wo`, increment the value whenever a project key changes.",
 			},
 			"project_wif_configs": {
 				Type:        schema.TypeSet,
@@ -95,13 +122,13 @@ func integrationGCPResource() *schema.Resource {
 						},
 					},
 				},
-				ConflictsWith: []string{"project_service_keys"},
+				ConflictsWith: []string{"project_service_keys", "project_service_keys_wo"},
 			},
 			"workload_identity_federation_config": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Description:   "Workload Identity Federation configuration JSON",
-				ConflictsWith: []string{"project_service_keys", "project_wif_configs"},
+				ConflictsWith: []string{"project_service_keys", "project_service_keys_wo", "project_wif_configs"},
 			},
 			"projects": {
 				Type:        schema.TypeList,
@@ -195,7 +222,7 @@ func integrationGCPRead(d *schema.ResourceData, meta any) error {
 	return gcpIntegrationAPIToTF(d, int)
 }
 
-func getGCPPayloadIntegration(d *schema.ResourceData) *integration.GCPIntegration {
+func getGCPPayloadIntegration(d *schema.ResourceData) (*integration.GCPIntegration, error) {
 	importGCPMetrics := d.Get("import_gcp_metrics").(bool)
 	gcp := &integration.GCPIntegration{
 		Name:                           d.Get("name").(string),
@@ -239,6 +266,22 @@ func getGCPPayloadIntegration(d *schema.ResourceData) *integration.GCPIntegratio
 		}
 		gcp.ProjectServiceKeys = serviceKeys
 	}
+	if val, ok := d.GetOk("project_service_keys_wo"); ok {
+		keys := val.([]any)
+		serviceKeys := make([]*integration.GCPProject, len(keys))
+		for i, v := range keys {
+			v := v.(map[string]any)
+			key, err := tfext.GetWriteOnlyString(d, cty.GetAttrPath("project_service_keys_wo").IndexInt(i).GetAttr("project_key"))
+			if err != nil {
+				return nil, err
+			}
+			serviceKeys[i] = &integration.GCPProject{
+				ProjectId:  v["project_id"].(string),
+				ProjectKey: key,
+			}
+		}
+		gcp.ProjectServiceKeys = serviceKeys
+	}
 	if val, ok := d.GetOk("project_wif_configs"); ok {
 		keys := val.(*schema.Set).List()
 		wifConfigs := make([]*integration.GCPProjectWIFConfig, len(keys))
@@ -292,12 +335,11 @@ func getGCPPayloadIntegration(d *schema.ResourceData) *integration.GCPIntegratio
 		gcp.ExcludeGCEInstancesWithLabels = convert.SchemaListAll(val, convert.ToString)
 	}
 
-	return gcp
+	return gcp, nil
 }
 
 func gcpIntegrationAPIToTF(d *schema.ResourceData, gcp *integration.GCPIntegration) error {
-	debugOutput, _ := json.Marshal(gcp)
-	log.Printf("[DEBUG] SignalFx: Got GCP Integration to enState: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Got GCP Integration to enState: %s", tfext.RedactedJSON(gcp, gcpSensitiveFields...))
 
 	if err := d.Set("name", gcp.Name); err != nil {
 		return err
@@ -391,18 +433,17 @@ func gcpIntegrationAPIToTF(d *schema.ResourceData, gcp *integration.GCPIntegrati
 	return nil
 }
 
+// gcpSensitiveFields are redacted from the logged payloads.
+var gcpSensitiveFields = []string{"projectKey"}
+
 func integrationGCPCreate(d *schema.ResourceData, meta any) error {
 	config := meta.(*signalfxConfig)
-	payload := getGCPPayloadIntegration(d)
-
-	// Convert payload to JSON to see what will be sent
-	debugOutput, err := json.MarshalIndent(payload, "", "  ")
+	payload, err := getGCPPayloadIntegration(d)
 	if err != nil {
-		return fmt.Errorf("error marshaling GCP integration payload to JSON for debug: %w", err)
+		return err
 	}
 
-	// Print the JSON payload for debugging purposes
-	log.Printf("[DEBUG] SignalFx: Create GCP Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Create GCP Integration Payload: %s", tfext.RedactedJSON(payload, gcpSensitiveFields...))
 
 	// Make the actual API request to create the GCP Integration
 	int, err := config.Client.CreateGCPIntegration(context.TODO(), payload)
@@ -418,10 +459,12 @@ func integrationGCPCreate(d *schema.ResourceData, meta any) error {
 }
 func integrationGCPUpdate(d *schema.ResourceData, meta any) error {
 	config := meta.(*signalfxConfig)
-	payload := getGCPPayloadIntegration(d)
+	payload, err := getGCPPayloadIntegration(d)
+	if err != nil {
+		return err
+	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update GCP Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Update GCP Integration Payload: %s", tfext.RedactedJSON(payload, gcpSensitiveFields...))
 
 	int, err := config.Client.UpdateGCPIntegration(context.TODO(), d.Id(), payload)
 	if err != nil {
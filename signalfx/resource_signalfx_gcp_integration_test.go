@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 const newIntegrationGCPConfig = `
@@ -147,3 +148,28 @@ func testAccIntegrationGCPDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestIntegrationGCPWriteOnlyProjectServiceKeys(t *testing.T) {
+	t.Parallel()
+
+	ts, meta := newWriteOnlyTestServer(t, nil)
+
+	state := applyWriteOnlyConfig(t, integrationGCPResource(), meta, map[string]any{
+		"name":    "GCP",
+		"enabled": true,
+		"project_service_keys_wo": []any{
+			map[string]any{"project_id": "project-a", "project_key": "key-a"},
+			map[string]any{"project_id": "project-b", "project_key": "key-b"},
+		},
+	})
+
+	assert.Equal(t, []any{
+		map[string]any{"projectId": "project-a", "projectKey": "key-a"},
+		map[string]any{"projectId": "project-b", "projectKey": "key-b"},
+	}, ts.Payload()["projectServiceKeys"], "Must send the write only project keys")
+	assert.Equal(t, "integration-id", state.ID, "Must create the integration")
+	assert.Equal(t, "project-a", state.Attributes["project_service_keys_wo.0.project_id"], "Must store the project id")
+	for k, v := range state.Attributes {
+		assert.NotContains(t, []string{"key-a", "key-b"}, v, "Must not store the project key in %s", k)
+	}
+}
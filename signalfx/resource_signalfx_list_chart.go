@@ -243,6 +243,10 @@ func listChartResource() *schema.Resource {
 			},
 		},
 
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.PublishLabels("viz_options"),
+		},
+
 		Create: listchartCreate,
 		Read:   listchartRead,
 		Update: listchartUpdate,
@@ -440,6 +444,9 @@ func listchartAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("description", c.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("program_text", c.ProgramText); err != nil {
 		return err
 	}
@@ -126,6 +126,9 @@ func logTimelineAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("description", c.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("program_text", c.ProgramText); err != nil {
 		return err
 	}
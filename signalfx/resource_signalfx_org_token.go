@@ -14,9 +14,10 @@ import (
 	"github.com/signalfx/signalfx-go/orgtoken"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
 	vnext "github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/orgtoken"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 var previewVNextOrgToken = feature.GetGlobalRegistry().MustRegister(
@@ -134,14 +135,16 @@ func orgTokenResource() *schema.Resource {
 				},
 			},
 			"notifications": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:      schema.TypeList,
+				Optional:  true,
+				Sensitive: true,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: check.Notification(),
 				},
 				Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 			},
+			"notification": notify.NewSchema("Where notifications will be sent when an incident occurs, set using a nested block for each notification type. Can not be set with notifications"),
 			"secret": &schema.Schema{
 				Type:      schema.TypeString,
 				Computed:  true,
@@ -235,13 +238,11 @@ func getPayloadOrgToken(d *schema.ResourceData) (*orgtoken.CreateUpdateTokenRequ
 		token.Limits = limits
 	}
 
-	if notifications, ok := d.GetOk("notifications"); ok {
-		notify, err := common.NewNotificationList(notifications.([]any))
-		if err != nil {
-			return nil, err
-		}
-		token.Notifications = notify
+	notifys, err := notify.Notifications.Decode(d.Get)
+	if err != nil {
+		return nil, err
 	}
+	token.Notifications = notifys
 
 	return token, nil
 }
@@ -253,8 +254,7 @@ func orgTokenCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Create Org Token Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Create Org Token Payload: %s", tfext.RedactedJSON(payload))
 
 	t, err := config.Client.CreateOrgToken(context.TODO(), payload)
 	if err != nil {
@@ -335,15 +335,16 @@ func orgTokenAPIToTF(d *schema.ResourceData, t *orgtoken.Token) error {
 		}
 	}
 
-	notifications := make([]string, len(t.Notifications))
-	for i, not := range t.Notifications {
-		tfNot, err := common.NewNotificationStringFromAPI(not)
-		if err != nil {
-			return err
-		}
-		notifications[i] = tfNot
+	notifications, err := notify.Notifications.Encode(t.Notifications)
+	if err != nil {
+		return err
+	}
+	// The notifications are kept in the form used by the prior state.
+	notify.Notifications.Select(notifications, d.Get)
+	if err := d.Set("notifications", notifications[notify.Notifications.Strings]); err != nil {
+		return err
 	}
-	if err := d.Set("notifications", notifications); err != nil {
+	if err := d.Set("notification", notifications[notify.Notifications.Blocks]); err != nil {
 		return err
 	}
 
@@ -376,8 +377,7 @@ func orgTokenUpdate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update Org Token Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Update Org Token Payload: %s", tfext.RedactedJSON(payload))
 
 	t, err := config.Client.UpdateOrgToken(context.TODO(), d.Id(), payload)
 	if err != nil {
@@ -17,6 +17,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/signalfx/signalfx-go/integration"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 const (
@@ -44,10 +46,24 @@ func integrationServiceNowResource() *schema.Resource {
 				Description: "User name used to authenticate the ServiceNow integration.",
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "Password used to authenticate the ServiceNow integration.",
+			},
+			"password_wo": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Password used to authenticate the ServiceNow integration.",
+				WriteOnly:   true,
+				Description: "Write-only password used to authenticate the ServiceNow integration, the value is never stored in state. Requires Terraform 1.11 or later.",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Used to trigger an update of `password_wo`, increment the value whenever the password changes.",
 			},
 			"instance_name": {
 				Type:        schema.TypeString,
@@ -82,7 +98,12 @@ func integrationServiceNowResource() *schema.Resource {
 	}
 }
 
-func getServiceNowIntegration(d *schema.ResourceData) *integration.ServiceNowIntegration {
+func getServiceNowIntegration(d *schema.ResourceData) (*integration.ServiceNowIntegration, error) {
+	password, err := tfext.GetStringOrWriteOnly(d, "password", "password_wo")
+	if err != nil {
+		return nil, err
+	}
+
 	snow := &integration.ServiceNowIntegration{
 		Type:         integration.SERVICE_NOW,
 		Name:         d.Get("name").(string),
@@ -90,7 +111,7 @@ func getServiceNowIntegration(d *schema.ResourceData) *integration.ServiceNowInt
 		InstanceName: d.Get("instance_name").(string),
 		IssueType:    d.Get("issue_type").(string),
 		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+		Password:     password,
 	}
 	if val, ok := d.GetOk("alert_triggered_payload_template"); ok {
 		snow.AlertTriggeredPayloadTemplate = val.(string)
@@ -98,7 +119,7 @@ func getServiceNowIntegration(d *schema.ResourceData) *integration.ServiceNowInt
 	if val, ok := d.GetOk("alert_resolved_payload_template"); ok {
 		snow.AlertResolvedPayloadTemplate = val.(string)
 	}
-	return snow
+	return snow, nil
 }
 
 func setServiceNowIntegration(d *schema.ResourceData, snow *integration.ServiceNowIntegration) error {
@@ -139,35 +160,44 @@ func integrationServiceNowRead(d *schema.ResourceData, meta interface{}) error {
 		}
 		return err
 	}
-	logIntegrationResponse(in, serviceNowIntegrationName)
+	logIntegrationResponse(in, serviceNowIntegrationName, serviceNowSensitiveFields...)
 
 	return setServiceNowIntegration(d, in)
 }
 
+// serviceNowSensitiveFields are redacted from the logged payloads.
+var serviceNowSensitiveFields = []string{"password"}
+
 func integrationServiceNowCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
-	out := getServiceNowIntegration(d)
-	logIntegrationCreateRequest(out, serviceNowIntegrationName)
+	out, err := getServiceNowIntegration(d)
+	if err != nil {
+		return err
+	}
+	logIntegrationCreateRequest(out, serviceNowIntegrationName, serviceNowSensitiveFields...)
 
 	in, err := config.Client.CreateServiceNowIntegration(context.TODO(), out)
 	if !handleIntegrationChange(err, d, in) {
 		return err
 	}
-	logIntegrationResponse(in, serviceNowIntegrationName)
+	logIntegrationResponse(in, serviceNowIntegrationName, serviceNowSensitiveFields...)
 
 	return setServiceNowIntegration(d, in)
 }
 
 func integrationServiceNowUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
-	out := getServiceNowIntegration(d)
-	logIntegrationUpdateRequest(out, serviceNowIntegrationName)
+	out, err := getServiceNowIntegration(d)
+	if err != nil {
+		return err
+	}
+	logIntegrationUpdateRequest(out, serviceNowIntegrationName, serviceNowSensitiveFields...)
 
 	in, err := config.Client.UpdateServiceNowIntegration(context.TODO(), d.Id(), out)
 	if !handleIntegrationChange(err, d, in) {
 		return err
 	}
-	logIntegrationResponse(in, serviceNowIntegrationName)
+	logIntegrationResponse(in, serviceNowIntegrationName, serviceNowSensitiveFields...)
 
 	return setServiceNowIntegration(d, in)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 const newIntegrationServiceNowConfig = `
@@ -77,3 +78,23 @@ func TestAccCreateUpdateIntegrationServiceNow(t *testing.T) {
 		},
 	})
 }
+
+func TestIntegrationServiceNowWriteOnlyPassword(t *testing.T) {
+	t.Parallel()
+
+	ts, meta := newWriteOnlyTestServer(t, nil)
+
+	state := applyWriteOnlyConfig(t, integrationServiceNowResource(), meta, map[string]any{
+		"name":          "ServiceNow",
+		"enabled":       true,
+		"username":      "admin",
+		"instance_name": "example.service-now.com",
+		"issue_type":    "Incident",
+		"password_wo":   "my-password",
+	})
+
+	assert.Equal(t, "my-password", ts.Payload()["password"], "Must send the write only password")
+	assert.Equal(t, "integration-id", state.ID, "Must create the integration")
+	assert.Empty(t, state.Attributes["password"], "Must not store the password")
+	assert.Empty(t, state.Attributes["password_wo"], "Must not store the write only password")
+}
@@ -191,6 +191,10 @@ func singleValueChartResource() *schema.Resource {
 			},
 		},
 
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.PublishLabels("viz_options"),
+		},
+
 		Create: singlevaluechartCreate,
 		Read:   singlevaluechartRead,
 		Update: singlevaluechartUpdate,
@@ -318,6 +322,9 @@ func singlevaluechartAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("description", c.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("program_text", c.ProgramText); err != nil {
 		return err
 	}
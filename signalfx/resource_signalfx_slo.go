@@ -6,17 +6,24 @@ package signalfx
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/signalfx/signalfx-go"
 	"github.com/signalfx/signalfx-go/detector"
 	"github.com/signalfx/signalfx-go/slo"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/notify"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 const (
@@ -243,7 +250,7 @@ func sloResource() *schema.Resource {
 
 		SchemaVersion: 1,
 
-		CustomizeDiff: sloValidate,
+		CustomizeDiff: customdiff.All(sloValidate, sloVerifyNotifications),
 		CreateContext: sloCreate,
 		ReadContext:   sloRead,
 		UpdateContext: sloUpdate,
@@ -278,6 +285,42 @@ func sloValidate(ctx context.Context, sloObject *schema.ResourceDiff, config int
 	return nil
 }
 
+// sloVerifyNotifications checks that the integration or team used by
+// each notification of the alert rules exists.
+//
+// Requires the feature preview `provider.verify_notifications` to be enabled.
+func sloVerifyNotifications(ctx context.Context, sloObject *schema.ResourceDiff, config interface{}) error {
+	if pmeta.LoadNotificationTargets(ctx, config) == nil {
+		return nil
+	}
+
+	var errs error
+	targets := configElements(sloObject.GetRawConfig(), targetLabel)
+	for i, target := range targets {
+		for j, alertRule := range configElements(target, alertRuleLabel) {
+			for k, rule := range configElements(alertRule, ruleLabel) {
+				name, ns := notify.Notifications.FromConfig(rule)
+				path := fmt.Sprintf("%s[%d].%s[%d].%s[%d].%s", targetLabel, i, alertRuleLabel, j, ruleLabel, k, name)
+				errs = errors.Join(errs, notify.Verify(ctx, config, path, ns))
+			}
+		}
+	}
+	return errs
+}
+
+// configElements returns the elements of the nested block within the raw config,
+// nil is returned when the block is not known yet.
+func configElements(v cty.Value, name string) []cty.Value {
+	if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() || !v.Type().HasAttribute(name) {
+		return nil
+	}
+	items := v.GetAttr(name)
+	if items.IsNull() || !items.IsKnown() {
+		return nil
+	}
+	return items.AsValueSlice()
+}
+
 func sloCreate(ctx context.Context, sloResource *schema.ResourceData, config interface{}) diag.Diagnostics {
 	client := config.(*signalfxConfig).Client
 	payload, err := getPayloadSlo(sloResource)
@@ -285,8 +328,7 @@ func sloCreate(ctx context.Context, sloResource *schema.ResourceData, config int
 		return diag.Errorf("Failed creating SLO json payload: %s", err.Error())
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] Create SLO Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] Create SLO Payload: %s", tfext.RedactedJSON(payload))
 
 	createdSlo, err := client.CreateSlo(ctx, payload)
 	if err != nil {
@@ -307,8 +349,7 @@ func sloUpdate(ctx context.Context, sloResource *schema.ResourceData, config int
 		return diag.Errorf("Failed creating SLO json payload: %s", err.Error())
 	}
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] Update SLO Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] Update SLO Payload: %s", tfext.RedactedJSON(payload))
 
 	updatedSlo, err := client.UpdateSlo(ctx, sloResource.Id(), payload)
 	if err != nil {
@@ -606,6 +647,7 @@ func sloAPIToTF(sloTfResource *schema.ResourceData, sloApiObject *slo.SloObject)
 	if err != nil {
 		return err
 	}
+	selectSloNotifications(sloTfResource.Get(targetLabel), tfTargets)
 
 	if errSet := sloTfResource.Set(targetLabel, tfTargets); errSet != nil {
 		return errSet
@@ -760,6 +802,36 @@ func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
 	return tfAlertRules, nil
 }
 
+// selectSloNotifications keeps the notifications of each rule in the form used by the prior state,
+// the rules are matched using the alert rule type and their position within the alert rule.
+func selectSloNotifications(prior interface{}, tfTargets []map[string]interface{}) {
+	priorRules := make(map[string]map[string]interface{})
+	priorTargets, _ := prior.([]interface{})
+	for _, t := range priorTargets {
+		target, _ := t.(map[string]interface{})
+		alertRules, _ := target[alertRuleLabel].([]interface{})
+		for _, a := range alertRules {
+			alertRule, _ := a.(map[string]interface{})
+			rules, _ := alertRule[ruleLabel].([]interface{})
+			for ind, r := range rules {
+				if rule, ok := r.(map[string]interface{}); ok {
+					priorRules[fmt.Sprint(alertRule[typeLabel], "/", ind)] = rule
+				}
+			}
+		}
+	}
+
+	for _, tfTarget := range tfTargets {
+		alertRules, _ := tfTarget[alertRuleLabel].([]map[string]interface{})
+		for _, alertRule := range alertRules {
+			rules, _ := alertRule[ruleLabel].([]map[string]interface{})
+			for ind, rule := range rules {
+				notify.Notifications.Select(rule, notify.FromMap(priorRules[fmt.Sprint(alertRule[typeLabel], "/", ind)]))
+			}
+		}
+	}
+}
+
 type DetectorRuleProvider[Rule DetectorRuleType] func(rule Rule) (detectorRule *detector.Rule)
 
 type RuleParametersProvider[Rule DetectorRuleType] func(rule Rule) []map[string]interface{}
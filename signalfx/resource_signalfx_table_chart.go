@@ -141,6 +141,10 @@ func tableChartResource() *schema.Resource {
 			},
 		},
 
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.PublishLabels("viz_options"),
+		},
+
 		Create: tablechartCreate,
 		Read:   tablechartRead,
 		Update: tablechartUpdate,
@@ -273,6 +277,9 @@ func tablechartAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("description", c.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("program_text", c.ProgramText); err != nil {
 		return err
 	}
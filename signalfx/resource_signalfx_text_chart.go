@@ -109,6 +109,9 @@ func textchartAPIToTF(d *schema.ResourceData, c *chart.Chart) error {
 	if err := d.Set("description", c.Description); err != nil {
 		return err
 	}
+	if err := d.Set("tags", c.Tags); err != nil {
+		return err
+	}
 	if err := d.Set("markdown", c.Options.Markdown); err != nil {
 		return err
 	}
@@ -463,6 +463,11 @@ func timeChartResource() *schema.Resource {
 			},
 		},
 
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.PublishLabels("viz_options"),
+			check.PublishLabels("event_options"),
+		},
+
 		Create: timechartCreate,
 		Read:   timechartRead,
 		Update: timechartUpdate,
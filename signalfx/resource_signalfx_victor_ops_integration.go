@@ -16,6 +16,7 @@ import (
 
 func integrationVictorOpsResource() *schema.Resource {
 	return &schema.Resource{
+		DeprecationMessage: "Please use signalfx_integration_splunk_oncall instead, existing resources can be moved using a moved block",
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
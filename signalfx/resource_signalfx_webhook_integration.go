@@ -5,13 +5,14 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go/integration"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 func integrationWebhookResource() *schema.Resource {
@@ -122,8 +123,7 @@ func integrationWebhookRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func webhookIntegrationAPIToTF(d *schema.ResourceData, og *integration.WebhookIntegration) error {
-	debugOutput, _ := json.Marshal(og)
-	log.Printf("[DEBUG] SignalFx: Got Webhook Integration to enState: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Got Webhook Integration to enState: %s", tfext.RedactedJSON(og))
 
 	if err := d.Set("name", og.Name); err != nil {
 		return err
@@ -164,8 +164,7 @@ func integrationWebhookCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
 	payload := getWebhookPayloadIntegration(d)
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Create Webhook Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Create Webhook Integration Payload: %s", tfext.RedactedJSON(payload))
 
 	int, err := config.Client.CreateWebhookIntegration(context.TODO(), payload)
 	if err != nil {
@@ -183,8 +182,7 @@ func integrationWebhookUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalfxConfig)
 	payload := getWebhookPayloadIntegration(d)
 
-	debugOutput, _ := json.Marshal(payload)
-	log.Printf("[DEBUG] SignalFx: Update Webhook Integration Payload: %s", string(debugOutput))
+	log.Printf("[DEBUG] SignalFx: Update Webhook Integration Payload: %s", tfext.RedactedJSON(payload))
 
 	int, err := config.Client.UpdateWebhookIntegration(context.TODO(), d.Id(), payload)
 	if err != nil {
@@ -0,0 +1,205 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalfx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/signalfx/signalfx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOnlyTestServer is a minimal integration API that records
+// the payloads sent by the resource and returns them on read.
+type writeOnlyTestServer struct {
+	mu       sync.Mutex
+	stored   map[string]any
+	payloads []map[string]any
+}
+
+func newWriteOnlyTestServer(t *testing.T, stored map[string]any) (*writeOnlyTestServer, *signalfxConfig) {
+	t.Helper()
+
+	ts := &writeOnlyTestServer{stored: stored}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ts.payloads = append(ts.payloads, payload)
+			ts.stored = make(map[string]any, len(payload)+1)
+			for k, v := range payload {
+				ts.stored[k] = v
+			}
+			ts.stored["id"] = "integration-id"
+		case http.MethodGet:
+			if ts.stored == nil {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ts.stored)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := signalfx.NewClient("token", signalfx.HTTPClient(s.Client()), signalfx.APIUrl(s.URL))
+	require.NoError(t, err, "Must create the client")
+
+	return ts, &signalfxConfig{Client: client}
+}
+
+// Payload returns the last payload sent to the API.
+func (ts *writeOnlyTestServer) Payload() map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if len(ts.payloads) == 0 {
+		return nil
+	}
+	return ts.payloads[len(ts.payloads)-1]
+}
+
+// applyWriteOnlyConfig creates the resource using the provided configuration.
+// As done by Terraform, the write only values are removed from the plan
+// and are only available within the raw configuration.
+func applyWriteOnlyConfig(t *testing.T, r *schema.Resource, meta any, config map[string]any) *terraform.InstanceState {
+	t.Helper()
+
+	raw, err := json.Marshal(config)
+	require.NoError(t, err, "Must encode the configuration")
+	cfg, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err, "Must decode the raw configuration")
+
+	diff, err := r.Diff(t.Context(), nil, terraform.NewResourceConfigRaw(withoutWriteOnly(r.Schema, config)), meta)
+	require.NoError(t, err, "Must plan the resource")
+	diff.RawConfig = cfg
+
+	state, diags := r.Apply(t.Context(), nil, diff, meta)
+	require.False(t, diags.HasError(), "Must create the resource: %v", diags)
+	return state
+}
+
+func withoutWriteOnly(s map[string]*schema.Schema, config map[string]any) map[string]any {
+	values := make(map[string]any, len(config))
+	for k, v := range config {
+		sch, ok := s[k]
+		switch {
+		case ok && sch.WriteOnly:
+			continue
+		case ok && sch.Type == schema.TypeList:
+			if elem, ok := sch.Elem.(*schema.Resource); ok {
+				var items []any
+				for _, item := range v.([]any) {
+					items = append(items, withoutWriteOnly(elem.Schema, item.(map[string]any)))
+				}
+				v = items
+			}
+		}
+		values[k] = v
+	}
+	return values
+}
+
+func TestIntegrationWriteOnlyPayloadLogs(t *testing.T) {
+	// The standard logger is replaced, so the test can not run in parallel.
+	var buf bytes.Buffer
+	prior := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(prior) })
+
+	for _, tc := range []struct {
+		name     string
+		resource *schema.Resource
+		config   map[string]any
+		secrets  []string
+	}{
+		{
+			name:     "aws",
+			resource: integrationAWSResource(),
+			config: map[string]any{
+				"integration_id": "integration-id",
+				"enabled":        true,
+				"regions":        []any{"us-east-1"},
+				"token":          "my-token",
+				"key_wo":         "my-aws-key",
+			},
+			secrets: []string{"my-aws-key"},
+		},
+		{
+			name:     "azure",
+			resource: integrationAzureResource(),
+			config: map[string]any{
+				"name":          "Azure",
+				"enabled":       true,
+				"app_id":        "my-app",
+				"tenant_id":     "my-tenant",
+				"services":      []any{"microsoft.sql/servers/elasticpools"},
+				"subscriptions": []any{"my-subscription"},
+				"secret_key_wo": "my-azure-secret",
+			},
+			secrets: []string{"my-azure-secret"},
+		},
+		{
+			name:     "gcp",
+			resource: integrationGCPResource(),
+			config: map[string]any{
+				"name":    "GCP",
+				"enabled": true,
+				"project_service_keys_wo": []any{
+					map[string]any{"project_id": "project-a", "project_key": "my-gcp-key"},
+				},
+			},
+			secrets: []string{"my-gcp-key"},
+		},
+		{
+			name:     "servicenow",
+			resource: integrationServiceNowResource(),
+			config: map[string]any{
+				"name":          "ServiceNow",
+				"enabled":       true,
+				"username":      "admin",
+				"instance_name": "example.service-now.com",
+				"issue_type":    "Incident",
+				"password_wo":   "my-servicenow-password",
+			},
+			secrets: []string{"my-servicenow-password"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+
+			var stored map[string]any
+			if tc.name == "aws" {
+				stored = map[string]any{"id": "integration-id", "name": "AWS", "type": "AWSCloudWatch"}
+			}
+			_, meta := newWriteOnlyTestServer(t, stored)
+			applyWriteOnlyConfig(t, tc.resource, meta, tc.config)
+
+			logs := buf.String()
+			assert.Contains(t, logs, "Integration Payload", "Must log the payload")
+			for _, secret := range tc.secrets {
+				assert.NotContains(t, logs, secret, "Must not log the write only value")
+			}
+		})
+	}
+}